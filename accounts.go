@@ -0,0 +1,177 @@
+package zoho
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"strings"
+)
+
+// RevokeToken revokes the refresh token, and with it every access token generated from it, then removes the
+// tokens from memory and replaces the saved tokens with empty tokens. When there is no refresh token, eg. when
+// using client credentials, the access token is revoked. Stores which can not save the empty tokens, eg. EnvStore,
+// still return the revoked tokens, they are ignored by z until it is given other tokens.
+// https://www.zoho.com/accounts/protocol/oauth/web-apps/revoke-token.html
+func (z *Zoho) RevokeToken() error {
+	t := z.token()
+	token := t.RefreshToken
+	if token == "" {
+		token = t.AccessToken
+	}
+	if token == "" {
+		return fmt.Errorf("Failed to revoke token: no token is held")
+	}
+
+	z.tokenMu.Lock()
+	revokeURL := fmt.Sprintf(
+		"%s%s/%s?%s",
+		z.oauth.baseURL,
+		oauthGenerateTokenRequestSlug,
+		oauthRevokeTokenRequestSlug,
+		url.Values{"token": {token}}.Encode(),
+	)
+	z.tokenMu.Unlock()
+
+	resp, err := z.postForm(revokeURL)
+	if err != nil {
+		return fmt.Errorf("Failed while requesting revoke token: %s", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf("Failed to read body of response for revoke token: %s", err)
+	}
+
+	if resp.StatusCode != 200 {
+		return fmt.Errorf("Got non-200 status code from request to revoke token: %s\n%s", resp.Status, string(body))
+	}
+
+	status := struct {
+		Status string `json:"status"`
+		Error  string `json:"error"`
+	}{}
+	if err := json.Unmarshal(body, &status); err == nil && (status.Error != "" || status.Status == "failure") {
+		return fmt.Errorf("Failed to revoke token: %s", string(body))
+	}
+
+	z.tokenMu.Lock()
+	z.oauth.token = AccessTokenResponse{}
+	z.oauth.clientCredentials = false
+	z.oauth.revoked = token
+	z.tokenMu.Unlock()
+
+	if err := z.SaveTokens(AccessTokenResponse{}); err != nil {
+		return fmt.Errorf("Failed to save revoked tokens: %s", err)
+	}
+	return nil
+}
+
+// GrantedScopes returns the scopes granted to the access token held
+func (z *Zoho) GrantedScopes() []ScopeString {
+	return z.token().GrantedScopes()
+}
+
+// HasScopes reports whether the access token held was granted all the scopes, see AccessTokenResponse.HasScopes
+func (z *Zoho) HasScopes(scopes ...ScopeString) bool {
+	return z.token().HasScopes(scopes...)
+}
+
+// GrantedScopes decodes the scopes of the token response, which Zoho returns separated by spaces
+func (t AccessTokenResponse) GrantedScopes() []ScopeString {
+	fields := strings.FieldsFunc(t.Scope, func(r rune) bool {
+		return r == ' ' || r == ','
+	})
+
+	scopes := make([]ScopeString, len(fields))
+	for i, f := range fields {
+		scopes[i] = ScopeString(f)
+	}
+	return scopes
+}
+
+// HasScopes reports whether all the scopes were granted. A scope is granted by the same scope, or by a broader
+// scope ending in ALL, eg. ZohoCRM.modules.ALL grants ZohoCRM.modules.leads.READ and ZohoInvoice.fullaccess.all
+// grants ZohoInvoice.invoices.CREATE.
+func (t AccessTokenResponse) HasScopes(scopes ...ScopeString) bool {
+	return len(t.MissingScopes(scopes...)) == 0
+}
+
+// MissingScopes returns the scopes which were not granted
+func (t AccessTokenResponse) MissingScopes(scopes ...ScopeString) []ScopeString {
+	granted := t.GrantedScopes()
+
+	missing := []ScopeString{}
+	for _, s := range scopes {
+		found := false
+		for _, g := range granted {
+			if scopeGrants(g, s) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			missing = append(missing, s)
+		}
+	}
+	return missing
+}
+
+// scopeGrants reports whether the granted scope includes the required scope. <Service>.fullaccess.all, used by
+// the finance products, grants every scope of the service.
+func scopeGrants(granted, required ScopeString) bool {
+	g := strings.Split(string(granted), ".")
+	r := strings.Split(string(required), ".")
+
+	if len(g) == 3 && strings.EqualFold(g[1], string(FullAccessScope)) && strings.EqualFold(g[2], string(All)) {
+		return len(r) >= 3 && strings.EqualFold(g[0], r[0])
+	}
+
+	for i := range g {
+		if i >= len(r) {
+			return false
+		}
+		if i >= 2 && strings.EqualFold(g[i], string(AllMethod)) {
+			return true
+		}
+		if !strings.EqualFold(g[i], r[i]) {
+			return false
+		}
+	}
+	return len(g) == len(r)
+}
+
+// UserInfo is the Zoho Accounts user who authorized the access token
+type UserInfo struct {
+	FirstName   string `json:"First_Name,omitempty"`
+	LastName    string `json:"Last_Name,omitempty"`
+	DisplayName string `json:"Display_Name,omitempty"`
+	Email       string `json:"Email,omitempty"`
+	ZUID        int64  `json:"ZUID,omitempty"`
+}
+
+// GetUserInfo returns the user who authorized the access token, it requires the AaaServer.profile.READ scope
+func (z *Zoho) GetUserInfo() (data UserInfo, err error) {
+	z.tokenMu.Lock()
+	accounts := strings.TrimSuffix(z.oauth.baseURL, "/oauth/v2/")
+	z.tokenMu.Unlock()
+
+	endpoint := Endpoint{
+		Name:         "user info",
+		URL:          accounts + "/oauth/user/info",
+		Method:       HTTPGet,
+		ResponseData: &UserInfo{},
+	}
+
+	err = z.HTTPRequest(&endpoint)
+	if err != nil {
+		return UserInfo{}, fmt.Errorf("Failed to retrieve user info: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UserInfo); ok {
+		return *v, nil
+	}
+
+	return UserInfo{}, fmt.Errorf("Data returned was not 'UserInfo'")
+}
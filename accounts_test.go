@@ -0,0 +1,128 @@
+package zoho
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+)
+
+func TestScopeGrants(t *testing.T) {
+	tests := []struct {
+		granted  ScopeString
+		required ScopeString
+		want     bool
+	}{
+		{"ZohoCRM.modules.ALL", "ZohoCRM.modules.leads.READ", true},
+		{"ZohoCRM.modules.leads.ALL", "ZohoCRM.modules.leads.READ", true},
+		{"ZohoCRM.modules.leads.READ", "ZohoCRM.modules.leads.READ", true},
+		{"zohocrm.modules.all", "ZohoCRM.modules.leads.READ", true},
+		{"ZohoCRM.modules.leads.READ", "ZohoCRM.modules.leads.CREATE", false},
+		{"ZohoCRM.modules.leads.READ", "ZohoCRM.modules.ALL", false},
+		{"ZohoCRM.settings.ALL", "ZohoCRM.modules.leads.READ", false},
+		{"ZohoInvoice.fullaccess.all", "ZohoInvoice.invoices.CREATE", true},
+		{"ZohoInvoice.fullaccess.all", "ZohoInvoice.contacts.READ", true},
+		{"ZohoBooks.fullaccess.all", "ZohoBooks.settings.READ", true},
+		{"ZohoSubscriptions.fullaccess.all", "ZohoSubscriptions.customers.ALL", true},
+		{"ZohoInvoice.fullaccess.all", "ZohoBooks.invoices.CREATE", false},
+		{"ZohoInvoice.fullaccess.READ", "ZohoInvoice.invoices.READ", false},
+	}
+
+	for _, tt := range tests {
+		if got := scopeGrants(tt.granted, tt.required); got != tt.want {
+			t.Errorf("scopeGrants(%s, %s) = %t, want %t", tt.granted, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestMissingScopes(t *testing.T) {
+	token := AccessTokenResponse{Scope: "ZohoInvoice.fullaccess.all ZohoCRM.modules.leads.READ,AaaServer.profile.READ"}
+
+	if !token.HasScopes("ZohoInvoice.invoices.CREATE", "ZohoCRM.modules.leads.READ", "AaaServer.profile.READ") {
+		t.Errorf("HasScopes returned false, missing %v", token.MissingScopes("ZohoInvoice.invoices.CREATE"))
+	}
+
+	missing := token.MissingScopes("ZohoInvoice.invoices.CREATE", "ZohoCRM.modules.contacts.READ", "ZohoBooks.invoices.READ")
+	want := []ScopeString{"ZohoCRM.modules.contacts.READ", "ZohoBooks.invoices.READ"}
+	if !reflect.DeepEqual(missing, want) {
+		t.Errorf("MissingScopes returned %v, want %v", missing, want)
+	}
+}
+
+func TestRevokeTokenEnvStore(t *testing.T) {
+	var mu sync.Mutex
+	var paths, authorizations []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		if r.URL.Path == "/crm/v2/Leads" {
+			authorizations = append(authorizations, r.Header.Get("Authorization"))
+		}
+		mu.Unlock()
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/oauth/v2/token/revoke":
+			w.Write([]byte(`{"status":"success"}`))
+		case "/oauth/v2/token":
+			w.Write([]byte(`{"access_token":"refreshed","expires_in":3600}`))
+		default:
+			w.Write([]byte(`{"data":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	for name, value := range map[string]string{"ZOHO_REFRESH_TOKEN": "refresh", "ZOHO_ACCESS_TOKEN": "access", "ZOHO_TOKEN_EXPIRES": ""} {
+		old, ok := os.LookupEnv(name)
+		os.Setenv(name, value)
+		if ok {
+			defer os.Setenv(name, old)
+		} else {
+			defer os.Unsetenv(name)
+		}
+	}
+
+	z := New()
+	z.CustomHTTPClient(srv.Client())
+	if err := z.SetBaseURL(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	z.SetTokenManager(EnvStore{})
+	z.SetClientID("id")
+	z.SetClientSecret("secret")
+
+	request := func() {
+		var data map[string]interface{}
+		if err := z.HTTPRequest(&Endpoint{Name: "records", URL: "https://www.zohoapis.com/crm/v2/Leads", Method: HTTPGet, ResponseData: &data}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	request()
+	if err := z.RevokeToken(); err != nil {
+		t.Fatal(err)
+	}
+	request()
+
+	if got := z.token(); got != (AccessTokenResponse{}) {
+		t.Errorf("held tokens %+v after revoking, want none", got)
+	}
+	want := []string{"Zoho-oauthtoken access", ""}
+	if !reflect.DeepEqual(authorizations, want) {
+		t.Errorf("requests were authorized with %q, want %q", authorizations, want)
+	}
+	for _, path := range paths {
+		if path == "/oauth/v2/token" {
+			t.Errorf("the revoked refresh token was used, requests %v", paths)
+		}
+	}
+
+	// new tokens in the environment are loaded
+	os.Setenv("ZOHO_ACCESS_TOKEN", "other")
+	os.Setenv("ZOHO_REFRESH_TOKEN", "other refresh")
+	z.SetTokenManager(EnvStore{})
+	request()
+	if got := authorizations[len(authorizations)-1]; got != "Zoho-oauthtoken other" {
+		t.Errorf("request was authorized with %q, want the new access token", got)
+	}
+}
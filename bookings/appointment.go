@@ -116,6 +116,7 @@ func (c *API) RescheduleAppointment(
 	return AppointmentResponse{}, fmt.Errorf("Data retrieved was not 'AppointmentResponse'")
 }
 
+// UpdateAppointmentData is sent as a form rather than JSON, both fields are required so it has no NullFields
 type UpdateAppointmentData struct {
 	BookingID string `url:"booking_id"`
 	Action    string `url:"action"`
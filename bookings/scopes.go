@@ -0,0 +1,23 @@
+package bookings
+
+import (
+	zoho "github.com/schmorrison/Zoho"
+)
+
+var methodScopes = zoho.MethodScopes{
+	"GetAppointment":        {"zohobookings.data.CREATE"},
+	"BookAppointment":       {"zohobookings.data.CREATE"},
+	"UpdateAppointment":     {"zohobookings.data.CREATE"},
+	"RescheduleAppointment": {"zohobookings.data.CREATE"},
+	"FetchAvailability":     {"zohobookings.data.CREATE"},
+	"FetchResources":        {"zohobookings.data.CREATE"},
+	"FetchServices":         {"zohobookings.data.CREATE"},
+	"FetchStaff":            {"zohobookings.data.CREATE"},
+	"FetchWorkspaces":       {"zohobookings.data.CREATE"},
+}
+
+// RequiredScopes returns the minimal scopes required by the methods of API, named as in the API, eg.
+// RequiredScopes("BookAppointment"). Every method of the Bookings API requires zohobookings.data.CREATE.
+func RequiredScopes(methods ...string) ([]zoho.ScopeString, error) {
+	return methodScopes.Required(methods...)
+}
@@ -0,0 +1,15 @@
+package books
+
+import (
+	zoho "github.com/schmorrison/Zoho"
+)
+
+var methodScopes = zoho.MethodScopes{
+	"GetCurrentUser": {"ZohoBooks.settings.READ"},
+}
+
+// RequiredScopes returns the minimal scopes required by the methods of API, named as in the API, eg.
+// RequiredScopes("GetCurrentUser")
+func RequiredScopes(methods ...string) ([]zoho.ScopeString, error) {
+	return methodScopes.Required(methods...)
+}
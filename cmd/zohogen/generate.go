@@ -0,0 +1,221 @@
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// fieldTypes maps the data_type of a field to the wrapper type in the crm and recruit packages
+var fieldTypes = map[string]string{
+	"text":                "SingleLine",
+	"textarea":            "MultiLine",
+	"email":               "Email",
+	"phone":               "Phone",
+	"picklist":            "PickList",
+	"multiselectpicklist": "MultiSelect",
+	"website":             "URL",
+	"integer":             "Number",
+	"bigint":              "Long",
+	"double":              "Decimal",
+	"currency":            "Currency",
+	"percent":             "Percent",
+	"boolean":             "Checkbox",
+	"date":                "*Date",
+	"datetime":            "*Time",
+	"lookup":              "Lookup",
+	"userlookup":          "Lookup",
+	"ownerlookup":         "Owner",
+	"autonumber":          "AutoNumber",
+	"multiselectlookup":   "[]Lookup",
+	"multiuserlookup":     "[]Lookup",
+}
+
+// formulaTypes maps the return_type of a formula field to a wrapper type
+var formulaTypes = map[string]string{
+	"text":     "SingleLine",
+	"double":   "Decimal",
+	"currency": "Currency",
+	"boolean":  "Checkbox",
+	"date":     "*Date",
+	"datetime": "*Time",
+}
+
+// initialisms are written in upper case in Go identifiers, as in records_types.go
+var initialisms = map[string]bool{
+	"API": true, "CRM": true, "HTML": true, "ID": true, "IP": true, "SIC": true,
+	"SKU": true, "URL": true, "UTM": true, "VAT": true,
+}
+
+// goType returns the Go type of the field, qualified with the package name
+func goType(pkg string, f Field) string {
+	t, ok := fieldTypes[f.DataType]
+	if f.DataType == "formula" {
+		t, ok = formulaTypes[f.ReturnType]
+	}
+	if !ok {
+		switch f.DataType {
+		case "subform", "fileupload", "imageupload":
+			return "[]map[string]interface{}"
+		}
+		return "interface{}"
+	}
+
+	prefix := ""
+	for strings.HasPrefix(t, "*") || strings.HasPrefix(t, "[]") {
+		if t[0] == '*' {
+			prefix, t = prefix+"*", t[1:]
+		} else {
+			prefix, t = prefix+"[]", t[2:]
+		}
+	}
+	return prefix + pkg + "." + t
+}
+
+// goName converts an API name or label to an exported Go identifier, eg. 'Lead_Source' becomes 'LeadSource'
+func goName(s string) string {
+	words := strings.FieldsFunc(s, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	b := strings.Builder{}
+	for _, w := range words {
+		if initialisms[strings.ToUpper(w)] {
+			b.WriteString(strings.ToUpper(w))
+			continue
+		}
+		r := []rune(w)
+		r[0] = unicode.ToUpper(r[0])
+		b.WriteString(string(r))
+	}
+
+	name := b.String()
+	if name == "" {
+		return "X"
+	}
+	if unicode.IsDigit([]rune(name)[0]) {
+		name = "X" + name
+	}
+	return name
+}
+
+// uniqueName returns name, or name suffixed with a number if it has already been used
+func uniqueName(used map[string]bool, name string) string {
+	n := name
+	for i := 2; used[n]; i++ {
+		n = fmt.Sprintf("%s%d", name, i)
+	}
+	used[n] = true
+	return n
+}
+
+// uniqueTypeName returns name, or name suffixed with a number, such that the record type and its Module
+// constant and Response type are all unused, and reserves the three names
+func uniqueTypeName(used map[string]bool, name string) string {
+	n := name
+	for i := 2; used[n] || used[n+"Module"] || used[n+"Response"]; i++ {
+		n = fmt.Sprintf("%s%d", name, i)
+	}
+	used[n] = true
+	used[n+"Module"] = true
+	used[n+"Response"] = true
+	return n
+}
+
+// generate renders the Go source for the modules in the dump
+func generate(d Dump, pkgName string) ([]byte, error) {
+	pkg := d.Product
+	if pkg != "crm" && pkg != "recruit" {
+		return nil, fmt.Errorf("Unsupported product '%s', must be 'crm' or 'recruit'", d.Product)
+	}
+
+	modules := append([]Module{}, d.Modules...)
+	sort.Slice(modules, func(i, j int) bool { return modules[i].APIName < modules[j].APIName })
+
+	b := &bytes.Buffer{}
+	fmt.Fprintf(b, "// Code generated by zohogen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(b, "package %s\n\n", pkgName)
+	fmt.Fprintf(b, "import %q\n\n", "github.com/schmorrison/Zoho/"+pkg)
+
+	types := map[string]bool{}
+	for _, m := range modules {
+		label := m.SingularLabel
+		if label == "" {
+			label = m.APIName
+		}
+		typeName := uniqueTypeName(types, goName(label))
+
+		fmt.Fprintf(b, "// %sModule is the API name of the %s module\n", typeName, m.APIName)
+		fmt.Fprintf(b, "const %sModule %s.Module = %q\n\n", typeName, pkg, m.APIName)
+
+		fmt.Fprintf(b, "// %s is a record of the %s module\n", typeName, m.APIName)
+		fmt.Fprintf(b, "type %s struct {\n", typeName)
+		fmt.Fprintf(b, "\tID string `json:\"id,omitempty\"`\n")
+
+		fieldNames := map[string]bool{"ID": true}
+		var picklists []Field
+		var picklistNames []string
+		for _, f := range m.Fields {
+			if f.APIName == "id" {
+				continue
+			}
+			name := uniqueName(fieldNames, goName(f.APIName))
+			if f.FieldLabel != "" && f.FieldLabel != f.APIName {
+				fmt.Fprintf(b, "\t// %s\n", strings.Replace(f.FieldLabel, "\n", " ", -1))
+			}
+			fmt.Fprintf(b, "\t%s %s `json:\"%s,omitempty\"`\n", name, goType(pkg, f), f.APIName)
+
+			if f.DataType == "picklist" || f.DataType == "multiselectpicklist" {
+				picklists = append(picklists, f)
+				picklistNames = append(picklistNames, name)
+			}
+		}
+		fmt.Fprintf(b, "}\n\n")
+
+		fmt.Fprintf(b, "// %sResponse is the data returned when listing, searching or retrieving %s records\n", typeName, m.APIName)
+		fmt.Fprintf(b, "type %sResponse struct {\n", typeName)
+		fmt.Fprintf(b, "\tData []%s `json:\"data,omitempty\"`\n", typeName)
+		fmt.Fprintf(b, "\tInfo %s.PageInfo `json:\"info,omitempty\"`\n", pkg)
+		fmt.Fprintf(b, "}\n\n")
+
+		for i, f := range picklists {
+			writePickListConstants(b, pkg, types, typeName+picklistNames[i], f)
+		}
+	}
+
+	src, err := format.Source(b.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("Failed to format generated code: %s", err)
+	}
+	return src, nil
+}
+
+// writePickListConstants writes a constant for each value of a picklist field, the values of
+// multiselect fields are untyped so they can be used in a MultiSelect
+func writePickListConstants(b *bytes.Buffer, pkg string, used map[string]bool, prefix string, f Field) {
+	var values []string
+	for _, v := range f.PickListValues {
+		if v == "" || v == "-None-" {
+			continue
+		}
+		values = append(values, v)
+	}
+	if len(values) == 0 {
+		return
+	}
+
+	typ := ""
+	if f.DataType == "picklist" {
+		typ = " " + pkg + ".PickList"
+	}
+
+	fmt.Fprintf(b, "// Values of the %s field\n", f.APIName)
+	fmt.Fprintf(b, "const (\n")
+	for _, v := range values {
+		fmt.Fprintf(b, "\t%s%s = %q\n", uniqueName(used, prefix+goName(v)), typ, v)
+	}
+	fmt.Fprintf(b, ")\n\n")
+}
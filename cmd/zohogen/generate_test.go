@@ -0,0 +1,89 @@
+package main
+
+import (
+	"bytes"
+	"flag"
+	"io/ioutil"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+var update = flag.Bool("update", false, "update the golden files")
+
+func TestGenerateGolden(t *testing.T) {
+	d, err := readDump(filepath.Join("testdata", "crm.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(d, "records")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	golden := filepath.Join("testdata", "crm.golden")
+	if *update {
+		if err := ioutil.WriteFile(golden, src, 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want, err := ioutil.ReadFile(golden)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(src, want) {
+		t.Errorf("generated code differs from %s, run 'go test -update' to update it:\n%s", golden, src)
+	}
+}
+
+// TestGenerateCompiles vets the generated code in a package of the module, so that it is type checked
+// against the crm package
+func TestGenerateCompiles(t *testing.T) {
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("go tool not found")
+	}
+
+	d, err := readDump(filepath.Join("testdata", "crm.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	src, err := generate(d, "records")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dir, err := ioutil.TempDir(".", "records")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "records.go"), src, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	out, err := exec.Command(goTool, "vet", "./"+dir).CombinedOutput()
+	if err != nil {
+		t.Errorf("generated code does not compile: %s\n%s", err, out)
+	}
+}
+
+func TestUniqueTypeName(t *testing.T) {
+	used := map[string]bool{}
+	for _, tt := range []struct{ name, want string }{
+		{"LeadModule", "LeadModule"},
+		{"Lead", "Lead2"},
+		{"Lead", "Lead3"},
+		{"Contact", "Contact"},
+	} {
+		if got := uniqueTypeName(used, tt.name); got != tt.want {
+			t.Errorf("uniqueTypeName(%s) = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+	for _, name := range []string{"Lead2", "Lead2Module", "Lead2Response", "ContactResponse"} {
+		if !used[name] {
+			t.Errorf("%s was not reserved", name)
+		}
+	}
+}
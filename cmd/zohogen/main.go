@@ -0,0 +1,117 @@
+// Command zohogen generates Go structs for the modules of a CRM or Recruit org, including custom
+// fields, from the org's module and field metadata. The fields use the wrapper types of the crm and
+// recruit packages, and a constant is generated for every picklist value.
+//
+// The metadata is retrieved from the org, or read from a dump previously saved with -save:
+//
+//    zohogen -product crm -client-id ID -client-secret SECRET -refresh-token TOKEN -save crm.json -out records.go
+//    zohogen -dump crm.json -package records -out records.go
+//
+// The generated records can be used with the generic record methods, eg.
+//
+//    c.ListRecords(&records.LeadResponse{}, records.LeadModule, nil)
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"strings"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+func main() {
+	var (
+		product      = flag.String("product", "crm", "the product to generate records for, 'crm' or 'recruit'")
+		dumpFile     = flag.String("dump", "", "read the metadata from a dump file instead of the org")
+		saveFile     = flag.String("save", "", "save the metadata retrieved from the org to a dump file")
+		modules      = flag.String("modules", "", "comma separated API names of the modules to generate, all modules when empty")
+		pkgName      = flag.String("package", "records", "the package name of the generated file")
+		outFile      = flag.String("out", "", "the file to write, stdout when empty")
+		tld          = flag.String("tld", "com", "the Zoho domain of the org, eg. 'com' or 'eu'")
+		clientID     = flag.String("client-id", "", "the oAuth2 client ID")
+		clientSecret = flag.String("client-secret", "", "the oAuth2 client secret")
+		refreshToken = flag.String("refresh-token", "", "the oAuth2 refresh token, the saved tokens are used when empty")
+		tokensFile   = flag.String("tokens", "", "the file the tokens are saved in")
+	)
+	flag.Parse()
+
+	only := map[string]bool{}
+	for _, m := range strings.Split(*modules, ",") {
+		if m = strings.TrimSpace(m); m != "" {
+			only[m] = true
+		}
+	}
+
+	var (
+		d   Dump
+		err error
+	)
+	if *dumpFile != "" {
+		d, err = readDump(*dumpFile)
+		if err == nil && len(only) > 0 {
+			filtered := d.Modules[:0]
+			for _, m := range d.Modules {
+				if only[m.APIName] {
+					filtered = append(filtered, m)
+				}
+			}
+			d.Modules = filtered
+		}
+	} else {
+		d, err = fetch(*product, *tld, *clientID, *clientSecret, *refreshToken, *tokensFile, only)
+		if err == nil && *saveFile != "" {
+			err = writeDump(*saveFile, d)
+		}
+	}
+	if err != nil {
+		fatal(err)
+	}
+
+	src, err := generate(d, *pkgName)
+	if err != nil {
+		fatal(err)
+	}
+
+	if *outFile == "" {
+		os.Stdout.Write(src)
+		return
+	}
+	if err := ioutil.WriteFile(*outFile, src, 0644); err != nil {
+		fatal(fmt.Errorf("Failed to write generated code: %s", err))
+	}
+}
+
+func fetch(product, tld, clientID, clientSecret, refreshToken, tokensFile string, only map[string]bool) (Dump, error) {
+	z := zoho.New()
+	z.SetZohoTLD(tld)
+	if tokensFile != "" {
+		z.SetTokensFile(tokensFile)
+	}
+	z.SetClientID(clientID)
+	z.SetClientSecret(clientSecret)
+
+	if refreshToken != "" {
+		z.SetRefreshToken(refreshToken)
+		if err := z.RefreshTokenRequest(); err != nil {
+			return Dump{}, err
+		}
+	} else if err := z.CheckForSavedTokens(); err != nil && err != zoho.ErrTokenExpired {
+		return Dump{}, fmt.Errorf("No saved tokens, provide a refresh token: %s", err)
+	}
+
+	switch product {
+	case "crm":
+		return fetchCRM(z, only)
+	case "recruit":
+		return fetchRecruit(z, only)
+	}
+	return Dump{}, fmt.Errorf("Unsupported product '%s', must be 'crm' or 'recruit'", product)
+}
+
+func fatal(err error) {
+	fmt.Fprintf(os.Stderr, "zohogen: %s\n", err)
+	os.Exit(1)
+}
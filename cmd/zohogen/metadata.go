@@ -0,0 +1,143 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/crm"
+	"github.com/schmorrison/Zoho/recruit"
+)
+
+// Dump is the module and field metadata of an org. It is the input of the generator, and can be
+// saved to a file so code can be regenerated without access to the org.
+type Dump struct {
+	Product string   `json:"product"`
+	Modules []Module `json:"modules"`
+}
+
+// Module is the metadata of a module and its fields
+type Module struct {
+	APIName       string  `json:"api_name"`
+	SingularLabel string  `json:"singular_label,omitempty"`
+	PluralLabel   string  `json:"plural_label,omitempty"`
+	Fields        []Field `json:"fields"`
+}
+
+// Field is the metadata of a field of a module
+type Field struct {
+	APIName        string   `json:"api_name"`
+	FieldLabel     string   `json:"field_label,omitempty"`
+	DataType       string   `json:"data_type"`
+	JSONType       string   `json:"json_type,omitempty"`
+	ReturnType     string   `json:"return_type,omitempty"`
+	ReadOnly       bool     `json:"read_only,omitempty"`
+	CustomField    bool     `json:"custom_field,omitempty"`
+	PickListValues []string `json:"pick_list_values,omitempty"`
+}
+
+func readDump(file string) (Dump, error) {
+	b, err := ioutil.ReadFile(file)
+	if err != nil {
+		return Dump{}, fmt.Errorf("Failed to read metadata dump: %s", err)
+	}
+	d := Dump{}
+	if err := json.Unmarshal(b, &d); err != nil {
+		return Dump{}, fmt.Errorf("Failed to decode metadata dump: %s", err)
+	}
+	return d, nil
+}
+
+func writeDump(file string, d Dump) error {
+	b, err := json.MarshalIndent(d, "", "  ")
+	if err != nil {
+		return fmt.Errorf("Failed to encode metadata dump: %s", err)
+	}
+	if err := ioutil.WriteFile(file, b, 0644); err != nil {
+		return fmt.Errorf("Failed to write metadata dump: %s", err)
+	}
+	return nil
+}
+
+// fetchCRM retrieves the metadata of the api supported CRM modules, or only the provided modules
+func fetchCRM(z *zoho.Zoho, only map[string]bool) (Dump, error) {
+	c := crm.New(z)
+	modules, err := c.GetModules()
+	if err != nil {
+		return Dump{}, err
+	}
+
+	d := Dump{Product: "crm"}
+	for _, m := range modules.Modules {
+		if !m.APISupported || (len(only) > 0 && !only[m.APIName]) {
+			continue
+		}
+		fields, err := c.GetFieldsMetadata(crm.Module(m.APIName))
+		if err != nil {
+			return Dump{}, err
+		}
+
+		mod := Module{APIName: m.APIName, SingularLabel: m.SingularLabel, PluralLabel: m.PluralLabel}
+		for _, f := range fields.Fields {
+			field := Field{
+				APIName:     f.APIName,
+				FieldLabel:  f.FieldLabel,
+				DataType:    f.DataType,
+				JSONType:    f.JSONType,
+				ReturnType:  f.Formula.ReturnType,
+				ReadOnly:    f.ReadOnly || f.FieldReadOnly,
+				CustomField: f.CustomField,
+			}
+			for _, p := range f.PickListValues {
+				field.PickListValues = append(field.PickListValues, p.ActualValue)
+			}
+			mod.Fields = append(mod.Fields, field)
+		}
+		d.Modules = append(d.Modules, mod)
+	}
+	return d, nil
+}
+
+// fetchRecruit retrieves the metadata of the api supported Recruit modules, or only the provided modules
+func fetchRecruit(z *zoho.Zoho, only map[string]bool) (Dump, error) {
+	c := recruit.New(z)
+	modules, err := c.GetAllMetadata()
+	if err != nil {
+		return Dump{}, err
+	}
+
+	d := Dump{Product: "recruit"}
+	for _, m := range modules.Modules {
+		if !m.APISupported || (len(only) > 0 && !only[m.APIName]) {
+			continue
+		}
+		fields, err := c.GetFieldsMetadata(map[string]zoho.Parameter{"module": zoho.Parameter(m.APIName)})
+		if err != nil {
+			return Dump{}, err
+		}
+
+		mod := Module{APIName: m.APIName, SingularLabel: m.SingularLabel, PluralLabel: m.PluralLabel}
+		for _, f := range fields.Fields {
+			field := Field{
+				APIName:     f.APIName,
+				FieldLabel:  f.FieldLabel,
+				DataType:    f.DataType,
+				JSONType:    f.JSONType,
+				ReadOnly:    f.ReadOnly || f.FieldReadOnly,
+				CustomField: f.CustomField,
+			}
+			// recruit does not type the picklist values, they are objects with an 'actual_value'
+			for _, p := range f.PickListValues {
+				if v, ok := p.(map[string]interface{}); ok {
+					if s, ok := v["actual_value"].(string); ok {
+						field.PickListValues = append(field.PickListValues, s)
+					}
+				}
+			}
+			mod.Fields = append(mod.Fields, field)
+		}
+		d.Modules = append(d.Modules, mod)
+	}
+	return d, nil
+}
@@ -0,0 +1,102 @@
+package crm
+
+import (
+	"fmt"
+	"time"
+)
+
+// ParticipantType is the kind of participant invited to an event
+type ParticipantType = string
+
+// Participant types of an EventParticipant
+const (
+	ContactParticipant ParticipantType = "contact"
+	LeadParticipant    ParticipantType = "lead"
+	UserParticipant    ParticipantType = "user"
+	EmailParticipant   ParticipantType = "email"
+)
+
+// EventParticipant is a participant of an event, Participant is the ID of the contact, lead or user, or the
+// email address when Type is EmailParticipant
+type EventParticipant struct {
+	Type        ParticipantType `json:"type"`
+	Participant string          `json:"participant"`
+	Name        string          `json:"name,omitempty"`
+	Email       string          `json:"Email,omitempty"`
+	Invited     bool            `json:"invited,omitempty"`
+	Status      string          `json:"status,omitempty"`
+}
+
+// ReminderAction is how a reminder is delivered
+type ReminderAction = string
+
+// Actions of a Reminder
+const (
+	EmailReminder         ReminderAction = "EMAIL"
+	PopupReminder         ReminderAction = "POPUP"
+	EmailAndPopupReminder ReminderAction = "EMAILANDPOPUP"
+)
+
+// Reminder is the Remind_At value of an event or task, eg. FREQ=NONE;ACTION=EMAIL;TRIGGER=DATE-TIME:2019-04-28T17:59:00+05:30
+type Reminder struct {
+	Alarm string `json:"ALARM"`
+}
+
+// NewReminder returns a Reminder delivered by action at the provided time
+func NewReminder(at time.Time, action ReminderAction) *Reminder {
+	return &Reminder{
+		Alarm: fmt.Sprintf("FREQ=NONE;ACTION=%s;TRIGGER=DATE-TIME:%s", action, at.Format("2006-01-02T15:04:05-07:00")),
+	}
+}
+
+// ReminderBefore returns a Reminder delivered by action the provided duration before start
+func ReminderBefore(start time.Time, before time.Duration, action ReminderAction) *Reminder {
+	return NewReminder(start.Add(-before), action)
+}
+
+// Recurrence is the Recurring_Activity value of an event or task, an iCalendar RRULE
+// eg. FREQ=WEEKLY;INTERVAL=1;BYDAY=MO;UNTIL=2019-12-31;DTSTART=2019-01-07
+type Recurrence struct {
+	RRule string `json:"RRULE"`
+}
+
+// RelateTo will link the event to the record specified by module and id, contacts are set as Who_Id and records
+// of other modules as What_Id
+func (e *EventData) RelateTo(module Module, id string) {
+	e.WhoID, e.WhatID, e.SeModule = relateActivity(module, id)
+}
+
+// RelateTo will link the task to the record specified by module and id, contacts are set as Who_Id and records
+// of other modules as What_Id
+func (t *TaskData) RelateTo(module Module, id string) {
+	t.WhoID, t.WhatID, t.SeModule = relateActivity(module, id)
+}
+
+func relateActivity(module Module, id string) (who *Lookup, what *Lookup, seModule Module) {
+	if module == ContactsModule {
+		return &Lookup{ID: id}, nil, ""
+	}
+	return nil, &Lookup{ID: id}, module
+}
+
+// CreateEvents will insert the events, participants are sent an invitation when SendNotification is set
+// https://www.zoho.com/crm/developer/docs/api/v2/insert-records.html
+func (c *API) CreateEvents(events ...EventData) (data InsertRecordsResponse, err error) {
+	for _, e := range events {
+		if e.EventTitle == "" || e.StartDateTime == nil || e.EndDateTime == nil {
+			return InsertRecordsResponse{}, fmt.Errorf("Failed to create events, the title, start and end of each event must be provided")
+		}
+	}
+	return c.InsertRecords(InsertRecordsData{Data: events}, EventsModule)
+}
+
+// CreateTasks will insert the tasks
+// https://www.zoho.com/crm/developer/docs/api/v2/insert-records.html
+func (c *API) CreateTasks(tasks ...TaskData) (data InsertRecordsResponse, err error) {
+	for _, t := range tasks {
+		if t.Subject == "" {
+			return InsertRecordsResponse{}, fmt.Errorf("Failed to create tasks, the subject of each task must be provided")
+		}
+	}
+	return c.InsertRecords(InsertRecordsData{Data: tasks}, TasksModule)
+}
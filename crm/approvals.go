@@ -0,0 +1,127 @@
+package crm
+
+import (
+	"fmt"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// ApprovalType filters the records returned by GetApprovals
+type ApprovalType = string
+
+// Types of GetApprovals
+const (
+	// AwaitingApproval are the records waiting for the approval of the current user
+	AwaitingApproval ApprovalType = "awaiting"
+	// OthersAwaitingApproval are the records waiting for the approval of other users
+	OthersAwaitingApproval ApprovalType = "others_awaiting"
+	// PendingApproval are the records submitted by the current user waiting for approval
+	PendingApproval ApprovalType = "pending"
+	// RejectedApproval are the records submitted by the current user which were rejected
+	RejectedApproval ApprovalType = "rejected"
+)
+
+// GetApprovals will return the records in approval processes of the provided type
+// https://www.zoho.com/crm/developer/docs/api/v2.1/get-approval-records.html
+func (c *API) GetApprovals(approvalType ApprovalType) (data ApprovalsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "approvals",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2.1/Approvals", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &ApprovalsResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"type": zoho.Parameter(approvalType),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return ApprovalsResponse{}, fmt.Errorf("Failed to retrieve %s approvals: %s", approvalType, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*ApprovalsResponse); ok {
+		return *v, nil
+	}
+
+	return ApprovalsResponse{}, fmt.Errorf("Data returned was not 'ApprovalsResponse'")
+}
+
+// ApprovalsResponse is the data returned by GetApprovals
+type ApprovalsResponse struct {
+	Data []struct {
+		Owner                     *Lookup `json:"owner,omitempty"`
+		Module                    Module  `json:"module,omitempty"`
+		Entity                    *Lookup `json:"entity,omitempty"`
+		IsApprovalProcessRejected bool    `json:"is_approval_process_rejected,omitempty"`
+		Rule                      *Lookup `json:"rule,omitempty"`
+		WaitingFor                *Lookup `json:"waiting_for,omitempty"`
+		SubmittedBy               *Lookup `json:"submitted_by,omitempty"`
+		SubmittedTime             *Time   `json:"submitted_time,omitempty"`
+		Rejected                  bool    `json:"rejected,omitempty"`
+		Approval                  struct {
+			Delegate bool `json:"delegate,omitempty"`
+			Approve  bool `json:"approve,omitempty"`
+			Reject   bool `json:"reject,omitempty"`
+			Resubmit bool `json:"resubmit,omitempty"`
+		} `json:"$approval,omitempty"`
+	} `json:"data,omitempty"`
+	Info PageInfo `json:"info,omitempty"`
+}
+
+// ApproveRecord will approve the record specified by module and id, which the current user is able to approve
+// when its $approval.approve flag is set
+// https://www.zoho.com/crm/developer/docs/api/v2.1/approve-records.html
+func (c *API) ApproveRecord(module Module, id, comments string) (data ApprovalActionResponse, err error) {
+	return c.approvalAction(module, id, ApprovalActionData{Action: "approve", Comments: comments})
+}
+
+// RejectRecord will reject the record specified by module and id
+// https://www.zoho.com/crm/developer/docs/api/v2.1/approve-records.html
+func (c *API) RejectRecord(module Module, id, comments string) (data ApprovalActionResponse, err error) {
+	return c.approvalAction(module, id, ApprovalActionData{Action: "reject", Comments: comments})
+}
+
+// DelegateRecord will delegate the approval of the record specified by module and id to the user specified by userID
+// https://www.zoho.com/crm/developer/docs/api/v2.1/approve-records.html
+func (c *API) DelegateRecord(module Module, id, userID, comments string) (data ApprovalActionResponse, err error) {
+	return c.approvalAction(module, id, ApprovalActionData{Action: "delegate", Comments: comments, User: &Lookup{ID: userID}})
+}
+
+// ResubmitRecord will resubmit the rejected record specified by module and id for approval
+// https://www.zoho.com/crm/developer/docs/api/v2.1/approve-records.html
+func (c *API) ResubmitRecord(module Module, id, comments string) (data ApprovalActionResponse, err error) {
+	return c.approvalAction(module, id, ApprovalActionData{Action: "resubmit", Comments: comments})
+}
+
+func (c *API) approvalAction(module Module, id string, action ApprovalActionData) (data ApprovalActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "approvals",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2.1/%s/%s/actions/approve", c.ZohoTLD, module, id),
+		Method:       zoho.HTTPPost,
+		ResponseData: &ApprovalActionResponse{},
+		RequestBody: struct {
+			Data []ApprovalActionData `json:"data"`
+		}{[]ApprovalActionData{action}},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return ApprovalActionResponse{}, fmt.Errorf("Failed to %s %s (%s): %s", action.Action, module, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*ApprovalActionResponse); ok {
+		return *v, nil
+	}
+
+	return ApprovalActionResponse{}, fmt.Errorf("Data returned was not 'ApprovalActionResponse'")
+}
+
+// ApprovalActionData is the action taken on a record in an approval process, User is the delegate
+type ApprovalActionData struct {
+	Action   string  `json:"action"`
+	Comments string  `json:"comments,omitempty"`
+	User     *Lookup `json:"user,omitempty"`
+}
+
+// ApprovalActionResponse is the data returned by ApproveRecord, RejectRecord, DelegateRecord and ResubmitRecord
+type ApprovalActionResponse = UpdateRelatedRecordResponse
@@ -0,0 +1,368 @@
+package crm
+
+import (
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"path/filepath"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// ListAttachments will return the attachments of the record specified by module and ID
+// https://www.zoho.com/crm/developer/docs/api/v2/get-attachments.html
+func (c *API) ListAttachments(
+	module Module,
+	recordID string,
+	params map[string]zoho.Parameter,
+) (data AttachmentsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "attachments",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/Attachments",
+			c.ZohoTLD,
+			module,
+			recordID,
+		),
+		Method:       zoho.HTTPGet,
+		ResponseData: &AttachmentsResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"fields":   "",
+			"page":     "",
+			"per_page": "200",
+		},
+	}
+
+	for k, v := range params {
+		endpoint.URLParameters[k] = v
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return AttachmentsResponse{}, fmt.Errorf("Failed to retrieve attachments of %s (%s): %s", module, recordID, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*AttachmentsResponse); ok {
+		return *v, nil
+	}
+
+	return AttachmentsResponse{}, fmt.Errorf("Data returned was not 'AttachmentsResponse'")
+}
+
+// AttachmentsResponse is the data returned by ListAttachments
+type AttachmentsResponse struct {
+	Data []struct {
+		Owner        Owner  `json:"Owner,omitempty"`
+		ModifiedBy   Owner  `json:"Modified_By,omitempty"`
+		CreatedBy    Owner  `json:"Created_By,omitempty"`
+		ParentID     Lookup `json:"Parent_Id,omitempty"`
+		Editable     bool   `json:"$editable,omitempty"`
+		FileID       string `json:"$file_id,omitempty"`
+		Type         string `json:"$type,omitempty"`
+		SeModule     string `json:"$se_module,omitempty"`
+		LinkURL      string `json:"$link_url,omitempty"`
+		FileName     string `json:"File_Name,omitempty"`
+		Size         string `json:"Size,omitempty"`
+		CreatedTime  Time   `json:"Created_Time,omitempty"`
+		ModifiedTime Time   `json:"Modified_Time,omitempty"`
+		ID           string `json:"id,omitempty"`
+	} `json:"data,omitempty"`
+	Info PageInfo `json:"info,omitempty"`
+}
+
+// UploadAttachment will attach the file at the provided path to the record specified by module and ID
+// https://www.zoho.com/crm/developer/docs/api/v2/upload-attachment.html
+func (c *API) UploadAttachment(module Module, recordID, file string) (data UploadAttachmentResponse, err error) {
+	return c.uploadAttachment(module, recordID, zoho.Endpoint{
+		BodyFormat:      zoho.FILE,
+		Attachment:      file,
+		AttachmentField: "file",
+	})
+}
+
+// UploadAttachmentReader will attach the contents of the reader to the record specified by module and ID,
+// using the provided file name
+// https://www.zoho.com/crm/developer/docs/api/v2/upload-attachment.html
+func (c *API) UploadAttachmentReader(
+	module Module,
+	recordID string,
+	fileName string,
+	r io.Reader,
+) (data UploadAttachmentResponse, err error) {
+	return c.uploadAttachment(module, recordID, zoho.Endpoint{
+		BodyFormat:       zoho.FILE,
+		Attachment:       fileName,
+		AttachmentReader: r,
+		AttachmentField:  "file",
+	})
+}
+
+// UploadAttachmentURL will attach a link to the record specified by module and ID
+// https://www.zoho.com/crm/developer/docs/api/v2/upload-attachment.html
+func (c *API) UploadAttachmentURL(module Module, recordID, attachmentURL string) (data UploadAttachmentResponse, err error) {
+	return c.uploadAttachment(module, recordID, zoho.Endpoint{
+		BodyFormat: zoho.FORM,
+		RequestBody: map[string]string{
+			"attachmentUrl": attachmentURL,
+		},
+	})
+}
+
+func (c *API) uploadAttachment(module Module, recordID string, endpoint zoho.Endpoint) (data UploadAttachmentResponse, err error) {
+	endpoint.Name = "attachments"
+	endpoint.URL = fmt.Sprintf(
+		"https://www.zohoapis.%s/crm/v2/%s/%s/Attachments",
+		c.ZohoTLD,
+		module,
+		recordID,
+	)
+	endpoint.Method = zoho.HTTPPost
+	endpoint.ResponseData = &UploadAttachmentResponse{}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UploadAttachmentResponse{}, fmt.Errorf("Failed to upload attachment to %s (%s): %s", module, recordID, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UploadAttachmentResponse); ok {
+		return *v, nil
+	}
+
+	return UploadAttachmentResponse{}, fmt.Errorf("Data returned was not 'UploadAttachmentResponse'")
+}
+
+// UploadAttachmentResponse is the data returned by the UploadAttachment methods
+type UploadAttachmentResponse struct {
+	Data []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ModifiedTime Time   `json:"Modified_Time,omitempty"`
+			ModifiedBy   Owner  `json:"Modified_By,omitempty"`
+			CreatedTime  Time   `json:"Created_Time,omitempty"`
+			ID           string `json:"id,omitempty"`
+			CreatedBy    Owner  `json:"Created_By,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// DownloadAttachment will return the contents of the attachment specified by attachmentID. The caller
+// must close the Body of the returned FileDownload.
+// https://www.zoho.com/crm/developer/docs/api/v2/download-attachments.html
+func (c *API) DownloadAttachment(module Module, recordID, attachmentID string) (data FileDownload, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "attachments",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/Attachments/%s",
+			c.ZohoTLD,
+			module,
+			recordID,
+			attachmentID,
+		),
+		Method: zoho.HTTPGet,
+	}
+
+	resp, err := c.Zoho.HTTPStreamRequest(&endpoint)
+	if err != nil {
+		return FileDownload{}, fmt.Errorf("Failed to download attachment (%s): %s", attachmentID, err)
+	}
+
+	return newFileDownload(resp), nil
+}
+
+// DeleteAttachment will delete the attachment specified by attachmentID from the record
+// https://www.zoho.com/crm/developer/docs/api/v2/delete-attachments.html
+func (c *API) DeleteAttachment(module Module, recordID, attachmentID string) (data DeleteRecordsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "attachments",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/Attachments/%s",
+			c.ZohoTLD,
+			module,
+			recordID,
+			attachmentID,
+		),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &DeleteRecordsResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return DeleteRecordsResponse{}, fmt.Errorf("Failed to delete attachment (%s): %s", attachmentID, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*DeleteRecordsResponse); ok {
+		return *v, nil
+	}
+
+	return DeleteRecordsResponse{}, fmt.Errorf("Data returned was not 'DeleteRecordsResponse'")
+}
+
+// FileDownload is a file returned by CRM. Body must be closed by the caller.
+type FileDownload struct {
+	Body          io.ReadCloser
+	FileName      string
+	ContentType   string
+	ContentLength int64
+}
+
+func newFileDownload(resp *http.Response) FileDownload {
+	d := FileDownload{
+		Body:          resp.Body,
+		ContentType:   resp.Header.Get("Content-Type"),
+		ContentLength: resp.ContentLength,
+	}
+	if _, params, err := mime.ParseMediaType(resp.Header.Get("Content-Disposition")); err == nil {
+		d.FileName = filepath.Base(params["filename"])
+	}
+	return d
+}
+
+// UploadFile will upload the file at the provided path to CRM, the returned ID can be used as the value of a file
+// upload field or as a BlueprintAttachment
+// https://www.zoho.com/crm/developer/docs/api/v2/upload-files-to-zfs.html
+func (c *API) UploadFile(file string) (data UploadFileResponse, err error) {
+	return c.uploadFile(zoho.Endpoint{Attachment: file})
+}
+
+// UploadFileReader will upload the contents of the reader to CRM, using the provided file name
+// https://www.zoho.com/crm/developer/docs/api/v2/upload-files-to-zfs.html
+func (c *API) UploadFileReader(fileName string, r io.Reader) (data UploadFileResponse, err error) {
+	return c.uploadFile(zoho.Endpoint{Attachment: fileName, AttachmentReader: r})
+}
+
+func (c *API) uploadFile(endpoint zoho.Endpoint) (data UploadFileResponse, err error) {
+	endpoint.Name = "files"
+	endpoint.URL = fmt.Sprintf("https://www.zohoapis.%s/crm/v2/files", c.ZohoTLD)
+	endpoint.Method = zoho.HTTPPost
+	endpoint.ResponseData = &UploadFileResponse{}
+	endpoint.BodyFormat = zoho.FILE
+	endpoint.AttachmentField = "file"
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UploadFileResponse{}, fmt.Errorf("Failed to upload file: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UploadFileResponse); ok {
+		return *v, nil
+	}
+
+	return UploadFileResponse{}, fmt.Errorf("Data returned was not 'UploadFileResponse'")
+}
+
+// UploadFileResponse is the data returned by UploadFile, the ID of the uploaded file is in the details
+type UploadFileResponse struct {
+	Data []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			Name string `json:"name,omitempty"`
+			ID   string `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// UploadPhoto will set the image of the record specified by module and ID to the file at the provided path
+// https://www.zoho.com/crm/developer/docs/api/v2/upload-image.html
+func (c *API) UploadPhoto(module Module, recordID, file string) (data PhotoResponse, err error) {
+	return c.uploadPhoto(module, recordID, zoho.Endpoint{
+		Attachment: file,
+	})
+}
+
+// UploadPhotoReader will set the image of the record specified by module and ID to the contents of the reader
+// https://www.zoho.com/crm/developer/docs/api/v2/upload-image.html
+func (c *API) UploadPhotoReader(module Module, recordID, fileName string, r io.Reader) (data PhotoResponse, err error) {
+	return c.uploadPhoto(module, recordID, zoho.Endpoint{
+		Attachment:       fileName,
+		AttachmentReader: r,
+	})
+}
+
+func (c *API) uploadPhoto(module Module, recordID string, endpoint zoho.Endpoint) (data PhotoResponse, err error) {
+	endpoint.Name = "photo"
+	endpoint.URL = fmt.Sprintf(
+		"https://www.zohoapis.%s/crm/v2/%s/%s/photo",
+		c.ZohoTLD,
+		module,
+		recordID,
+	)
+	endpoint.Method = zoho.HTTPPost
+	endpoint.ResponseData = &PhotoResponse{}
+	endpoint.BodyFormat = zoho.FILE
+	endpoint.AttachmentField = "file"
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return PhotoResponse{}, fmt.Errorf("Failed to upload photo to %s (%s): %s", module, recordID, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*PhotoResponse); ok {
+		return *v, nil
+	}
+
+	return PhotoResponse{}, fmt.Errorf("Data returned was not 'PhotoResponse'")
+}
+
+// DownloadPhoto will return the image of the record specified by module and ID. The caller
+// must close the Body of the returned FileDownload.
+// https://www.zoho.com/crm/developer/docs/api/v2/download-image.html
+func (c *API) DownloadPhoto(module Module, recordID string) (data FileDownload, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "photo",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/photo",
+			c.ZohoTLD,
+			module,
+			recordID,
+		),
+		Method: zoho.HTTPGet,
+	}
+
+	resp, err := c.Zoho.HTTPStreamRequest(&endpoint)
+	if err != nil {
+		return FileDownload{}, fmt.Errorf("Failed to download photo of %s (%s): %s", module, recordID, err)
+	}
+
+	return newFileDownload(resp), nil
+}
+
+// DeletePhoto will remove the image of the record specified by module and ID
+// https://www.zoho.com/crm/developer/docs/api/v2/delete-image.html
+func (c *API) DeletePhoto(module Module, recordID string) (data PhotoResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "photo",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/photo",
+			c.ZohoTLD,
+			module,
+			recordID,
+		),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &PhotoResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return PhotoResponse{}, fmt.Errorf("Failed to delete photo of %s (%s): %s", module, recordID, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*PhotoResponse); ok {
+		return *v, nil
+	}
+
+	return PhotoResponse{}, fmt.Errorf("Data returned was not 'PhotoResponse'")
+}
+
+// PhotoResponse is the data returned by UploadPhoto and DeletePhoto
+type PhotoResponse struct {
+	Code    string `json:"code,omitempty"`
+	Details struct {
+	} `json:"details,omitempty"`
+	Message string `json:"message,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
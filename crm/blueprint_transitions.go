@@ -0,0 +1,360 @@
+package crm
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// BlueprintTransition is a transition of a blueprint that is available to a record, and the
+// fields that are shown to the user when it is executed
+type BlueprintTransition struct {
+	NextTransitions    []string `json:"next_transitions"`
+	PercentPartialSave float64  `json:"percent_partial_save"`
+	Data               struct {
+		Attachments string `json:"Attachments"`
+	} `json:"data"`
+	NextFieldValue  string           `json:"next_field_value"`
+	Name            string           `json:"name"`
+	CriteriaMatched bool             `json:"criteria_matched"`
+	ID              string           `json:"id"`
+	Fields          []BlueprintField `json:"fields"`
+	CriteriaMessage string           `json:"criteria_message"`
+}
+
+// BlueprintField is a field that can be, or when Mandatory must be, provided when executing a transition
+type BlueprintField struct {
+	APIName            string          `json:"api_name"`
+	DisplayLabel       string          `json:"display_label"`
+	FieldLabel         string          `json:"field_label"`
+	Type               string          `json:"_type"`
+	DataType           DataType        `json:"data_type"`
+	ColumnName         string          `json:"column_name"`
+	PersonalityName    string          `json:"personality_name"`
+	ID                 string          `json:"id"`
+	TransitionSequence int             `json:"transition_sequence"`
+	Mandatory          bool            `json:"mandatory"`
+	Layouts            string          `json:"layouts"`
+	Length             int             `json:"length"`
+	PickListValues     []PickListValue `json:"pick_list_values"`
+	Items              []ChecklistItem `json:"items"`
+}
+
+// ChecklistItem is an item of a transitions checklist
+type ChecklistItem struct {
+	Item           string `json:"item"`
+	ID             string `json:"id"`
+	SequenceNumber int    `json:"sequence_number"`
+}
+
+// Data types of the special fields of a transition
+const (
+	ChecklistType  DataType = "checklist"
+	AttachmentType DataType = "attachment"
+)
+
+// IsChecklist reports whether the field is the checklist of the transition
+func (f BlueprintField) IsChecklist() bool {
+	return f.DataType == ChecklistType || strings.EqualFold(f.Type, string(ChecklistType))
+}
+
+// IsAttachment reports whether the field is the attachments of the transition
+func (f BlueprintField) IsAttachment() bool {
+	return f.DataType == AttachmentType || strings.EqualFold(f.Type, string(AttachmentType)) || f.APIName == "Attachments"
+}
+
+// Transition returns the transition specified by ID or name
+func (b BlueprintResponse) Transition(idOrName string) (BlueprintTransition, bool) {
+	for _, t := range b.Blueprint.Transitions {
+		if t.ID == idOrName || t.Name == idOrName {
+			return t, true
+		}
+	}
+	return BlueprintTransition{}, false
+}
+
+// Field returns the field of the transition specified by API name
+func (t BlueprintTransition) Field(apiName string) (BlueprintField, bool) {
+	for _, f := range t.Fields {
+		if f.APIName == apiName {
+			return f, true
+		}
+	}
+	return BlueprintField{}, false
+}
+
+// RequiredFields returns the fields that must be provided to execute the transition
+func (t BlueprintTransition) RequiredFields() []BlueprintField {
+	fields := []BlueprintField{}
+	for _, f := range t.Fields {
+		if f.Mandatory {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// Checklist returns the value of a checklist field with each of the items checked
+func Checklist(items ...string) []map[string]bool {
+	list := make([]map[string]bool, 0, len(items))
+	for _, i := range items {
+		list = append(list, map[string]bool{i: true})
+	}
+	return list
+}
+
+// BlueprintAttachment is a file attached when executing a transition, the FileID is returned by UploadFile
+type BlueprintAttachment struct {
+	FileID  string `json:"$file_id,omitempty"`
+	LinkURL string `json:"$link_url,omitempty"`
+	Name    string `json:"name,omitempty"`
+}
+
+// BlueprintValidationError is returned when the data provided for a transition is missing mandatory
+// fields, or contains fields that are invalid
+type BlueprintValidationError struct {
+	Transition string
+	Missing    []string
+	Invalid    map[string]string
+}
+
+func (e BlueprintValidationError) Error() string {
+	problems := []string{}
+	if len(e.Missing) > 0 {
+		problems = append(problems, fmt.Sprintf("missing mandatory fields %s", strings.Join(e.Missing, ", ")))
+	}
+	fields := make([]string, 0, len(e.Invalid))
+	for f := range e.Invalid {
+		fields = append(fields, f)
+	}
+	sort.Strings(fields)
+	for _, f := range fields {
+		problems = append(problems, fmt.Sprintf("%s %s", f, e.Invalid[f]))
+	}
+	return fmt.Sprintf("Invalid data for transition '%s': %s", e.Transition, strings.Join(problems, "; "))
+}
+
+// Validate checks that data contains every mandatory field of the transition, and that each value
+// has the type expected by the field. A BlueprintValidationError is returned describing every problem.
+// Keys which are not fields of the transition, such as Notes, are not checked. Values decoded from JSON
+// (float64, json.Number and []interface{}) are accepted.
+func (t BlueprintTransition) Validate(data map[string]interface{}) error {
+	verr := BlueprintValidationError{Transition: t.Name, Invalid: map[string]string{}}
+
+	for _, f := range t.Fields {
+		v, ok := data[f.APIName]
+		if !ok || isEmptyValue(v) {
+			if f.Mandatory {
+				verr.Missing = append(verr.Missing, f.APIName)
+			}
+			continue
+		}
+		if problem := f.check(v); problem != "" {
+			verr.Invalid[f.APIName] = problem
+		}
+	}
+
+	if len(verr.Missing) > 0 || len(verr.Invalid) > 0 {
+		return verr
+	}
+	return nil
+}
+
+// check returns a description of why v is not a valid value of the field, or an empty string
+func (f BlueprintField) check(v interface{}) string {
+	switch {
+	case f.IsChecklist():
+		checked, ok := checklistItems(v)
+		if !ok {
+			return "must be a checklist, see crm.Checklist"
+		}
+		for _, i := range f.Items {
+			if f.Mandatory && !checked[i.Item] {
+				return fmt.Sprintf("item '%s' must be checked", i.Item)
+			}
+		}
+		return ""
+	case f.IsAttachment():
+		switch v.(type) {
+		case []BlueprintAttachment, []map[string]interface{}, []interface{}:
+			return ""
+		}
+		return "must be a []crm.BlueprintAttachment"
+	}
+
+	rv := reflect.ValueOf(v)
+	switch f.DataType {
+	case TextType, TextAreaType, EmailType, PhoneType, WebsiteType:
+		if rv.Kind() != reflect.String {
+			return fmt.Sprintf("must be a string, not %T", v)
+		}
+		if f.Length > 0 && len([]rune(rv.String())) > f.Length {
+			return fmt.Sprintf("exceeds the maximum length of %d", f.Length)
+		}
+	case PickListType:
+		if rv.Kind() != reflect.String {
+			return fmt.Sprintf("must be a string, not %T", v)
+		}
+		if !(FieldMetadata{PickListValues: f.PickListValues}).hasPickListValue(rv.String()) {
+			return fmt.Sprintf("'%s' is not an option of the picklist", rv.String())
+		}
+	case MultiSelectType:
+		if rv.Kind() != reflect.Slice || rv.Type().Elem().Kind() != reflect.String {
+			return fmt.Sprintf("must be a list of strings, not %T", v)
+		}
+	case IntegerType, BigIntType:
+		switch rv.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		case reflect.Float32, reflect.Float64:
+			// numbers decoded from JSON are float64
+			if x := rv.Float(); x != math.Trunc(x) {
+				return fmt.Sprintf("must be an integer, not %v", x)
+			}
+		default:
+			if n, ok := v.(json.Number); ok {
+				if _, err := n.Int64(); err != nil {
+					return fmt.Sprintf("must be an integer, not %s", n)
+				}
+				return ""
+			}
+			return fmt.Sprintf("must be an integer, not %T", v)
+		}
+	case DoubleType, CurrencyType, PercentType:
+		switch rv.Kind() {
+		case reflect.Float32, reflect.Float64, reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		default:
+			if n, ok := v.(json.Number); ok {
+				if _, err := n.Float64(); err != nil {
+					return fmt.Sprintf("must be a number, not %s", n)
+				}
+				return ""
+			}
+			return fmt.Sprintf("must be a number, not %T", v)
+		}
+	case BooleanType:
+		if rv.Kind() != reflect.Bool {
+			return fmt.Sprintf("must be a boolean, not %T", v)
+		}
+	case DateType, DateTimeType:
+		switch t := v.(type) {
+		case time.Time, Time, *Time, Date, *Date:
+		case string:
+			if _, err := time.Parse("2006-01-02", t); err == nil {
+				return ""
+			}
+			if _, err := time.Parse("2006-01-02T15:04:05-07:00", t); err != nil {
+				return fmt.Sprintf("'%s' is not a date", t)
+			}
+		default:
+			return fmt.Sprintf("must be a date, not %T", v)
+		}
+	case LookupType, OwnerLookupType, UserLookupType:
+		switch v.(type) {
+		case Lookup, Owner, *Lookup, *Owner, string, map[string]interface{}, map[string]string:
+		default:
+			return fmt.Sprintf("must be a lookup, not %T", v)
+		}
+	}
+	return ""
+}
+
+// checklistItems returns whether each item of a checklist value is checked, the value is a []map[string]bool
+// as returned by Checklist, or the same list decoded from JSON
+func checklistItems(v interface{}) (map[string]bool, bool) {
+	checked := map[string]bool{}
+	switch list := v.(type) {
+	case []map[string]bool:
+		for _, m := range list {
+			for item, c := range m {
+				checked[item] = c
+			}
+		}
+	case []map[string]interface{}:
+		items := make([]interface{}, len(list))
+		for i, m := range list {
+			items[i] = m
+		}
+		return checklistItems(items)
+	case []interface{}:
+		for _, e := range list {
+			m, ok := e.(map[string]interface{})
+			if !ok {
+				return nil, false
+			}
+			for item, c := range m {
+				b, ok := c.(bool)
+				if !ok {
+					return nil, false
+				}
+				checked[item] = b
+			}
+		}
+	default:
+		return nil, false
+	}
+	return checked, true
+}
+
+func isEmptyValue(v interface{}) bool {
+	if v == nil {
+		return true
+	}
+	rv := reflect.ValueOf(v)
+	switch rv.Kind() {
+	case reflect.String, reflect.Slice, reflect.Map:
+		return rv.Len() == 0
+	case reflect.Ptr, reflect.Interface:
+		return rv.IsNil()
+	}
+	return false
+}
+
+// GetBlueprintTransitions returns the transitions that are available to the record specified by module and ID
+func (c *API) GetBlueprintTransitions(module Module, id string) ([]BlueprintTransition, error) {
+	blueprint, err := c.GetBlueprint(module, id)
+	if err != nil {
+		return nil, err
+	}
+	return blueprint.Blueprint.Transitions, nil
+}
+
+// ExecuteBlueprintTransition validates the data against the transition, specified by ID or name, that is available to
+// the record and then executes it. The data can include the transitions checklist, see Checklist, and attachments, see
+// BlueprintAttachment. If the data is invalid a BlueprintValidationError is returned and the transition is not executed.
+func (c *API) ExecuteBlueprintTransition(
+	module Module,
+	id string,
+	transition string,
+	data map[string]interface{},
+) (UpdateBlueprintResponse, error) {
+	blueprint, err := c.GetBlueprint(module, id)
+	if err != nil {
+		return UpdateBlueprintResponse{}, err
+	}
+
+	t, ok := blueprint.Transition(transition)
+	if !ok {
+		return UpdateBlueprintResponse{}, fmt.Errorf(
+			"Failed to execute transition, '%s' is not available to %s (%s)",
+			transition,
+			module,
+			id,
+		)
+	}
+	if err := t.Validate(data); err != nil {
+		return UpdateBlueprintResponse{}, err
+	}
+
+	if data == nil {
+		data = map[string]interface{}{}
+	}
+	return c.UpdateBlueprint(UpdateBlueprintData{
+		Blueprint: []BlueprintTransitionData{{TransitionID: t.ID, Data: data}},
+	}, module, id)
+}
@@ -0,0 +1,97 @@
+package crm
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestBlueprintFieldCheck(t *testing.T) {
+	text := BlueprintField{APIName: "Reason", DataType: TextType, Length: 5}
+	picklist := BlueprintField{APIName: "Status", DataType: PickListType, PickListValues: []PickListValue{{ActualValue: "Won"}}}
+	integer := BlueprintField{APIName: "Seats", DataType: IntegerType}
+	double := BlueprintField{APIName: "Amount", DataType: CurrencyType}
+	date := BlueprintField{APIName: "Closing_Date", DataType: DateType}
+	lookup := BlueprintField{APIName: "Account_Name", DataType: LookupType}
+	checklist := BlueprintField{APIName: "Checklist", DataType: ChecklistType, Mandatory: true, Items: []ChecklistItem{{Item: "Signed"}}}
+	attachment := BlueprintField{APIName: "Attachments", DataType: AttachmentType}
+
+	tests := []struct {
+		field BlueprintField
+		value interface{}
+		valid bool
+	}{
+		{text, "Price", true},
+		{text, "Too long", false},
+		{text, 5, false},
+		{picklist, "Won", true},
+		{picklist, "Lost", false},
+		{BlueprintField{DataType: MultiSelectType}, []string{"a"}, true},
+		{BlueprintField{DataType: MultiSelectType}, "a", false},
+		{integer, 3, true},
+		{integer, int64(3), true},
+		{integer, float64(3), true},
+		{integer, 3.5, false},
+		{integer, json.Number("3"), true},
+		{integer, json.Number("3.5"), false},
+		{integer, "3", false},
+		{BlueprintField{DataType: BigIntType}, float64(9007199254740992), true},
+		{double, 10.5, true},
+		{double, 10, true},
+		{double, json.Number("10.5"), true},
+		{double, "10.5", false},
+		{BlueprintField{DataType: BooleanType}, true, true},
+		{BlueprintField{DataType: BooleanType}, "true", false},
+		{date, "2021-03-04", true},
+		{date, Date(time.Now()), true},
+		{date, "04/03/2021", false},
+		{lookup, Lookup{ID: "1"}, true},
+		{lookup, map[string]interface{}{"id": "1"}, true},
+		{lookup, 1, false},
+		{checklist, Checklist("Signed"), true},
+		{checklist, []map[string]bool{{"Signed": false}}, false},
+		{checklist, []interface{}{map[string]interface{}{"Signed": true}}, true},
+		{checklist, []map[string]interface{}{{"Signed": true}}, true},
+		{checklist, []interface{}{map[string]interface{}{"Signed": "yes"}}, false},
+		{checklist, "Signed", false},
+		{attachment, []BlueprintAttachment{{FileID: "1"}}, true},
+		{attachment, []interface{}{map[string]interface{}{"$file_id": "1"}}, true},
+		{attachment, "1", false},
+	}
+
+	for _, tt := range tests {
+		problem := tt.field.check(tt.value)
+		if valid := problem == ""; valid != tt.valid {
+			t.Errorf("%s check(%#v) = %q, want valid %t", tt.field.DataType, tt.value, problem, tt.valid)
+		}
+	}
+}
+
+func TestBlueprintTransitionValidate(t *testing.T) {
+	transition := BlueprintTransition{Name: "Close", Fields: []BlueprintField{
+		{APIName: "Reason", DataType: TextType, Mandatory: true},
+		{APIName: "Seats", DataType: IntegerType},
+	}}
+
+	// the data of a transition decoded from JSON, with notes which are not a field of the transition
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(`{"Reason":"Price","Seats":3,"Notes":"Called"}`), &data); err != nil {
+		t.Fatal(err)
+	}
+	if err := transition.Validate(data); err != nil {
+		t.Errorf("valid data returned %s", err)
+	}
+
+	err := transition.Validate(map[string]interface{}{"Seats": "3"})
+	verr, ok := err.(BlueprintValidationError)
+	if !ok {
+		t.Fatalf("returned %v, want a BlueprintValidationError", err)
+	}
+	if len(verr.Missing) != 1 || verr.Missing[0] != "Reason" || verr.Invalid["Seats"] == "" {
+		t.Errorf("returned %+v", verr)
+	}
+	if !strings.Contains(err.Error(), "missing mandatory fields Reason") {
+		t.Errorf("returned %s", err)
+	}
+}
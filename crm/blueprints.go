@@ -48,29 +48,7 @@ type BlueprintResponse struct {
 			ID           string `json:"id"`
 			FieldName    string `json:"field_name"`
 		} `json:"process_info"`
-		Transitions []struct {
-			NextTransitions    []string `json:"next_transitions"`
-			PercentPartialSave float64  `json:"percent_partial_save"`
-			Data               struct {
-				Attachments string `json:"Attachments"`
-			} `json:"data"`
-			NextFieldValue  string `json:"next_field_value"`
-			Name            string `json:"name"`
-			CriteriaMatched bool   `json:"criteria_matched"`
-			ID              string `json:"id"`
-			Fields          []struct {
-				DisplayLabel       string `json:"display_label"`
-				Type               string `json:"_type"`
-				DataType           string `json:"data_type"`
-				ColumnName         string `json:"column_name"`
-				PersonalityName    string `json:"personality_name"`
-				ID                 string `json:"id"`
-				TransitionSequence int    `json:"transition_sequence"`
-				Mandatory          bool   `json:"mandatory"`
-				Layouts            string `json:"layouts"`
-			} `json:"fields"`
-			CriteriaMessage string `json:"criteria_message"`
-		} `json:"transitions"`
+		Transitions []BlueprintTransition `json:"transitions"`
 	} `json:"blueprint"`
 }
 
@@ -108,10 +86,13 @@ func (c *API) UpdateBlueprint(
 
 // UpdateBlueprintData is the data that should be provided to UpdateBlueprint
 type UpdateBlueprintData struct {
-	Blueprint []struct {
-		TransitionID string                 `json:"transition_id"`
-		Data         map[string]interface{} `json:"data"`
-	} `json:"blueprint"`
+	Blueprint []BlueprintTransitionData `json:"blueprint"`
+}
+
+// BlueprintTransitionData is the transition to execute, and the values of the transitions fields
+type BlueprintTransitionData struct {
+	TransitionID string                 `json:"transition_id"`
+	Data         map[string]interface{} `json:"data"`
 }
 
 // UpdateBlueprintResponse is the data returned by UpdateBlueprint
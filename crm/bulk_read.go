@@ -0,0 +1,286 @@
+package crm
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// CreateBulkReadJob schedules a bulk read job that exports the records of a module matching the query
+// https://www.zoho.com/crm/developer/docs/api/v2/bulk-read/create-job.html
+func (c *API) CreateBulkReadJob(request BulkReadData) (data BulkJobResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "bulk",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/bulk/v2/read", c.ZohoTLD),
+		Method:       zoho.HTTPPost,
+		ResponseData: &BulkJobResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return BulkJobResponse{}, fmt.Errorf("Failed to create bulk read job: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*BulkJobResponse); ok {
+		return *v, nil
+	}
+
+	return BulkJobResponse{}, fmt.Errorf("Data returned was not 'BulkJobResponse'")
+}
+
+// BulkReadData is the data provided to CreateBulkReadJob
+type BulkReadData struct {
+	Callback *BulkCallback `json:"callback,omitempty"`
+	Query    BulkReadQuery `json:"query"`
+	FileType string        `json:"file_type,omitempty"`
+}
+
+// BulkCallback is the URL that Zoho will notify when a bulk job is complete
+type BulkCallback struct {
+	URL    string `json:"url,omitempty"`
+	Method string `json:"method,omitempty"`
+}
+
+// BulkReadQuery specifies which records and fields a bulk read job exports. When there are more
+// than 200,000 matching records, subsequent pages must be requested with a new job.
+type BulkReadQuery struct {
+	Module   Module             `json:"module"`
+	CVID     string             `json:"cvid,omitempty"`
+	Fields   []string           `json:"fields,omitempty"`
+	Criteria *BulkReadCriterion `json:"criteria,omitempty"`
+	Page     int                `json:"page,omitempty"`
+}
+
+// BulkReadCriterion filters the records exported by a bulk read job. A criterion either compares
+// APIName against Value using Comparator, or combines the criteria in Group with GroupOperator.
+type BulkReadCriterion struct {
+	APIName       string              `json:"api_name,omitempty"`
+	Comparator    string              `json:"comparator,omitempty"`
+	Value         interface{}         `json:"value,omitempty"`
+	GroupOperator string              `json:"group_operator,omitempty"`
+	Group         []BulkReadCriterion `json:"group,omitempty"`
+}
+
+// BulkJobResponse is the data returned when a bulk read job is created
+type BulkJobResponse struct {
+	Data []struct {
+		Status  string `json:"status,omitempty"`
+		Code    string `json:"code,omitempty"`
+		Message string `json:"message,omitempty"`
+		Details struct {
+			ID          string `json:"id,omitempty"`
+			Operation   string `json:"operation,omitempty"`
+			State       string `json:"state,omitempty"`
+			CreatedBy   Owner  `json:"created_by,omitempty"`
+			CreatedTime Time   `json:"created_time,omitempty"`
+		} `json:"details,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// BulkJobState is the state of a bulk read or bulk write job
+type BulkJobState = string
+
+const (
+	// BulkJobAdded - the job has been scheduled
+	BulkJobAdded BulkJobState = "ADDED"
+	// BulkJobQueued - the job is waiting to be processed
+	BulkJobQueued BulkJobState = "QUEUED"
+	// BulkJobInProgress - the job is being processed
+	BulkJobInProgress BulkJobState = "IN PROGRESS"
+	// BulkJobCompleted - the job has finished and the result can be downloaded
+	BulkJobCompleted BulkJobState = "COMPLETED"
+	// BulkJobFailed - the job could not be completed
+	BulkJobFailed BulkJobState = "FAILED"
+)
+
+// GetBulkReadJob returns the details of the bulk read job specified by jobID
+// https://www.zoho.com/crm/developer/docs/api/v2/bulk-read/get-job-details.html
+func (c *API) GetBulkReadJob(jobID string) (data BulkReadJobDetailsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "bulk",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/bulk/v2/read/%s", c.ZohoTLD, jobID),
+		Method:       zoho.HTTPGet,
+		ResponseData: &BulkReadJobDetailsResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return BulkReadJobDetailsResponse{}, fmt.Errorf("Failed to retrieve bulk read job (%s): %s", jobID, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*BulkReadJobDetailsResponse); ok {
+		return *v, nil
+	}
+
+	return BulkReadJobDetailsResponse{}, fmt.Errorf("Data returned was not 'BulkReadJobDetailsResponse'")
+}
+
+// BulkReadJobDetailsResponse is the data returned by GetBulkReadJob
+type BulkReadJobDetailsResponse struct {
+	Data []struct {
+		ID          string        `json:"id,omitempty"`
+		Operation   string        `json:"operation,omitempty"`
+		State       BulkJobState  `json:"state,omitempty"`
+		Query       BulkReadQuery `json:"query,omitempty"`
+		CreatedBy   Owner         `json:"created_by,omitempty"`
+		CreatedTime Time          `json:"created_time,omitempty"`
+		Result      struct {
+			Page        int    `json:"page,omitempty"`
+			Count       int    `json:"count,omitempty"`
+			DownloadURL string `json:"download_url,omitempty"`
+			PerPage     int    `json:"per_page,omitempty"`
+			MoreRecords bool   `json:"more_records,omitempty"`
+		} `json:"result,omitempty"`
+		FileType string `json:"file_type,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// WaitForBulkReadJob polls the bulk read job specified by jobID every interval until it is completed,
+// it has failed, or the context is done.
+func (c *API) WaitForBulkReadJob(ctx context.Context, jobID string, interval time.Duration) (data BulkReadJobDetailsResponse, err error) {
+	for {
+		data, err = c.GetBulkReadJob(jobID)
+		if err != nil {
+			return BulkReadJobDetailsResponse{}, err
+		}
+		if len(data.Data) == 0 {
+			return BulkReadJobDetailsResponse{}, fmt.Errorf("Bulk read job (%s) was not found", jobID)
+		}
+
+		switch data.Data[0].State {
+		case BulkJobCompleted:
+			return data, nil
+		case BulkJobFailed:
+			return data, fmt.Errorf("Bulk read job (%s) failed", jobID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return data, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// DownloadBulkReadResult downloads the zip file produced by a completed bulk read job and
+// returns the contents of the CSV file it contains. The whole file is held in memory, the result of
+// a large export should be read with OpenBulkReadResult.
+// https://www.zoho.com/crm/developer/docs/api/v2/bulk-read/download-result.html
+func (c *API) DownloadBulkReadResult(jobID string) ([]byte, error) {
+	result, err := c.OpenBulkReadResult(jobID)
+	if err != nil {
+		return nil, err
+	}
+	defer result.Close()
+
+	csvData, err := ioutil.ReadAll(result)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read bulk read result (%s): %s", jobID, err)
+	}
+	return csvData, nil
+}
+
+// OpenBulkReadResult downloads the zip file produced by a completed bulk read job to a temporary file,
+// and returns a reader of the CSV file it contains. Closing the reader removes the temporary file. The
+// rows can be decoded one at a time with a CSVDecoder, so the records are never all held in memory.
+//
+//    result, err := c.OpenBulkReadResult(jobID)
+//    defer result.Close()
+//    d := crm.NewCSVDecoder(result)
+// https://www.zoho.com/crm/developer/docs/api/v2/bulk-read/download-result.html
+func (c *API) OpenBulkReadResult(jobID string) (io.ReadCloser, error) {
+	endpoint := zoho.Endpoint{
+		Name:   "bulk",
+		URL:    fmt.Sprintf("https://www.zohoapis.%s/crm/bulk/v2/read/%s/result", c.ZohoTLD, jobID),
+		Method: zoho.HTTPGet,
+	}
+
+	resp, err := c.Zoho.HTTPStreamRequest(&endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to download bulk read result (%s): %s", jobID, err)
+	}
+	defer resp.Body.Close()
+
+	file, err := ioutil.TempFile("", "zoho-bulk-read-*.zip")
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create temporary file for bulk read result (%s): %s", jobID, err)
+	}
+	result := &tempFileReader{file: file}
+
+	size, err := io.Copy(file, resp.Body)
+	if err != nil {
+		result.Close()
+		return nil, fmt.Errorf("Failed to read bulk read result (%s): %s", jobID, err)
+	}
+
+	result.ReadCloser, err = openFirstFile(file, size)
+	if err != nil {
+		result.Close()
+		return nil, err
+	}
+	return result, nil
+}
+
+// tempFileReader reads a file of a zip archive saved to a temporary file, which is removed when closed
+type tempFileReader struct {
+	io.ReadCloser
+	file *os.File
+}
+
+func (r *tempFileReader) Close() error {
+	if r.ReadCloser != nil {
+		r.ReadCloser.Close()
+	}
+	r.file.Close()
+	return os.Remove(r.file.Name())
+}
+
+// ReadBulkReadResult downloads the result of a completed bulk read job and decodes the CSV rows into out,
+// which must be a pointer to a slice of structs or a pointer to a []map[string]string. Struct fields are
+// matched to the CSV columns by their json tag. Every record is held in out, OpenBulkReadResult and
+// a CSVDecoder can instead be used to handle one record at a time.
+func (c *API) ReadBulkReadResult(jobID string, out interface{}) error {
+	result, err := c.OpenBulkReadResult(jobID)
+	if err != nil {
+		return err
+	}
+	defer result.Close()
+
+	return DecodeCSV(result, out)
+}
+
+// openFirstFile opens the first file in the zip archive
+func openFirstFile(r io.ReaderAt, size int64) (io.ReadCloser, error) {
+	z, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open zip archive: %s", err)
+	}
+	if len(z.File) == 0 {
+		return nil, fmt.Errorf("Zip archive is empty")
+	}
+
+	f, err := z.File[0].Open()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to open '%s' in zip archive: %s", z.File[0].Name, err)
+	}
+	return f, nil
+}
+
+// unzipFirstFile returns the contents of the first file in the zip archive
+func unzipFirstFile(data []byte) ([]byte, error) {
+	f, err := openFirstFile(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	return ioutil.ReadAll(f)
+}
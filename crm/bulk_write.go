@@ -0,0 +1,330 @@
+package crm
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// UploadBulkFile uploads a CSV file to be used by a bulk write job. If the file is not already
+// a zip archive it will be compressed before upload. The CRM organization ID is required in the
+// request, it is retrieved with GetOrganization on the first upload.
+// https://www.zoho.com/crm/developer/docs/api/v2/bulk-write/upload-file.html
+func (c *API) UploadBulkFile(file string) (data UploadBulkFileResponse, err error) {
+	contents, err := ioutil.ReadFile(file)
+	if err != nil {
+		return UploadBulkFileResponse{}, fmt.Errorf("Failed to read bulk file '%s': %s", file, err)
+	}
+
+	name := filepath.Base(file)
+	if !strings.EqualFold(filepath.Ext(name), ".zip") {
+		contents, err = zipFile(name, contents)
+		if err != nil {
+			return UploadBulkFileResponse{}, err
+		}
+		name = strings.TrimSuffix(name, filepath.Ext(name)) + ".zip"
+	}
+
+	orgID, err := c.orgID()
+	if err != nil {
+		return UploadBulkFileResponse{}, err
+	}
+
+	endpoint := zoho.Endpoint{
+		Name:             "bulk",
+		URL:              fmt.Sprintf("https://content.zohoapis.%s/crm/v2/upload", c.ZohoTLD),
+		Method:           zoho.HTTPPost,
+		ResponseData:     &UploadBulkFileResponse{},
+		BodyFormat:       zoho.FILE,
+		Attachment:       name,
+		AttachmentReader: bytes.NewReader(contents),
+		AttachmentField:  "file",
+		Headers: map[string]string{
+			"X-CRM-ORG": orgID,
+			"feature":   "bulk-write",
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UploadBulkFileResponse{}, fmt.Errorf("Failed to upload bulk file: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UploadBulkFileResponse); ok {
+		return *v, nil
+	}
+
+	return UploadBulkFileResponse{}, fmt.Errorf("Data returned was not 'UploadBulkFileResponse'")
+}
+
+// UploadBulkFileResponse is the data returned by UploadBulkFile
+type UploadBulkFileResponse struct {
+	Status  string `json:"status,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	Details struct {
+		FileID      string `json:"file_id,omitempty"`
+		CreatedTime Time   `json:"created_time,omitempty"`
+	} `json:"details,omitempty"`
+}
+
+// orgID returns the ID of the CRM organization, which is not the OrganizationID of zoho.Zoho used by the
+// finance products. It is retrieved with GetOrganization once.
+func (c *API) orgID() (string, error) {
+	c.zgidMu.Lock()
+	defer c.zgidMu.Unlock()
+	if c.zgid != "" {
+		return c.zgid, nil
+	}
+
+	org, err := c.GetOrganization()
+	if err != nil {
+		return "", err
+	}
+	if len(org.Org) == 0 || org.Org[0].Zgid == "" {
+		return "", fmt.Errorf("Failed to retrieve the organization ID")
+	}
+	c.zgid = org.Org[0].Zgid
+	return c.zgid, nil
+}
+
+func zipFile(name string, contents []byte) ([]byte, error) {
+	var b bytes.Buffer
+	w := zip.NewWriter(&b)
+	f, err := w.Create(name)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create zip archive: %s", err)
+	}
+	if _, err = f.Write(contents); err != nil {
+		return nil, fmt.Errorf("Failed to write zip archive: %s", err)
+	}
+	if err = w.Close(); err != nil {
+		return nil, fmt.Errorf("Failed to close zip archive: %s", err)
+	}
+	return b.Bytes(), nil
+}
+
+// CreateBulkWriteJob starts a bulk write job which inserts, updates or upserts the records of uploaded files
+// https://www.zoho.com/crm/developer/docs/api/v2/bulk-write/create-job.html
+func (c *API) CreateBulkWriteJob(request BulkWriteData) (data BulkWriteJobResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "bulk",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/bulk/v2/write", c.ZohoTLD),
+		Method:       zoho.HTTPPost,
+		ResponseData: &BulkWriteJobResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return BulkWriteJobResponse{}, fmt.Errorf("Failed to create bulk write job: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*BulkWriteJobResponse); ok {
+		return *v, nil
+	}
+
+	return BulkWriteJobResponse{}, fmt.Errorf("Data returned was not 'BulkWriteJobResponse'")
+}
+
+// BulkWriteOperation is the kind of bulk write job
+type BulkWriteOperation = string
+
+const (
+	// BulkInsert inserts every row as a new record
+	BulkInsert BulkWriteOperation = "insert"
+	// BulkUpdate updates the records matched by FindBy
+	BulkUpdate BulkWriteOperation = "update"
+	// BulkUpsert updates the records matched by FindBy and inserts the others
+	BulkUpsert BulkWriteOperation = "upsert"
+)
+
+// BulkWriteData is the data provided to CreateBulkWriteJob
+type BulkWriteData struct {
+	CharacterEncoding string              `json:"character_encoding,omitempty"`
+	Operation         BulkWriteOperation  `json:"operation"`
+	Callback          *BulkCallback       `json:"callback,omitempty"`
+	Resource          []BulkWriteResource `json:"resource"`
+}
+
+// BulkWriteResource maps the columns of an uploaded file to the fields of a module
+type BulkWriteResource struct {
+	Type          string             `json:"type"`
+	Module        Module             `json:"module"`
+	FileID        string             `json:"file_id"`
+	IgnoreEmpty   bool               `json:"ignore_empty,omitempty"`
+	FindBy        string             `json:"find_by,omitempty"`
+	FieldMappings []BulkFieldMapping `json:"field_mappings,omitempty"`
+}
+
+// BulkFieldMapping maps the column at Index to the field APIName
+type BulkFieldMapping struct {
+	APIName      string            `json:"api_name"`
+	Index        *int              `json:"index,omitempty"`
+	Format       string            `json:"format,omitempty"`
+	FindBy       string            `json:"find_by,omitempty"`
+	DefaultValue map[string]string `json:"default_value,omitempty"`
+}
+
+// BulkWriteJobResponse is the data returned by CreateBulkWriteJob
+type BulkWriteJobResponse struct {
+	Status  string `json:"status,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	Details struct {
+		ID        string `json:"id,omitempty"`
+		CreatedBy Owner  `json:"created_by,omitempty"`
+	} `json:"details,omitempty"`
+}
+
+// GetBulkWriteJob returns the details of the bulk write job specified by jobID
+// https://www.zoho.com/crm/developer/docs/api/v2/bulk-write/get-status.html
+func (c *API) GetBulkWriteJob(jobID string) (data BulkWriteJobDetailsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "bulk",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/bulk/v2/write/%s", c.ZohoTLD, jobID),
+		Method:       zoho.HTTPGet,
+		ResponseData: &BulkWriteJobDetailsResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return BulkWriteJobDetailsResponse{}, fmt.Errorf("Failed to retrieve bulk write job (%s): %s", jobID, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*BulkWriteJobDetailsResponse); ok {
+		return *v, nil
+	}
+
+	return BulkWriteJobDetailsResponse{}, fmt.Errorf("Data returned was not 'BulkWriteJobDetailsResponse'")
+}
+
+// BulkWriteJobDetailsResponse is the data returned by GetBulkWriteJob
+type BulkWriteJobDetailsResponse struct {
+	Status            BulkJobState `json:"status,omitempty"`
+	CharacterEncoding string       `json:"character_encoding,omitempty"`
+	Resource          []struct {
+		Status        BulkJobState       `json:"status,omitempty"`
+		Type          string             `json:"type,omitempty"`
+		Module        Module             `json:"module,omitempty"`
+		FieldMappings []BulkFieldMapping `json:"field_mappings,omitempty"`
+		File          struct {
+			Status       BulkJobState `json:"status,omitempty"`
+			Name         string       `json:"name,omitempty"`
+			AddedCount   int          `json:"added_count,omitempty"`
+			SkippedCount int          `json:"skipped_count,omitempty"`
+			UpdatedCount int          `json:"updated_count,omitempty"`
+			TotalCount   int          `json:"total_count,omitempty"`
+		} `json:"file,omitempty"`
+	} `json:"resource,omitempty"`
+	ID       string        `json:"id,omitempty"`
+	Callback *BulkCallback `json:"callback,omitempty"`
+	Result   struct {
+		DownloadURL string `json:"download_url,omitempty"`
+	} `json:"result,omitempty"`
+	CreatedBy   Owner              `json:"created_by,omitempty"`
+	Operation   BulkWriteOperation `json:"operation,omitempty"`
+	CreatedTime Time               `json:"created_time,omitempty"`
+}
+
+// WaitForBulkWriteJob polls the bulk write job specified by jobID every interval until it is completed,
+// it has failed, or the context is done.
+func (c *API) WaitForBulkWriteJob(ctx context.Context, jobID string, interval time.Duration) (data BulkWriteJobDetailsResponse, err error) {
+	for {
+		data, err = c.GetBulkWriteJob(jobID)
+		if err != nil {
+			return BulkWriteJobDetailsResponse{}, err
+		}
+
+		switch data.Status {
+		case BulkJobCompleted:
+			return data, nil
+		case BulkJobFailed:
+			return data, fmt.Errorf("Bulk write job (%s) failed", jobID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return data, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
+// GetBulkWriteResult downloads the result file of a completed bulk write job. Each row of the uploaded
+// file is returned with the ID of the record it produced, or the errors that caused it to be skipped.
+// https://www.zoho.com/crm/developer/docs/api/v2/bulk-write/download-result.html
+func (c *API) GetBulkWriteResult(jobID string) (data []BulkWriteResultRow, err error) {
+	job, err := c.GetBulkWriteJob(jobID)
+	if err != nil {
+		return nil, err
+	}
+	if job.Result.DownloadURL == "" {
+		return nil, fmt.Errorf("Bulk write job (%s) has no result to download, status is %s", jobID, job.Status)
+	}
+
+	endpoint := zoho.Endpoint{
+		Name:   "bulk",
+		URL:    job.Result.DownloadURL,
+		Method: zoho.HTTPGet,
+	}
+
+	resp, err := c.Zoho.HTTPStreamRequest(&endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to download bulk write result (%s): %s", jobID, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read bulk write result (%s): %s", jobID, err)
+	}
+
+	csvData, err := unzipFirstFile(body)
+	if err != nil {
+		return nil, err
+	}
+
+	rows := []map[string]string{}
+	if err := DecodeCSV(bytes.NewReader(csvData), &rows); err != nil {
+		return nil, err
+	}
+
+	for i, row := range rows {
+		result := BulkWriteResultRow{
+			Row:      i + 1,
+			Status:   row["STATUS"],
+			RecordID: row["RECORD_ID"],
+			Errors:   row["ERRORS"],
+			Fields:   row,
+		}
+		delete(row, "STATUS")
+		delete(row, "RECORD_ID")
+		delete(row, "ERRORS")
+		data = append(data, result)
+	}
+
+	return data, nil
+}
+
+// BulkWriteResultRow is a single row of a bulk write result file. Row is the 1-based row number of the
+// uploaded file, Fields holds the values of the uploaded columns.
+type BulkWriteResultRow struct {
+	Row      int
+	Status   string
+	RecordID string
+	Errors   string
+	Fields   map[string]string
+}
+
+// Failed reports whether the row was not written
+func (r BulkWriteResultRow) Failed() bool {
+	return r.Errors != "" || strings.EqualFold(r.Status, "SKIPPED")
+}
@@ -0,0 +1,63 @@
+package crm
+
+import (
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/zohotest"
+)
+
+func TestUploadBulkFileOrganization(t *testing.T) {
+	srv := zohotest.NewServer()
+	defer srv.Close()
+	srv.Seed("crm/org", zohotest.Record{"id": "4000001", "zgid": "7000001"})
+
+	z := srv.Zoho()
+	// the organization of the finance products must not be sent to CRM
+	z.SetOrganizationID("60012345")
+
+	var orgs []string
+	z.Use(func(next zoho.Handler) zoho.Handler {
+		return func(endpoint *zoho.Endpoint, req *http.Request) (*http.Response, error) {
+			if endpoint.Name != "bulk" {
+				return next(endpoint, req)
+			}
+			orgs = append(orgs, req.Header.Get("X-CRM-ORG"))
+			return &http.Response{StatusCode: http.StatusOK, Body: http.NoBody, Request: req}, nil
+		}
+	})
+
+	dir, err := ioutil.TempDir("", "crm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	file := filepath.Join(dir, "leads.csv")
+	if err := ioutil.WriteFile(file, []byte("Last_Name\nSmith\n"), 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	c := New(z)
+	for i := 0; i < 2; i++ {
+		if _, err := c.UploadBulkFile(file); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	if len(orgs) != 2 || orgs[0] != "7000001" || orgs[1] != "7000001" {
+		t.Errorf("X-CRM-ORG headers were %v, want the zgid of the CRM organization", orgs)
+	}
+	retrieved := 0
+	for _, r := range srv.Requests() {
+		if r.Path == "/crm/v2/org" {
+			retrieved++
+		}
+	}
+	if retrieved != 1 {
+		t.Errorf("organization was retrieved %d times, want once", retrieved)
+	}
+}
@@ -0,0 +1,288 @@
+package crm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// LeadConversion is the data provided to ConvertLeadWith
+//
+// The lead is merged into the account or contact specified by ID, or when MatchAccount or MatchContact is set,
+// into the single existing record found by FindDuplicates using the values the lead's fields map to on conversion.
+// MatchFields provides the fields checked for duplicates in a module without unique fields, eg. Account_Name.
+// When Deal is provided a deal is created with its fields, which may include custom fields. CRM moves the notes
+// and attachments of the lead to the contact, CopyNotesTo copies the notes to the account or deal as well and
+// MoveAttachmentsTo moves the attachments to the account or deal instead.
+type LeadConversion struct {
+	Account              string
+	Contact              string
+	MatchAccount         bool
+	MatchContact         bool
+	MatchFields          map[Module][]string
+	Deal                 interface{}
+	AssignTo             string
+	Overwrite            bool
+	NotifyLeadOwner      bool
+	NotifyNewEntityOwner bool
+	CopyNotesTo          []Module
+	MoveAttachmentsTo    Module
+}
+
+// LeadConversionResult is the data returned by ConvertLeadWith
+type LeadConversionResult struct {
+	ContactID     string
+	AccountID     string
+	DealID        string
+	MergedContact bool
+	MergedAccount bool
+	NotesCopied   int
+}
+
+// ID returns the ID of the record created or merged into in the module
+func (r LeadConversionResult) ID(module Module) string {
+	switch module {
+	case ContactsModule:
+		return r.ContactID
+	case AccountsModule:
+		return r.AccountID
+	case DealsModule, PotentialsModule:
+		return r.DealID
+	}
+	return ""
+}
+
+// ConvertLeadWith will convert the Lead record specified by ID to a contact, account and optionally a deal,
+// as described by the conversion
+// https://www.zoho.com/crm/developer/docs/api/v2.1/convert-lead.html
+func (c *API) ConvertLeadWith(ID string, conversion LeadConversion) (result LeadConversionResult, err error) {
+	result.AccountID, result.ContactID = conversion.Account, conversion.Contact
+
+	if (conversion.MatchAccount && result.AccountID == "") || (conversion.MatchContact && result.ContactID == "") {
+		candidates, err := c.convertedLeadValues(ID)
+		if err != nil {
+			return LeadConversionResult{}, err
+		}
+		if conversion.MatchAccount && result.AccountID == "" {
+			result.AccountID, err = c.matchConverted(AccountsModule, candidates[AccountsModule], conversion.MatchFields[AccountsModule])
+			if err != nil {
+				return LeadConversionResult{}, err
+			}
+		}
+		if conversion.MatchContact && result.ContactID == "" {
+			result.ContactID, err = c.matchConverted(ContactsModule, candidates[ContactsModule], conversion.MatchFields[ContactsModule])
+			if err != nil {
+				return LeadConversionResult{}, err
+			}
+		}
+	}
+	result.MergedAccount, result.MergedContact = result.AccountID != "", result.ContactID != ""
+
+	// the notes are read before converting, the lead is deleted by the conversion
+	var notes NotesResponse
+	if len(conversion.CopyNotesTo) > 0 {
+		notes, err = c.GetNote(LeadsModule, ID)
+		if err != nil {
+			return LeadConversionResult{}, err
+		}
+	}
+
+	request := convertLeadData{
+		Overwrite:            conversion.Overwrite,
+		NotifyLeadOwner:      conversion.NotifyLeadOwner,
+		NotifyNewEntityOwner: conversion.NotifyNewEntityOwner,
+		Accounts:             result.AccountID,
+		Contacts:             result.ContactID,
+		AssignTo:             conversion.AssignTo,
+		Deals:                conversion.Deal,
+	}
+	if conversion.MoveAttachmentsTo != "" {
+		request.MoveAttachmentsTo = &convertLeadModule{APIName: conversion.MoveAttachmentsTo}
+	}
+
+	endpoint := zoho.Endpoint{
+		Name:         "records",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2.1/%s/%s/actions/convert", c.ZohoTLD, LeadsModule, ID),
+		Method:       zoho.HTTPPost,
+		ResponseData: &convertLeadResponse{},
+		RequestBody: struct {
+			Data []convertLeadData `json:"data"`
+		}{[]convertLeadData{request}},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return LeadConversionResult{}, fmt.Errorf("Failed to convert lead (%s): %s", ID, err)
+	}
+
+	v, ok := endpoint.ResponseData.(*convertLeadResponse)
+	if !ok || len(v.Data) == 0 {
+		return LeadConversionResult{}, fmt.Errorf("Data returned was not 'convertLeadResponse'")
+	}
+
+	converted := v.Data[0]
+	if converted.Status == "error" {
+		return LeadConversionResult{}, fmt.Errorf("Failed to convert lead (%s): %s: %s", ID, converted.Code, converted.Message)
+	}
+	result.ContactID = firstConvertedID(converted.Contacts, converted.Details.Contacts)
+	result.AccountID = firstConvertedID(converted.Accounts, converted.Details.Accounts)
+	result.DealID = firstConvertedID(converted.Deals, converted.Details.Deals)
+
+	for _, module := range conversion.CopyNotesTo {
+		parent := result.ID(module)
+		if parent == "" {
+			continue
+		}
+		copied, err := c.copyNotes(notes, module, parent)
+		result.NotesCopied += copied
+		if err != nil {
+			return result, fmt.Errorf("Lead (%s) was converted, but failed to copy notes to %s: %s", ID, module, err)
+		}
+	}
+
+	return result, nil
+}
+
+// convertedLeadValues returns the values of the lead's fields, keyed by the module and API name they are mapped to
+// when the lead is converted
+func (c *API) convertedLeadValues(ID string) (map[Module]map[string]interface{}, error) {
+	metadata, err := c.GetFieldsMetadata(LeadsModule)
+	if err != nil {
+		return nil, err
+	}
+
+	v, err := c.GetRecord(&RecordsResponse{}, LeadsModule, ID)
+	if err != nil {
+		return nil, err
+	}
+	records, ok := v.(*RecordsResponse)
+	if !ok || len(records.Data) == 0 {
+		return nil, fmt.Errorf("Lead (%s) was not found", ID)
+	}
+	lead := records.Data[0]
+
+	values := map[Module]map[string]interface{}{}
+	for _, f := range metadata.Fields {
+		if !lead.Has(f.APIName) || lead.IsNull(f.APIName) {
+			continue
+		}
+		for module, mapped := range f.ConvertMapping {
+			target, ok := mapped.(string)
+			if !ok || target == "" {
+				continue
+			}
+			var value interface{}
+			if err := lead.Get(f.APIName, &value); err != nil {
+				return nil, err
+			}
+			if values[Module(module)] == nil {
+				values[Module(module)] = map[string]interface{}{}
+			}
+			values[Module(module)][target] = value
+		}
+	}
+	return values, nil
+}
+
+// matchConverted returns the ID of the single record of the module duplicating the values, or an empty string
+// when there is none
+func (c *API) matchConverted(module Module, values map[string]interface{}, fields []string) (string, error) {
+	if len(values) == 0 {
+		return "", nil
+	}
+
+	var duplicates []Duplicate
+	var err error
+	if len(fields) > 0 {
+		duplicates, err = c.FindDuplicatesBy(module, values, fields...)
+	} else {
+		duplicates, err = c.FindDuplicates(module, values)
+	}
+	if err != nil {
+		return "", err
+	}
+
+	switch len(duplicates) {
+	case 0:
+		return "", nil
+	case 1:
+		return duplicates[0].ID, nil
+	}
+	return "", fmt.Errorf("Failed to convert lead, found %d matching records in %s", len(duplicates), module)
+}
+
+// copyNotes creates a copy of each note on the record specified by module and parent
+func (c *API) copyNotes(notes NotesResponse, module Module, parent string) (copied int, err error) {
+	const maxNotes = 100
+	for start := 0; start < len(notes.Data); start += maxNotes {
+		end := start + maxNotes
+		if end > len(notes.Data) {
+			end = len(notes.Data)
+		}
+
+		request := CreateNoteData{}
+		for _, n := range notes.Data[start:end] {
+			request.Data = append(request.Data, NoteData{
+				NoteTitle:   n.NoteTitle,
+				NoteContent: n.NoteContent,
+				ParentID:    parent,
+				SeModule:    string(module),
+			})
+		}
+
+		if _, err := c.CreateNotes(request); err != nil {
+			return copied, err
+		}
+		copied += end - start
+	}
+	return copied, nil
+}
+
+type convertLeadData struct {
+	Overwrite            bool               `json:"overwrite,omitempty"`
+	NotifyLeadOwner      bool               `json:"notify_lead_owner,omitempty"`
+	NotifyNewEntityOwner bool               `json:"notify_new_entity_owner,omitempty"`
+	Accounts             string             `json:"Accounts,omitempty"`
+	Contacts             string             `json:"Contacts,omitempty"`
+	AssignTo             string             `json:"assign_to,omitempty"`
+	Deals                interface{}        `json:"Deals,omitempty"`
+	MoveAttachmentsTo    *convertLeadModule `json:"move_attachments_to,omitempty"`
+}
+
+type convertLeadModule struct {
+	APIName Module `json:"api_name"`
+}
+
+// convertLeadResponse accepts both the v2 response, with the IDs in each element, and the v2.1 response with
+// the IDs or lookups in the details
+type convertLeadResponse struct {
+	Data []struct {
+		Code     string          `json:"code,omitempty"`
+		Message  string          `json:"message,omitempty"`
+		Status   string          `json:"status,omitempty"`
+		Contacts json.RawMessage `json:"Contacts,omitempty"`
+		Accounts json.RawMessage `json:"Accounts,omitempty"`
+		Deals    json.RawMessage `json:"Deals,omitempty"`
+		Details  struct {
+			Contacts json.RawMessage `json:"Contacts,omitempty"`
+			Accounts json.RawMessage `json:"Accounts,omitempty"`
+			Deals    json.RawMessage `json:"Deals,omitempty"`
+		} `json:"details,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// firstConvertedID returns the ID in the first of the values which is an ID or a lookup
+func firstConvertedID(values ...json.RawMessage) string {
+	for _, raw := range values {
+		var id string
+		if json.Unmarshal(raw, &id) == nil && id != "" {
+			return id
+		}
+		var lookup Lookup
+		if json.Unmarshal(raw, &lookup) == nil && lookup.ID != "" {
+			return lookup.ID
+		}
+	}
+	return ""
+}
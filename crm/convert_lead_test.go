@@ -0,0 +1,84 @@
+package crm
+
+import (
+	"testing"
+
+	"github.com/schmorrison/Zoho/zohotest"
+)
+
+// convertServer seeds the fields of the mock server, which returns the same fields for every module, so that
+// Email is unique and the lead fields are mapped to the contact and account
+func convertServer() *zohotest.Server {
+	srv := zohotest.NewServer()
+	srv.Seed("crm/settings/fields",
+		zohotest.Record{"api_name": "Last_Name", "convert_mapping": map[string]interface{}{"Contacts": "Last_Name"}},
+		zohotest.Record{
+			"api_name":        "Email",
+			"unique":          map[string]interface{}{"casesensitive": "false"},
+			"convert_mapping": map[string]interface{}{"Contacts": "Email"},
+		},
+		zohotest.Record{"api_name": "Company", "convert_mapping": map[string]interface{}{"Accounts": "Account_Name"}},
+	)
+	return srv
+}
+
+func TestConvertLeadWith(t *testing.T) {
+	srv := convertServer()
+	defer srv.Close()
+	contact := srv.Seed("crm/Contacts", zohotest.Record{"Last_Name": "Smith", "Email": "smith@example.com"})[0]
+	lead := srv.Seed("crm/Leads", zohotest.Record{"Last_Name": "Smith", "Email": "smith@example.com", "Company": "Acme"})[0]
+	srv.Seed("crm/Notes",
+		zohotest.Record{"Note_Title": "Call", "Note_Content": "Called", "Parent_Id": map[string]interface{}{"id": lead}},
+		zohotest.Record{"Note_Title": "Other", "Parent_Id": map[string]interface{}{"id": "1"}},
+	)
+	c := New(srv.Zoho())
+
+	result, err := c.ConvertLeadWith(lead, LeadConversion{
+		MatchContact: true,
+		Deal:         map[string]interface{}{"Deal_Name": "Acme deal", "Stage": "Qualification"},
+		CopyNotesTo:  []Module{AccountsModule, DealsModule},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if result.ContactID != contact || !result.MergedContact {
+		t.Errorf("contact %s merged %t, want the duplicate %s", result.ContactID, result.MergedContact, contact)
+	}
+	if result.AccountID == "" || result.MergedAccount || result.DealID == "" {
+		t.Errorf("returned %+v, want a new account and deal", result)
+	}
+	if result.NotesCopied != 2 {
+		t.Errorf("copied %d notes, want the note of the lead copied to the account and deal", result.NotesCopied)
+	}
+
+	copies := map[string]bool{}
+	for _, n := range srv.Records("crm/Notes") {
+		if n["Note_Title"] == "Call" {
+			copies[formatValue(n["Parent_Id"])] = true
+		}
+	}
+	if !copies[result.AccountID] || !copies[result.DealID] {
+		t.Errorf("notes were copied to %v", copies)
+	}
+	if len(srv.Records("crm/Leads")) != 0 {
+		t.Error("lead was not converted")
+	}
+}
+
+func TestConvertLeadWithAmbiguousMatch(t *testing.T) {
+	srv := convertServer()
+	defer srv.Close()
+	srv.Seed("crm/Contacts",
+		zohotest.Record{"Last_Name": "Smith", "Email": "smith@example.com"},
+		zohotest.Record{"Last_Name": "Smith", "Email": "smith@example.com"},
+	)
+	lead := srv.Seed("crm/Leads", zohotest.Record{"Last_Name": "Smith", "Email": "smith@example.com"})[0]
+
+	if _, err := New(srv.Zoho()).ConvertLeadWith(lead, LeadConversion{MatchContact: true}); err == nil {
+		t.Error("a lead matching 2 contacts was converted")
+	}
+	if len(srv.Records("crm/Leads")) != 1 {
+		t.Error("lead was converted")
+	}
+}
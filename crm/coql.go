@@ -0,0 +1,103 @@
+package crm
+
+import (
+	"fmt"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// COQLMaxLimit is the most rows a single COQL query can return
+const COQLMaxLimit = 200
+
+// COQLMaxRows is the most rows that can be retrieved by paging through a COQL query with an offset
+const COQLMaxRows = 10000
+
+// QueryRecords executes the COQL select statement and decodes the rows into response, for example
+// a *COQLResponse or one of the record types in this package.
+// https://www.zoho.com/crm/developer/docs/api/v2/COQL-Overview.html
+func (c *API) QueryRecords(response interface{}, query string) (data interface{}, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "coql",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/coql", c.ZohoTLD),
+		Method:       zoho.HTTPPost,
+		ResponseData: response,
+		RequestBody: COQLData{
+			SelectQuery: query,
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to execute COQL query: %s", err)
+	}
+
+	if endpoint.ResponseData != nil {
+		return endpoint.ResponseData, nil
+	}
+
+	return nil, fmt.Errorf("Data returned was nil")
+}
+
+// COQLData is the data provided to the COQL endpoint
+type COQLData struct {
+	SelectQuery string `json:"select_query"`
+}
+
+// COQLResponse is a generic response to a COQL query
+type COQLResponse struct {
+	Data []map[string]interface{} `json:"data,omitempty"`
+	Info PageInfo                 `json:"info,omitempty"`
+}
+
+// QueryAllRecords executes the query one page at a time, passing the rows of each page to fn, until there
+// are no more records or fn returns an error. Pages start at the offset of the query, and a limit set on the
+// query is the total number of rows passed to fn. A query without an order is ordered by id, so the pages
+// do not overlap. The query is not modified.
+//
+// COQL can not page beyond the first COQLMaxRows rows, an error is returned when more rows remain after them,
+// the where clause should then be narrowed (eg. by id or Created_Time) and the query repeated.
+func (c *API) QueryAllRecords(query *SelectQuery, fn func(rows []map[string]interface{}) error) error {
+	remaining := query.limit
+	for offset := query.offset; ; offset += COQLMaxLimit {
+		if offset >= COQLMaxRows {
+			return fmt.Errorf("Failed to query all records, COQL can not return rows beyond the first %d", COQLMaxRows)
+		}
+		size := COQLMaxLimit
+		if query.limit > 0 && remaining < size {
+			size = remaining
+		}
+		if offset+size > COQLMaxRows {
+			size = COQLMaxRows - offset
+		}
+
+		q := *query
+		if len(q.orderBy) == 0 {
+			q.orderBy = []string{"id asc"}
+		}
+		statement, err := q.Limit(size).Offset(offset).Build()
+		if err != nil {
+			return err
+		}
+
+		v, err := c.QueryRecords(&COQLResponse{}, statement)
+		if err != nil {
+			return err
+		}
+
+		page, ok := v.(*COQLResponse)
+		if !ok {
+			return fmt.Errorf("Data returned was not 'COQLResponse'")
+		}
+
+		if len(page.Data) > 0 {
+			if err := fn(page.Data); err != nil {
+				return err
+			}
+		}
+		remaining -= len(page.Data)
+
+		if !page.Info.MoreRecords || len(page.Data) == 0 || (query.limit > 0 && remaining <= 0) {
+			return nil
+		}
+	}
+}
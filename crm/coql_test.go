@@ -0,0 +1,97 @@
+package crm
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/schmorrison/Zoho/zohotest"
+)
+
+func TestQueryAllRecords(t *testing.T) {
+	srv := zohotest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 450; i++ {
+		srv.Seed("crm/Leads", zohotest.Record{"Last_Name": fmt.Sprintf("Lead %03d", i), "Lead_Source": "Web"})
+	}
+	c := New(srv.Zoho())
+
+	tests := []struct {
+		limit, offset int
+		want          int
+	}{
+		{0, 0, 450},
+		{0, 300, 150},
+		{250, 0, 250},
+		{50, 420, 30},
+	}
+
+	for _, tt := range tests {
+		query := Select("Last_Name").From(LeadsModule).Where(Where("Lead_Source", Equals, "Web")).
+			OrderBy("Last_Name", Asc).Limit(tt.limit).Offset(tt.offset)
+		before := query.String()
+
+		var names []string
+		err := c.QueryAllRecords(query, func(rows []map[string]interface{}) error {
+			if len(rows) > COQLMaxLimit {
+				t.Errorf("page of %d rows", len(rows))
+			}
+			for _, row := range rows {
+				names = append(names, row["Last_Name"].(string))
+			}
+			return nil
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(names) != tt.want {
+			t.Errorf("limit %d offset %d: got %d rows, want %d", tt.limit, tt.offset, len(names), tt.want)
+		} else if first := fmt.Sprintf("Lead %03d", tt.offset); names[0] != first {
+			t.Errorf("limit %d offset %d: first row is %s, want %s", tt.limit, tt.offset, names[0], first)
+		}
+		if after := query.String(); after != before {
+			t.Errorf("query was modified from %q to %q", before, after)
+		}
+	}
+}
+
+func TestQueryAllRecordsOrder(t *testing.T) {
+	srv := zohotest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 3; i++ {
+		srv.Seed("crm/Leads", zohotest.Record{"Last_Name": fmt.Sprintf("Lead %d", i)})
+	}
+	c := New(srv.Zoho())
+
+	query := Select("Last_Name").From(LeadsModule).Where(Where("Last_Name", IsNotNull))
+	if err := c.QueryAllRecords(query, func(rows []map[string]interface{}) error { return nil }); err != nil {
+		t.Fatal(err)
+	}
+	requests := srv.Requests()
+	if len(requests) != 1 || !strings.Contains(string(requests[0].Body), "order by id asc limit 200") {
+		t.Errorf("sent %+v, want a query ordered by id", requests)
+	}
+}
+
+func TestQueryAllRecordsMaxRows(t *testing.T) {
+	srv := zohotest.NewServer()
+	defer srv.Close()
+	for i := 0; i < COQLMaxRows+1; i++ {
+		srv.Seed("crm/Leads", zohotest.Record{"Last_Name": "Smith"})
+	}
+	c := New(srv.Zoho())
+
+	rows := 0
+	query := Select("Last_Name").From(LeadsModule).Where(Where("Last_Name", Equals, "Smith")).Offset(COQLMaxRows - 250)
+	err := c.QueryAllRecords(query, func(page []map[string]interface{}) error {
+		rows += len(page)
+		return nil
+	})
+	if err == nil || !strings.Contains(err.Error(), "beyond the first") {
+		t.Errorf("returned %v, want an error for the rows beyond the first %d", err, COQLMaxRows)
+	}
+	if rows != 250 {
+		t.Errorf("passed %d rows, want 250", rows)
+	}
+}
@@ -2,6 +2,7 @@ package crm
 
 import (
 	"math/rand"
+	"sync"
 	"time"
 
 	zoho "github.com/schmorrison/Zoho"
@@ -37,6 +38,10 @@ const (
 type API struct {
 	*zoho.Zoho
 	id string
+
+	// zgid is the organization ID sent in the X-CRM-ORG header, retrieved once with GetOrganization
+	zgidMu sync.Mutex
+	zgid   string
 }
 
 // New returns a *crm.API with the provided zoho.Zoho as an embedded field
@@ -0,0 +1,277 @@
+package crm
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// DecodeCSV decodes the rows of a CSV file, such as a bulk read result, into out. The first row of the
+// file must be the header. out must be a pointer to a []map[string]string, or a pointer to a slice of
+// structs (or struct pointers) whose fields are matched to the columns by their json tag. The fields of
+// embedded structs are matched as if they were fields of the outer struct.
+//
+// Basic kinds are converted with strconv, types implementing json.Unmarshaler (eg. Time and Date)
+// receive the value as a JSON string, and struct fields with an ID field (eg. Lookup and Owner)
+// receive the value as their ID. Empty values are left as the zero value.
+//
+// Every row is held in out, large files can be decoded one row at a time with a CSVDecoder.
+func DecodeCSV(r io.Reader, out interface{}) error {
+	rv := reflect.ValueOf(out)
+	if rv.Kind() != reflect.Ptr || rv.Elem().Kind() != reflect.Slice {
+		return fmt.Errorf("Failed to decode CSV, out must be a pointer to a slice")
+	}
+	slice := rv.Elem()
+	elemType := slice.Type().Elem()
+
+	d := NewCSVDecoder(r)
+	for {
+		elem := reflect.New(elemType).Elem()
+		err := d.decode(elem)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		slice.Set(reflect.Append(slice, elem))
+	}
+}
+
+// CSVDecoder decodes the rows of a CSV file one at a time, in the same way as DecodeCSV
+//
+//    d := crm.NewCSVDecoder(r)
+//    for {
+//        lead := Lead{}
+//        if err := d.Decode(&lead); err == io.EOF {
+//            break
+//        } else if err != nil {
+//            return err
+//        }
+//    }
+type CSVDecoder struct {
+	reader *csv.Reader
+	header []string
+	line   int
+}
+
+// NewCSVDecoder returns a CSVDecoder reading from r, the first row must be the header
+func NewCSVDecoder(r io.Reader) *CSVDecoder {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.ReuseRecord = true
+	return &CSVDecoder{reader: reader}
+}
+
+// Decode decodes the next row into v, which must be a pointer to a struct (or struct pointer) or a pointer
+// to a map[string]string. io.EOF is returned when there are no more rows.
+func (d *CSVDecoder) Decode(v interface{}) error {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() {
+		return fmt.Errorf("Failed to decode CSV, v must be a non-nil pointer")
+	}
+	return d.decode(rv.Elem())
+}
+
+func (d *CSVDecoder) decode(elem reflect.Value) error {
+	if d.header == nil {
+		header, err := d.reader.Read()
+		if err == io.EOF {
+			return io.EOF
+		}
+		if err != nil {
+			return fmt.Errorf("Failed to read CSV header: %s", err)
+		}
+		d.header = append([]string{}, header...)
+		// a UTF-8 byte order mark may precede the first column name
+		if len(d.header) > 0 {
+			d.header[0] = strings.TrimPrefix(d.header[0], "\ufeff")
+		}
+		d.line = 1
+	}
+
+	d.line++
+	row, err := d.reader.Read()
+	if err == io.EOF {
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to read CSV row %d: %s", d.line, err)
+	}
+
+	if err := decodeCSVRow(d.header, row, elem); err != nil {
+		return fmt.Errorf("Failed to decode CSV row %d: %s", d.line, err)
+	}
+	return nil
+}
+
+func decodeCSVRow(header, row []string, elem reflect.Value) error {
+	if elem.Kind() == reflect.Map {
+		if elem.Type().Key().Kind() != reflect.String || elem.Type().Elem().Kind() != reflect.String {
+			return fmt.Errorf("unsupported map type %s", elem.Type())
+		}
+		elem.Set(reflect.MakeMap(elem.Type()))
+		for i, name := range header {
+			if i < len(row) {
+				elem.SetMapIndex(reflect.ValueOf(name), reflect.ValueOf(row[i]).Convert(elem.Type().Elem()))
+			}
+		}
+		return nil
+	}
+
+	if elem.Kind() == reflect.Ptr {
+		if elem.IsNil() {
+			elem.Set(reflect.New(elem.Type().Elem()))
+		}
+		elem = elem.Elem()
+	}
+	if elem.Kind() != reflect.Struct {
+		return fmt.Errorf("unsupported element type %s", elem.Type())
+	}
+
+	fields := csvFieldIndex(elem.Type())
+	for i, name := range header {
+		index, ok := fields[name]
+		if !ok || i >= len(row) || row[i] == "" {
+			continue
+		}
+		if err := setCSVValue(csvField(elem, index), row[i]); err != nil {
+			return fmt.Errorf("column %s: %s", name, err)
+		}
+	}
+	return nil
+}
+
+// csvFieldIndex maps json tag names to the field indexes of the struct type. The fields of embedded structs
+// without a json name are included, unless the outer struct has a field with the same name.
+func csvFieldIndex(t reflect.Type) map[string][]int {
+	fields := map[string][]int{}
+	var embedded [][]int
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			// the fields of an unexported embedded struct pointer can not be allocated
+			if f.PkgPath == "" || f.Type.Kind() != reflect.Ptr {
+				embedded = append(embedded, f.Index)
+			}
+			continue
+		}
+		if f.PkgPath != "" {
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		fields[name] = f.Index
+	}
+
+	for _, index := range embedded {
+		ft := t.FieldByIndex(index).Type
+		if ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		for name, sub := range csvFieldIndex(ft) {
+			if _, ok := fields[name]; !ok {
+				fields[name] = append(append([]int{}, index...), sub...)
+			}
+		}
+	}
+	return fields
+}
+
+// csvField returns the field of the struct at index, allocating nil embedded struct pointers on the way
+func csvField(v reflect.Value, index []int) reflect.Value {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Ptr {
+			if v.IsNil() {
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v
+}
+
+func setCSVValue(v reflect.Value, s string) error {
+	if v.Kind() == reflect.Ptr {
+		v.Set(reflect.New(v.Type().Elem()))
+		v = v.Elem()
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(s)
+		return nil
+	case reflect.Bool:
+		b, err := strconv.ParseBool(s)
+		if err != nil {
+			return err
+		}
+		v.SetBool(b)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, err := strconv.ParseInt(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetInt(n)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		n, err := strconv.ParseUint(s, 10, 64)
+		if err != nil {
+			return err
+		}
+		v.SetUint(n)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(n)
+		return nil
+	case reflect.Slice:
+		if v.Type().Elem().Kind() == reflect.String {
+			// multi-select values are separated by semicolons in CRM exports
+			parts := strings.Split(s, ";")
+			v.Set(reflect.MakeSlice(v.Type(), 0, len(parts)))
+			for _, p := range parts {
+				v.Set(reflect.Append(v, reflect.ValueOf(p).Convert(v.Type().Elem())))
+			}
+			return nil
+		}
+	}
+
+	if u, ok := v.Addr().Interface().(json.Unmarshaler); ok {
+		quoted, _ := json.Marshal(s)
+		return u.UnmarshalJSON(quoted)
+	}
+
+	if v.Kind() == reflect.Struct {
+		if id := v.FieldByName("ID"); id.IsValid() && id.Kind() == reflect.String {
+			id.SetString(s)
+			return nil
+		}
+	}
+
+	if v.Kind() == reflect.Interface && v.NumMethod() == 0 {
+		v.Set(reflect.ValueOf(s))
+		return nil
+	}
+
+	return fmt.Errorf("unsupported field type %s", v.Type())
+}
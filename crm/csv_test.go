@@ -0,0 +1,196 @@
+package crm
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/zohotest"
+)
+
+func TestDecodeCSV(t *testing.T) {
+	type lead struct {
+		ID          string      `json:"id"`
+		LastName    string      `json:"Last_Name"`
+		Employees   Number      `json:"No_of_Employees"`
+		Revenue     Currency    `json:"Annual_Revenue"`
+		EmailOptOut bool        `json:"Email_Opt_Out"`
+		Tags        MultiSelect `json:"Tags"`
+		Owner       Owner       `json:"Owner"`
+		Account     *Lookup     `json:"Account_Name"`
+		Birthday    Date        `json:"Birthday"`
+		CreatedTime *Time       `json:"Created_Time"`
+		Description string      `json:"-"`
+	}
+
+	file := "\ufeffid,Last_Name,No_of_Employees,Annual_Revenue,Email_Opt_Out,Tags,Owner,Account_Name,Birthday,Created_Time,Description,Unknown\n" +
+		"1,\"Smith, Jr\",12,1500.5,true,Web;Trade Show,100,200,1990-04-05,2021-03-04T05:06:07+01:00,ignored,x\n" +
+		"2,Jones,,,,,,,,,,\n"
+
+	var leads []lead
+	if err := DecodeCSV(strings.NewReader(file), &leads); err != nil {
+		t.Fatal(err)
+	}
+	if len(leads) != 2 {
+		t.Fatalf("decoded %d rows, want 2", len(leads))
+	}
+
+	created := Time(time.Date(2021, 3, 4, 5, 6, 7, 0, time.FixedZone("", 3600)))
+	want := lead{
+		ID:          "1",
+		LastName:    "Smith, Jr",
+		Employees:   12,
+		Revenue:     1500.5,
+		EmailOptOut: true,
+		Tags:        MultiSelect{"Web", "Trade Show"},
+		Owner:       Owner{ID: "100"},
+		Account:     &Lookup{ID: "200"},
+		Birthday:    Date(time.Date(1990, 4, 5, 0, 0, 0, 0, time.UTC)),
+	}
+	got := leads[0]
+	if got.CreatedTime == nil || !time.Time(*got.CreatedTime).Equal(time.Time(created)) {
+		t.Errorf("Created_Time decoded as %v", got.CreatedTime)
+	}
+	got.CreatedTime = nil
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("decoded %+v, want %+v", got, want)
+	}
+	if !reflect.DeepEqual(leads[1], lead{ID: "2", LastName: "Jones"}) {
+		t.Errorf("empty values decoded as %+v", leads[1])
+	}
+}
+
+func TestDecodeCSVMaps(t *testing.T) {
+	var rows []map[string]string
+	if err := DecodeCSV(strings.NewReader("id,Last_Name\n1,Smith\n2\n"), &rows); err != nil {
+		t.Fatal(err)
+	}
+	want := []map[string]string{{"id": "1", "Last_Name": "Smith"}, {"id": "2"}}
+	if !reflect.DeepEqual(rows, want) {
+		t.Errorf("decoded %v, want %v", rows, want)
+	}
+}
+
+func TestDecodeCSVErrors(t *testing.T) {
+	var leads []struct {
+		Employees int `json:"No_of_Employees"`
+	}
+	err := DecodeCSV(strings.NewReader("No_of_Employees\n10\nmany\n"), &leads)
+	if err == nil || !strings.Contains(err.Error(), "row 3") {
+		t.Errorf("invalid number returned error %v", err)
+	}
+
+	if err := DecodeCSV(strings.NewReader("id\n1\n"), leads); err == nil {
+		t.Error("a slice which is not a pointer did not return an error")
+	}
+}
+
+func TestDecodeCSVEmbedded(t *testing.T) {
+	type person struct {
+		ID       string `json:"id"`
+		LastName string `json:"Last_Name"`
+	}
+	type Address struct {
+		City string `json:"City"`
+	}
+	type lead struct {
+		person
+		*Address
+		ID string `json:"Lead_ID"`
+	}
+
+	var leads []lead
+	if err := DecodeCSV(strings.NewReader("id,Lead_ID,Last_Name,City\n1,L1,Smith,Paris\n"), &leads); err != nil {
+		t.Fatal(err)
+	}
+	want := []lead{{person: person{ID: "1", LastName: "Smith"}, Address: &Address{City: "Paris"}, ID: "L1"}}
+	if !reflect.DeepEqual(leads, want) {
+		t.Errorf("decoded %+v, want %+v", leads, want)
+	}
+
+	type outer struct {
+		person
+		LastName string `json:"Last_Name"`
+	}
+	var rows []outer
+	if err := DecodeCSV(strings.NewReader("Last_Name\nSmith\n"), &rows); err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1 || rows[0].LastName != "Smith" || rows[0].person.LastName != "" {
+		t.Errorf("outer field did not take precedence, decoded %+v", rows)
+	}
+}
+
+func TestCSVDecoder(t *testing.T) {
+	d := NewCSVDecoder(strings.NewReader("id,Last_Name\n1,Smith\n2,Jones\n"))
+	var names []string
+	for {
+		var row struct {
+			LastName string `json:"Last_Name"`
+		}
+		err := d.Decode(&row)
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		names = append(names, row.LastName)
+	}
+	if !reflect.DeepEqual(names, []string{"Smith", "Jones"}) {
+		t.Errorf("decoded %v", names)
+	}
+
+	if err := NewCSVDecoder(strings.NewReader("")).Decode(&map[string]string{}); err != io.EOF {
+		t.Errorf("empty file returned %v, want io.EOF", err)
+	}
+}
+
+func TestOpenBulkReadResult(t *testing.T) {
+	var archive bytes.Buffer
+	zw := zip.NewWriter(&archive)
+	f, err := zw.Create("111.csv")
+	if err != nil {
+		t.Fatal(err)
+	}
+	f.Write([]byte("id,Last_Name\n1,Smith\n"))
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	srv := zohotest.NewServer()
+	defer srv.Close()
+	z := srv.Zoho()
+	z.Use(func(next zoho.Handler) zoho.Handler {
+		return func(endpoint *zoho.Endpoint, req *http.Request) (*http.Response, error) {
+			if endpoint.Name != "bulk" {
+				return next(endpoint, req)
+			}
+			body := ioutil.NopCloser(bytes.NewReader(archive.Bytes()))
+			return &http.Response{StatusCode: http.StatusOK, Body: body, Request: req}, nil
+		}
+	})
+	c := New(z)
+
+	before, _ := filepath.Glob(filepath.Join(os.TempDir(), "zoho-bulk-read-*.zip"))
+	var rows []map[string]string
+	if err := c.ReadBulkReadResult("111", &rows); err != nil {
+		t.Fatal(err)
+	}
+	if want := []map[string]string{{"id": "1", "Last_Name": "Smith"}}; !reflect.DeepEqual(rows, want) {
+		t.Errorf("decoded %v, want %v", rows, want)
+	}
+	after, _ := filepath.Glob(filepath.Join(os.TempDir(), "zoho-bulk-read-*.zip"))
+	if len(after) > len(before) {
+		t.Errorf("temporary files were not removed: %v", after)
+	}
+}
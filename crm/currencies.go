@@ -0,0 +1,194 @@
+package crm
+
+import (
+	"fmt"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// GetCurrencies will return the currencies of a multi-currency organization
+// https://www.zoho.com/crm/developer/docs/api/v2/get-currencies.html
+func (c *API) GetCurrencies() (data CurrenciesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "currencies",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/org/currencies", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &CurrenciesResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return CurrenciesResponse{}, fmt.Errorf("Failed to retrieve currencies: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*CurrenciesResponse); ok {
+		return *v, nil
+	}
+
+	return CurrenciesResponse{}, fmt.Errorf("Data returned was not 'CurrenciesResponse'")
+}
+
+// GetCurrency will return the currency specified by id
+// https://www.zoho.com/crm/developer/docs/api/v2/get-currencies.html
+func (c *API) GetCurrency(id string) (data CurrenciesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "currencies",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/org/currencies/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPGet,
+		ResponseData: &CurrenciesResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return CurrenciesResponse{}, fmt.Errorf("Failed to retrieve currency (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*CurrenciesResponse); ok {
+		return *v, nil
+	}
+
+	return CurrenciesResponse{}, fmt.Errorf("Data returned was not 'CurrenciesResponse'")
+}
+
+// CurrenciesResponse is the data returned by GetCurrencies and GetCurrency
+type CurrenciesResponse struct {
+	Currencies []OrgCurrency `json:"currencies,omitempty"`
+}
+
+// OrgCurrency is a currency of a multi-currency organization. The ExchangeRate is the value of the base
+// currency in this currency.
+type OrgCurrency struct {
+	Symbol             string          `json:"symbol,omitempty"`
+	CreatedTime        *Time           `json:"created_time,omitempty"`
+	IsActive           *bool           `json:"is_active,omitempty"`
+	ExchangeRate       string          `json:"exchange_rate,omitempty"`
+	Format             *CurrencyFormat `json:"format,omitempty"`
+	CreatedBy          *Lookup         `json:"created_by,omitempty"`
+	PrefixSymbol       *bool           `json:"prefix_symbol,omitempty"`
+	IsBase             bool            `json:"is_base,omitempty"`
+	ModifiedTime       *Time           `json:"modified_time,omitempty"`
+	Name               string          `json:"name,omitempty"`
+	ModifiedBy         *Lookup         `json:"modified_by,omitempty"`
+	ID                 string          `json:"id,omitempty"`
+	ISOCode            string          `json:"iso_code,omitempty"`
+	AutoRateEnabled    *bool           `json:"auto_rate_enabled,omitempty"`
+	AutoRateUpdateFreq string          `json:"auto_rate_update_frequency,omitempty"`
+}
+
+// CurrencyFormat is how amounts in a currency are displayed
+type CurrencyFormat struct {
+	DecimalSeparator  string `json:"decimal_separator,omitempty"`
+	DecimalPlaces     string `json:"decimal_places,omitempty"`
+	ThousandSeparator string `json:"thousand_separator,omitempty"`
+}
+
+// AddCurrencies will add currencies to a multi-currency organization, the Name, ISOCode, Symbol and
+// ExchangeRate of each must be provided
+// https://www.zoho.com/crm/developer/docs/api/v2/add-currencies.html
+func (c *API) AddCurrencies(request CurrenciesData) (data CurrencyActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "currencies",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/org/currencies", c.ZohoTLD),
+		Method:       zoho.HTTPPost,
+		ResponseData: &CurrencyActionResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return CurrencyActionResponse{}, fmt.Errorf("Failed to add currencies: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*CurrencyActionResponse); ok {
+		return *v, nil
+	}
+
+	return CurrencyActionResponse{}, fmt.Errorf("Data returned was not 'CurrencyActionResponse'")
+}
+
+// UpdateCurrencies will modify the currencies, eg. their exchange rates, the ID of each must be provided
+// https://www.zoho.com/crm/developer/docs/api/v2/update-currencies.html
+func (c *API) UpdateCurrencies(request CurrenciesData) (data CurrencyActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "currencies",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/org/currencies", c.ZohoTLD),
+		Method:       zoho.HTTPPut,
+		ResponseData: &CurrencyActionResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return CurrencyActionResponse{}, fmt.Errorf("Failed to update currencies: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*CurrencyActionResponse); ok {
+		return *v, nil
+	}
+
+	return CurrencyActionResponse{}, fmt.Errorf("Data returned was not 'CurrencyActionResponse'")
+}
+
+// EnableMultiCurrency will enable multiple currencies for the organization, using the provided base currency
+// https://www.zoho.com/crm/developer/docs/api/v2/enable-currency.html
+func (c *API) EnableMultiCurrency(base OrgCurrency) (data BaseCurrencyResponse, err error) {
+	return c.baseCurrency(zoho.HTTPPost, base)
+}
+
+// UpdateBaseCurrency will modify the base currency of a multi-currency organization
+// https://www.zoho.com/crm/developer/docs/api/v2/update-currency.html
+func (c *API) UpdateBaseCurrency(base OrgCurrency) (data BaseCurrencyResponse, err error) {
+	return c.baseCurrency(zoho.HTTPPut, base)
+}
+
+func (c *API) baseCurrency(method zoho.HTTPMethod, base OrgCurrency) (data BaseCurrencyResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "currencies",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/org/currencies/actions/enable", c.ZohoTLD),
+		Method:       method,
+		ResponseData: &BaseCurrencyResponse{},
+		RequestBody: struct {
+			BaseCurrency OrgCurrency `json:"base_currency"`
+		}{base},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return BaseCurrencyResponse{}, fmt.Errorf("Failed to set base currency: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*BaseCurrencyResponse); ok {
+		return *v, nil
+	}
+
+	return BaseCurrencyResponse{}, fmt.Errorf("Data returned was not 'BaseCurrencyResponse'")
+}
+
+// CurrenciesData is the data provided to AddCurrencies and UpdateCurrencies
+type CurrenciesData struct {
+	Currencies []OrgCurrency `json:"currencies"`
+}
+
+// CurrencyActionResponse is the data returned by AddCurrencies and UpdateCurrencies
+type CurrencyActionResponse struct {
+	Currencies []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ID string `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"currencies,omitempty"`
+}
+
+// BaseCurrencyResponse is the data returned by EnableMultiCurrency and UpdateBaseCurrency
+type BaseCurrencyResponse struct {
+	BaseCurrency struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ID string `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"base_currency,omitempty"`
+}
@@ -0,0 +1,124 @@
+package crm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Duplicate is an existing record matching a record provided to FindDuplicates, Fields are the API names of
+// the fields with the same value
+type Duplicate struct {
+	ID     string
+	Fields []string
+	Row    map[string]interface{}
+}
+
+// FindDuplicates will return the records of the module with the same value as the record in any of the unique fields
+// of the module. The record can be a map of API names to values, a *Record, or a struct marshalled with the API names.
+func (c *API) FindDuplicates(module Module, record interface{}) ([]Duplicate, error) {
+	metadata, err := c.GetFieldsMetadata(module)
+	if err != nil {
+		return nil, err
+	}
+
+	fields := []string{}
+	for _, f := range metadata.Fields {
+		if f.IsUnique() {
+			fields = append(fields, f.APIName)
+		}
+	}
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("Failed to find duplicates, %s has no unique fields", module)
+	}
+
+	return c.FindDuplicatesBy(module, record, fields...)
+}
+
+// FindDuplicatesBy will return the records of the module with the same value as the record in any of the provided
+// fields, fields without a value in the record are ignored
+func (c *API) FindDuplicatesBy(module Module, record interface{}, fields ...string) ([]Duplicate, error) {
+	values, err := duplicateValues(record, fields)
+	if err != nil {
+		return nil, err
+	}
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	criteria := []Criteria{}
+	selected := []string{"id"}
+	for _, f := range fields {
+		if v, ok := values[f]; ok {
+			criteria = append(criteria, Where(f, Equals, v))
+			selected = append(selected, f)
+		}
+	}
+
+	where := criteria[0]
+	if len(criteria) > 1 {
+		where = where.Or(criteria[1:]...)
+	}
+
+	duplicates := []Duplicate{}
+	err = c.QueryAllRecords(Select(selected...).From(module).Where(where), func(rows []map[string]interface{}) error {
+		for _, row := range rows {
+			d := Duplicate{ID: fmt.Sprint(row["id"]), Row: row}
+			for _, f := range selected[1:] {
+				if sameValue(row[f], values[f]) {
+					d.Fields = append(d.Fields, f)
+				}
+			}
+			duplicates = append(duplicates, d)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("Failed to find duplicates in %s: %s", module, err)
+	}
+
+	return duplicates, nil
+}
+
+// duplicateValues returns the scalar values of the fields in the record, empty values are omitted
+func duplicateValues(record interface{}, fields []string) (map[string]interface{}, error) {
+	b, err := json.Marshal(record)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to marshal record: %s", err)
+	}
+
+	row := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&row); err != nil {
+		return nil, fmt.Errorf("Failed to find duplicates, record is not a JSON object: %s", err)
+	}
+
+	values := map[string]interface{}{}
+	for _, f := range fields {
+		switch v := row[f].(type) {
+		case string:
+			if v != "" {
+				values[f] = v
+			}
+		case json.Number:
+			if i, err := v.Int64(); err == nil {
+				values[f] = i
+			} else if n, err := v.Float64(); err == nil {
+				values[f] = n
+			}
+		case bool:
+			values[f] = v
+		}
+	}
+	return values, nil
+}
+
+// sameValue compares a value returned by COQL with a value of the record, strings are compared case insensitively
+func sameValue(a, b interface{}) bool {
+	if b == nil {
+		return false
+	}
+	return strings.EqualFold(formatValue(a), formatValue(b))
+}
@@ -0,0 +1,61 @@
+package crm
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/schmorrison/Zoho/zohotest"
+)
+
+func TestFindDuplicates(t *testing.T) {
+	srv := zohotest.NewServer()
+	defer srv.Close()
+	srv.Seed("crm/settings/fields",
+		zohotest.Record{"api_name": "Email", "unique": map[string]interface{}{"casesensitive": "false"}},
+		zohotest.Record{"api_name": "Phone"},
+	)
+	ids := srv.Seed("crm/Contacts",
+		zohotest.Record{"Last_Name": "Smith", "Email": "SMITH@example.com", "Phone": "555"},
+		zohotest.Record{"Last_Name": "Jones", "Email": "jones@example.com", "Phone": "555"},
+	)
+	c := New(srv.Zoho())
+
+	tests := []struct {
+		name   string
+		find   func() ([]Duplicate, error)
+		ids    []string
+		fields [][]string
+	}{
+		{"unique fields", func() ([]Duplicate, error) {
+			return c.FindDuplicates(ContactsModule, map[string]interface{}{"Email": "smith@example.com", "Phone": "555"})
+		}, ids[:1], [][]string{{"Email"}}},
+		{"provided fields", func() ([]Duplicate, error) {
+			return c.FindDuplicatesBy(ContactsModule, map[string]interface{}{"Email": "smith@example.com", "Phone": "555"}, "Email", "Phone")
+		}, ids, [][]string{{"Email", "Phone"}, {"Phone"}}},
+		{"struct", func() ([]Duplicate, error) {
+			return c.FindDuplicates(ContactsModule, struct {
+				Email string `json:"Email"`
+			}{"jones@example.com"})
+		}, ids[1:], [][]string{{"Email"}}},
+		{"empty value", func() ([]Duplicate, error) {
+			return c.FindDuplicates(ContactsModule, map[string]interface{}{"Email": ""})
+		}, nil, nil},
+	}
+
+	for _, tt := range tests {
+		duplicates, err := tt.find()
+		if err != nil {
+			t.Errorf("%s: %s", tt.name, err)
+			continue
+		}
+		var gotIDs []string
+		var gotFields [][]string
+		for _, d := range duplicates {
+			gotIDs = append(gotIDs, d.ID)
+			gotFields = append(gotFields, d.Fields)
+		}
+		if !reflect.DeepEqual(gotIDs, tt.ids) || !reflect.DeepEqual(gotFields, tt.fields) {
+			t.Errorf("%s: found %v with fields %v, want %v with fields %v", tt.name, gotIDs, gotFields, tt.ids, tt.fields)
+		}
+	}
+}
@@ -0,0 +1,308 @@
+package crm
+
+import (
+	"fmt"
+	"html"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// SendMail will send the email from the record specified by module and id, the email is listed in the Emails of the
+// record. The From address must be one returned by GetFromAddresses. Files uploaded with UploadFile can be sent as
+// Attachments, or shown inline in an html Content with InlineImage.
+// https://www.zoho.com/crm/developer/docs/api/v2/send-mail.html
+func (c *API) SendMail(module Module, id string, mail SendMailData) (data SendMailResponse, err error) {
+	if mail.Template == nil && (mail.Subject == "" || mail.Content == "") {
+		return SendMailResponse{}, fmt.Errorf("Failed to send mail, must provide a template or a subject and content")
+	}
+
+	endpoint := zoho.Endpoint{
+		Name:         "send_mail",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/%s/%s/actions/send_mail", c.ZohoTLD, module, id),
+		Method:       zoho.HTTPPost,
+		ResponseData: &SendMailResponse{},
+		RequestBody: struct {
+			Data []SendMailData `json:"data"`
+		}{[]SendMailData{mail}},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return SendMailResponse{}, fmt.Errorf("Failed to send mail from %s (%s): %s", module, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*SendMailResponse); ok {
+		return *v, nil
+	}
+
+	return SendMailResponse{}, fmt.Errorf("Data returned was not 'SendMailResponse'")
+}
+
+// MailFormat is the format of the content of an email
+type MailFormat = string
+
+// Formats of SendMailData
+const (
+	HTMLMail MailFormat = "html"
+	TextMail MailFormat = "text"
+)
+
+// SendMailData is the email provided to SendMail. When a Template is provided its subject and content are used,
+// with the merge fields filled from the record.
+type SendMailData struct {
+	From          EmailAddress      `json:"from"`
+	To            []EmailAddress    `json:"to"`
+	Cc            []EmailAddress    `json:"cc,omitempty"`
+	Bcc           []EmailAddress    `json:"bcc,omitempty"`
+	ReplyTo       *EmailAddress     `json:"reply_to,omitempty"`
+	Subject       string            `json:"subject,omitempty"`
+	Content       string            `json:"content,omitempty"`
+	MailFormat    MailFormat        `json:"mail_format,omitempty"`
+	OrgEmail      bool              `json:"org_email,omitempty"`
+	ConsentEmail  bool              `json:"consent_email,omitempty"`
+	InReplyTo     string            `json:"in_reply_to,omitempty"`
+	ScheduledTime *Time             `json:"scheduled_time,omitempty"`
+	Template      *Lookup           `json:"template,omitempty"`
+	Attachments   []EmailAttachment `json:"attachments,omitempty"`
+}
+
+// EmailAddress is a sender or recipient of an email
+type EmailAddress struct {
+	UserName string `json:"user_name,omitempty"`
+	Email    string `json:"email"`
+}
+
+// EmailAttachment is a file attached to an email, the ID of a file uploaded with UploadFile
+type EmailAttachment struct {
+	ID   string `json:"id"`
+	Name string `json:"name,omitempty"`
+	Size string `json:"size,omitempty"`
+}
+
+// InlineImage returns an img element showing the file uploaded with UploadFile, for use in the html
+// Content of SendMailData
+func (c *API) InlineImage(fileID, alt string) string {
+	return fmt.Sprintf(
+		`<img src="https://crm.zoho.%s/crm/viewInLineImage?fileContent=%s" alt="%s">`,
+		c.ZohoTLD,
+		html.EscapeString(fileID),
+		html.EscapeString(alt),
+	)
+}
+
+// SendMailResponse is the data returned by SendMail
+type SendMailResponse struct {
+	Data []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			MessageID string `json:"message_id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// GetFromAddresses will return the addresses the current user can send email from
+// https://www.zoho.com/crm/developer/docs/api/v2/get-email-addresses.html
+func (c *API) GetFromAddresses() (data FromAddressesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "send_mail",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/emails/actions/from_addresses", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &FromAddressesResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return FromAddressesResponse{}, fmt.Errorf("Failed to retrieve from addresses: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*FromAddressesResponse); ok {
+		return *v, nil
+	}
+
+	return FromAddressesResponse{}, fmt.Errorf("Data returned was not 'FromAddressesResponse'")
+}
+
+// FromAddressesResponse is the data returned by GetFromAddresses
+type FromAddressesResponse struct {
+	FromAddress []struct {
+		Email    string `json:"email,omitempty"`
+		Type     string `json:"type,omitempty"`
+		ID       string `json:"id,omitempty"`
+		Default  bool   `json:"default,omitempty"`
+		UserName string `json:"user_name,omitempty"`
+	} `json:"from_address,omitempty"`
+}
+
+// GetEmails will return the emails sent from or received by the record specified by module and id
+// https://www.zoho.com/crm/developer/docs/api/v2/get-email-rel-list.html
+func (c *API) GetEmails(module Module, id string, params map[string]zoho.Parameter) (data EmailsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "emails",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/%s/%s/Emails", c.ZohoTLD, module, id),
+		Method:       zoho.HTTPGet,
+		ResponseData: &EmailsResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"index": "",
+			"type":  "",
+		},
+	}
+
+	for k, v := range params {
+		endpoint.URLParameters[k] = v
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return EmailsResponse{}, fmt.Errorf("Failed to retrieve emails of %s (%s): %s", module, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*EmailsResponse); ok {
+		return *v, nil
+	}
+
+	return EmailsResponse{}, fmt.Errorf("Data returned was not 'EmailsResponse'")
+}
+
+// EmailsResponse is the data returned by GetEmails
+type EmailsResponse struct {
+	Emails []RecordEmail `json:"email_related_list,omitempty"`
+	Info   struct {
+		Count       int    `json:"count,omitempty"`
+		NextIndex   string `json:"next_index,omitempty"`
+		PrevIndex   string `json:"prev_index,omitempty"`
+		PerPage     int    `json:"per_page,omitempty"`
+		MoreRecords bool   `json:"more_records,omitempty"`
+	} `json:"info,omitempty"`
+}
+
+// GetEmail will return the email specified by messageID, including its content, of the record specified by
+// module and id
+// https://www.zoho.com/crm/developer/docs/api/v2/get-email-rel-list.html
+func (c *API) GetEmail(module Module, id, messageID string) (data EmailResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "emails",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/%s/%s/Emails/%s", c.ZohoTLD, module, id, messageID),
+		Method:       zoho.HTTPGet,
+		ResponseData: &EmailResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return EmailResponse{}, fmt.Errorf("Failed to retrieve email (%s) of %s (%s): %s", messageID, module, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*EmailResponse); ok {
+		return *v, nil
+	}
+
+	return EmailResponse{}, fmt.Errorf("Data returned was not 'EmailResponse'")
+}
+
+// EmailResponse is the data returned by GetEmail
+type EmailResponse struct {
+	Emails []RecordEmail `json:"Emails,omitempty"`
+}
+
+// RecordEmail is an email of a record, Content and Attachments are only returned by GetEmail
+type RecordEmail struct {
+	MessageID   string            `json:"message_id,omitempty"`
+	Subject     string            `json:"subject,omitempty"`
+	Summary     string            `json:"summary,omitempty"`
+	Content     string            `json:"content,omitempty"`
+	From        EmailAddress      `json:"from,omitempty"`
+	To          []EmailAddress    `json:"to,omitempty"`
+	Cc          []EmailAddress    `json:"cc,omitempty"`
+	ReplyTo     *EmailAddress     `json:"reply_to,omitempty"`
+	Owner       *Lookup           `json:"owner,omitempty"`
+	Time        *Time             `json:"time,omitempty"`
+	Sent        bool              `json:"sent,omitempty"`
+	Read        bool              `json:"read,omitempty"`
+	Scheduled   bool              `json:"scheduled,omitempty"`
+	Attachments []EmailAttachment `json:"attachments,omitempty"`
+	Status      []struct {
+		Type          string `json:"type,omitempty"`
+		Count         string `json:"count,omitempty"`
+		FirstOpen     *Time  `json:"first_open,omitempty"`
+		LastOpen      *Time  `json:"last_open,omitempty"`
+		BouncedTime   *Time  `json:"bounced_time,omitempty"`
+		BouncedReason string `json:"bounced_reason,omitempty"`
+	} `json:"status,omitempty"`
+}
+
+// GetEmailTemplates will return the email templates of the module
+// https://www.zoho.com/crm/developer/docs/api/v2/email-template.html
+func (c *API) GetEmailTemplates(module Module) (data EmailTemplatesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "email_templates",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/email_templates", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &EmailTemplatesResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return EmailTemplatesResponse{}, fmt.Errorf("Failed to retrieve email templates of %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*EmailTemplatesResponse); ok {
+		return *v, nil
+	}
+
+	return EmailTemplatesResponse{}, fmt.Errorf("Data returned was not 'EmailTemplatesResponse'")
+}
+
+// GetEmailTemplate will return the email template specified by id, including its content
+// https://www.zoho.com/crm/developer/docs/api/v2/email-template.html
+func (c *API) GetEmailTemplate(id string) (data EmailTemplatesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "email_templates",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/email_templates/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPGet,
+		ResponseData: &EmailTemplatesResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return EmailTemplatesResponse{}, fmt.Errorf("Failed to retrieve email template (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*EmailTemplatesResponse); ok {
+		return *v, nil
+	}
+
+	return EmailTemplatesResponse{}, fmt.Errorf("Data returned was not 'EmailTemplatesResponse'")
+}
+
+// EmailTemplatesResponse is the data returned by GetEmailTemplates and GetEmailTemplate
+type EmailTemplatesResponse struct {
+	EmailTemplates []struct {
+		ID            string  `json:"id,omitempty"`
+		Name          string  `json:"name,omitempty"`
+		Subject       string  `json:"subject,omitempty"`
+		Content       string  `json:"content,omitempty"`
+		Type          string  `json:"type,omitempty"`
+		EditorMode    string  `json:"editor_mode,omitempty"`
+		Favorite      bool    `json:"favorite,omitempty"`
+		Associated    bool    `json:"associated,omitempty"`
+		ConsentLinked bool    `json:"consent_linked,omitempty"`
+		Module        *Lookup `json:"module,omitempty"`
+		Folder        *Lookup `json:"folder,omitempty"`
+		CreatedBy     *Lookup `json:"created_by,omitempty"`
+		ModifiedBy    *Lookup `json:"modified_by,omitempty"`
+		CreatedTime   *Time   `json:"created_time,omitempty"`
+		ModifiedTime  *Time   `json:"modified_time,omitempty"`
+		LastUsageTime *Time   `json:"last_usage_time,omitempty"`
+		Attachments   []struct {
+			Size     string `json:"size,omitempty"`
+			FileName string `json:"file_name,omitempty"`
+			FileID   string `json:"file_id,omitempty"`
+			ID       string `json:"id,omitempty"`
+		} `json:"attachments,omitempty"`
+	} `json:"email_templates,omitempty"`
+	Info PageInfo `json:"info,omitempty"`
+}
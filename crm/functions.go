@@ -0,0 +1,84 @@
+package crm
+
+import (
+	"encoding/json"
+	"fmt"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// ExecuteFunction will execute the CRM function specified by its API name, which must be made available as a REST API
+// with OAuth2 authorization. The arguments are passed to the function by name.
+// https://www.zoho.com/crm/developer/docs/functions/serverless-fn-apis.html
+func (c *API) ExecuteFunction(name string, arguments map[string]interface{}) (data FunctionResponse, err error) {
+	return c.executeFunction(name, arguments, map[string]zoho.Parameter{
+		"auth_type": "oauth",
+	})
+}
+
+// ExecuteFunctionWithKey will execute the CRM function specified by its API name using the API key of the function,
+// no access token is required
+// https://www.zoho.com/crm/developer/docs/functions/serverless-fn-apis.html
+func (c *API) ExecuteFunctionWithKey(name, apiKey string, arguments map[string]interface{}) (data FunctionResponse, err error) {
+	return c.executeFunction(name, arguments, map[string]zoho.Parameter{
+		"auth_type": "apikey",
+		"zapikey":   zoho.Parameter(apiKey),
+	})
+}
+
+func (c *API) executeFunction(
+	name string,
+	arguments map[string]interface{},
+	params map[string]zoho.Parameter,
+) (data FunctionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:          "functions",
+		URL:           fmt.Sprintf("https://www.zohoapis.%s/crm/v2/functions/%s/actions/execute", c.ZohoTLD, name),
+		Method:        zoho.HTTPPost,
+		ResponseData:  &FunctionResponse{},
+		URLParameters: params,
+	}
+
+	if len(arguments) > 0 {
+		args, err := json.Marshal(arguments)
+		if err != nil {
+			return FunctionResponse{}, fmt.Errorf("Failed to marshal arguments of function %s: %s", name, err)
+		}
+		endpoint.URLParameters["arguments"] = zoho.Parameter(args)
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return FunctionResponse{}, fmt.Errorf("Failed to execute function %s: %s", name, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*FunctionResponse); ok {
+		if v.Code != "success" {
+			return *v, fmt.Errorf("Failed to execute function %s: %s: %s", name, v.Code, v.Message)
+		}
+		return *v, nil
+	}
+
+	return FunctionResponse{}, fmt.Errorf("Data returned was not 'FunctionResponse'")
+}
+
+// FunctionResponse is the data returned by ExecuteFunction and ExecuteFunctionWithKey, Output is the value
+// returned by the function as a string
+type FunctionResponse struct {
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	Details struct {
+		Output      string   `json:"output,omitempty"`
+		OutputType  string   `json:"output_type,omitempty"`
+		ID          string   `json:"id,omitempty"`
+		UserMessage []string `json:"userMessage,omitempty"`
+	} `json:"details,omitempty"`
+}
+
+// Decode unmarshals the output of a function returning a map or list into v
+func (f FunctionResponse) Decode(v interface{}) error {
+	if err := json.Unmarshal([]byte(f.Details.Output), v); err != nil {
+		return fmt.Errorf("Failed to unmarshal output of type %s: %s", f.Details.OutputType, err)
+	}
+	return nil
+}
@@ -0,0 +1,299 @@
+package crm
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// MaxMassActionRecords is the number of records that can be provided to ChangeOwner and MassUpdate in one request
+const MaxMassActionRecords = 500
+
+// ChangeOwner will transfer the records specified by IDs in the module to a new owner in a single request,
+// at most MaxMassActionRecords records can be provided
+// https://www.zoho.com/crm/developer/docs/api/v2/change-owner.html
+func (c *API) ChangeOwner(request ChangeOwnerData, module Module) (data ChangeOwnerResponse, err error) {
+	if len(request.IDs) == 0 || len(request.IDs) > MaxMassActionRecords {
+		return ChangeOwnerResponse{}, fmt.Errorf(
+			"Failed to change owner, must provide between 1 and %d IDs",
+			MaxMassActionRecords,
+		)
+	}
+
+	endpoint := zoho.Endpoint{
+		Name:         "change_owner",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/%s/actions/change_owner", c.ZohoTLD, module),
+		Method:       zoho.HTTPPost,
+		ResponseData: &ChangeOwnerResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return ChangeOwnerResponse{}, fmt.Errorf("Failed to change owner of %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*ChangeOwnerResponse); ok {
+		return *v, nil
+	}
+
+	return ChangeOwnerResponse{}, fmt.Errorf("Data returned was not 'ChangeOwnerResponse'")
+}
+
+// ChangeOwnerData is the data provided to ChangeOwner. RelatedModules are the modules whose records
+// related to each record are also transferred, eg. Tasks, Events and Calls.
+type ChangeOwnerData struct {
+	IDs            []string `json:"ids"`
+	Owner          Lookup   `json:"owner"`
+	Notify         bool     `json:"notify"`
+	RelatedModules []struct {
+		APIName Module `json:"api_name"`
+	} `json:"related_modules,omitempty"`
+}
+
+// ChangeOwnerResponse is the data returned by ChangeOwner
+type ChangeOwnerResponse = UpdateRecordsResponse
+
+// ReassignRecords will transfer every record in the module owned by the user specified by from, to the user
+// specified by to. When byTerritory is provided, records assigned to a territory in the map are instead transferred
+// to the user the territory ID maps to. The records are found with COQL, a page at a time ordered by id, and transferred
+// MaxMassActionRecords at a time.
+func (c *API) ReassignRecords(
+	module Module,
+	from string,
+	to string,
+	byTerritory map[string]string,
+	notify bool,
+) (transferred int, err error) {
+	fields := []string{"id"}
+	if len(byTerritory) > 0 {
+		fields = append(fields, "Territories")
+	}
+
+	// collect every ID before transferring, each page starts after the last id of the previous page so no
+	// record is skipped, and the number of records is not limited by the offsets COQL allows
+	owners := map[string][]string{}
+	var last Long
+	for {
+		where := Where("Owner", Equals, from)
+		if last > 0 {
+			where = where.And(Where("id", GreaterThan, last))
+		}
+		statement, err := Select(fields...).From(module).Where(where).OrderBy("id", Asc).Limit(COQLMaxLimit).Build()
+		if err != nil {
+			return 0, err
+		}
+		v, err := c.QueryRecords(&COQLResponse{}, statement)
+		if err != nil {
+			return 0, fmt.Errorf("Failed to find records of %s owned by %s: %s", module, from, err)
+		}
+		page, ok := v.(*COQLResponse)
+		if !ok {
+			return 0, fmt.Errorf("Data returned was not 'COQLResponse'")
+		}
+
+		for _, row := range page.Data {
+			id := fmt.Sprint(row["id"])
+			if last, err = parseLong(id); err != nil {
+				return 0, fmt.Errorf("Failed to find records of %s owned by %s: invalid id '%s'", module, from, id)
+			}
+			owner := to
+			for _, t := range territoryIDs(row["Territories"]) {
+				if u, ok := byTerritory[t]; ok {
+					owner = u
+					break
+				}
+			}
+			owners[owner] = append(owners[owner], id)
+		}
+		if !page.Info.MoreRecords || len(page.Data) == 0 {
+			break
+		}
+	}
+
+	for owner, ids := range owners {
+		for start := 0; start < len(ids); start += MaxMassActionRecords {
+			end := start + MaxMassActionRecords
+			if end > len(ids) {
+				end = len(ids)
+			}
+			_, err := c.ChangeOwner(ChangeOwnerData{
+				IDs:    ids[start:end],
+				Owner:  Lookup{ID: owner},
+				Notify: notify,
+			}, module)
+			if err != nil {
+				return transferred, err
+			}
+			transferred += end - start
+		}
+	}
+	return transferred, nil
+}
+
+// parseLong parses a record ID
+func parseLong(id string) (Long, error) {
+	n, err := strconv.ParseInt(id, 10, 64)
+	return Long(n), err
+}
+
+// territoryIDs returns the IDs of the territories in the value of a Territories field
+func territoryIDs(v interface{}) []string {
+	list, ok := v.([]interface{})
+	if !ok {
+		return nil
+	}
+	ids := []string{}
+	for _, t := range list {
+		switch t := t.(type) {
+		case map[string]interface{}:
+			ids = append(ids, fmt.Sprint(t["id"]))
+		case string:
+			ids = append(ids, t)
+		}
+	}
+	return ids
+}
+
+// MassUpdate will schedule a job setting the fields in Data on the records specified by IDs, or every record
+// in the custom view specified by CVID. The returned job ID can be provided to WaitForMassUpdate.
+// https://www.zoho.com/crm/developer/docs/api/v2/mass-update-records.html
+func (c *API) MassUpdate(request MassUpdateData, module Module) (data MassUpdateResponse, err error) {
+	if len(request.IDs) == 0 && request.CVID == "" {
+		return MassUpdateResponse{}, fmt.Errorf("Failed to mass update, must provide IDs or a custom view ID")
+	}
+	if len(request.IDs) > MaxMassActionRecords {
+		return MassUpdateResponse{}, fmt.Errorf("Failed to mass update, at most %d IDs can be provided", MaxMassActionRecords)
+	}
+
+	endpoint := zoho.Endpoint{
+		Name:         "mass_update",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/%s/actions/mass_update", c.ZohoTLD, module),
+		Method:       zoho.HTTPPost,
+		ResponseData: &MassUpdateResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return MassUpdateResponse{}, fmt.Errorf("Failed to mass update %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*MassUpdateResponse); ok {
+		return *v, nil
+	}
+
+	return MassUpdateResponse{}, fmt.Errorf("Data returned was not 'MassUpdateResponse'")
+}
+
+// MassUpdateData is the data provided to MassUpdate. Data must contain a single element with the fields to set,
+// eg. []map[string]interface{}{{"Lead_Status": "Contacted"}}. Territory limits the records of a custom view.
+type MassUpdateData struct {
+	Data      interface{} `json:"data"`
+	IDs       []string    `json:"ids,omitempty"`
+	CVID      string      `json:"cvid,omitempty"`
+	OverWrite bool        `json:"over_write,omitempty"`
+	Territory *struct {
+		ID           string `json:"id"`
+		IncludeChild bool   `json:"include_child,omitempty"`
+	} `json:"territory,omitempty"`
+}
+
+// MassUpdateResponse is the data returned by MassUpdate
+type MassUpdateResponse struct {
+	Data []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			JobID string `json:"job_id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// JobID returns the ID of the scheduled job
+func (m MassUpdateResponse) JobID() string {
+	if len(m.Data) == 0 {
+		return ""
+	}
+	return m.Data[0].Details.JobID
+}
+
+// GetMassUpdateStatus will return the status of the mass update job specified by jobID
+// https://www.zoho.com/crm/developer/docs/api/v2/mass-update-records.html
+func (c *API) GetMassUpdateStatus(module Module, jobID string) (data MassUpdateStatusResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "mass_update",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/%s/actions/mass_update", c.ZohoTLD, module),
+		Method:       zoho.HTTPGet,
+		ResponseData: &MassUpdateStatusResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"job_id": zoho.Parameter(jobID),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return MassUpdateStatusResponse{}, fmt.Errorf("Failed to retrieve mass update job (%s): %s", jobID, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*MassUpdateStatusResponse); ok {
+		return *v, nil
+	}
+
+	return MassUpdateStatusResponse{}, fmt.Errorf("Data returned was not 'MassUpdateStatusResponse'")
+}
+
+// MassUpdateStatus is the status of a mass update job
+type MassUpdateStatus = string
+
+const (
+	// MassUpdateScheduled - the job is waiting to be processed
+	MassUpdateScheduled MassUpdateStatus = "SCHEDULED"
+	// MassUpdateRunning - the records are being updated
+	MassUpdateRunning MassUpdateStatus = "RUNNING"
+	// MassUpdateCompleted - the job has finished, the counts report the records updated
+	MassUpdateCompleted MassUpdateStatus = "COMPLETED"
+	// MassUpdateFailed - the job could not be completed
+	MassUpdateFailed MassUpdateStatus = "FAILED"
+)
+
+// MassUpdateStatusResponse is the data returned by GetMassUpdateStatus
+type MassUpdateStatusResponse struct {
+	Data []struct {
+		Status          MassUpdateStatus `json:"Status,omitempty"`
+		FailedCount     int              `json:"Failed_Count,omitempty"`
+		UpdatedCount    int              `json:"Updated_Count,omitempty"`
+		NotUpdatedCount int              `json:"Not_Updated_Count,omitempty"`
+		TotalCount      int              `json:"Total_Count,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// WaitForMassUpdate polls the mass update job every interval until it has completed or failed, or the context is done
+func (c *API) WaitForMassUpdate(ctx context.Context, module Module, jobID string, interval time.Duration) (data MassUpdateStatusResponse, err error) {
+	for {
+		data, err = c.GetMassUpdateStatus(module, jobID)
+		if err != nil {
+			return MassUpdateStatusResponse{}, err
+		}
+		if len(data.Data) == 0 {
+			return MassUpdateStatusResponse{}, fmt.Errorf("Mass update job (%s) was not found", jobID)
+		}
+
+		switch data.Data[0].Status {
+		case MassUpdateCompleted:
+			return data, nil
+		case MassUpdateFailed:
+			return data, fmt.Errorf("Mass update job (%s) failed", jobID)
+		}
+
+		select {
+		case <-ctx.Done():
+			return data, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
@@ -0,0 +1,95 @@
+package crm
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/zohotest"
+)
+
+func TestReassignRecords(t *testing.T) {
+	srv := zohotest.NewServer()
+	defer srv.Close()
+	for i := 0; i < 450; i++ {
+		owner := "1"
+		if i%3 == 0 {
+			owner = "2"
+		}
+		srv.Seed("crm/Leads", zohotest.Record{"Last_Name": "Smith", "Owner": map[string]interface{}{"id": owner}})
+	}
+
+	z := srv.Zoho()
+	transferred := map[string]int{}
+	z.Use(func(next zoho.Handler) zoho.Handler {
+		return func(endpoint *zoho.Endpoint, req *http.Request) (*http.Response, error) {
+			if endpoint.Name != "change_owner" {
+				return next(endpoint, req)
+			}
+			var data ChangeOwnerData
+			body, _ := ioutil.ReadAll(req.Body)
+			if err := json.Unmarshal(body, &data); err != nil {
+				t.Error(err)
+			}
+			for _, id := range data.IDs {
+				transferred[id]++
+			}
+			resp := `{"data":[{"code":"SUCCESS","status":"success"}]}`
+			return &http.Response{StatusCode: http.StatusOK, Body: ioutil.NopCloser(strings.NewReader(resp)), Request: req}, nil
+		}
+	})
+	c := New(z)
+
+	n, err := c.ReassignRecords(LeadsModule, "1", "3", nil, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 300 || len(transferred) != 300 {
+		t.Errorf("transferred %d records, %d distinct, want 300", n, len(transferred))
+	}
+	for id, count := range transferred {
+		if count != 1 {
+			t.Errorf("record %s was transferred %d times", id, count)
+		}
+	}
+
+	for _, r := range srv.Requests() {
+		if r.Path == "/crm/v2/coql" && strings.Contains(string(r.Body), "offset") {
+			t.Errorf("query paged by offset: %s", r.Body)
+		}
+	}
+}
+
+func TestWaitForMassUpdate(t *testing.T) {
+	statuses := []MassUpdateStatus{MassUpdateScheduled, MassUpdateRunning, MassUpdateCompleted}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[{"Status":"` + statuses[0] + `","Updated_Count":2}]}`))
+		statuses = statuses[1:]
+	}))
+	defer srv.Close()
+
+	z := zoho.New()
+	z.CustomHTTPClient(srv.Client())
+	if err := z.SetBaseURL(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	z.SetTokenManager(&zohotest.TokenStore{Token: zoho.AccessTokenResponse{
+		AccessToken: "access",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}})
+
+	data, err := New(z).WaitForMassUpdate(context.Background(), LeadsModule, "1", time.Millisecond)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(statuses) != 0 || data.Data[0].Status != MassUpdateCompleted || data.Data[0].UpdatedCount != 2 {
+		t.Errorf("returned %+v with %d statuses remaining", data, len(statuses))
+	}
+}
@@ -0,0 +1,443 @@
+package crm
+
+import (
+	"fmt"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// GetFieldsMetadata returns the metadata of every field in the module, including custom fields
+// https://www.zoho.com/crm/developer/docs/api/v2/field-meta.html
+func (c *API) GetFieldsMetadata(module Module) (data FieldsMetadataResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "fields",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/fields", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &FieldsMetadataResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return FieldsMetadataResponse{}, fmt.Errorf("Failed to retrieve fields of %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*FieldsMetadataResponse); ok {
+		return *v, nil
+	}
+
+	return FieldsMetadataResponse{}, fmt.Errorf("Data retrieved was not 'FieldsMetadataResponse'")
+}
+
+// GetFieldMetadata returns the metadata of the field specified by id in the module
+// https://www.zoho.com/crm/developer/docs/api/v2/field-meta.html
+func (c *API) GetFieldMetadata(module Module, id string) (data FieldsMetadataResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "fields",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/fields/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPGet,
+		ResponseData: &FieldsMetadataResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return FieldsMetadataResponse{}, fmt.Errorf("Failed to retrieve field (%s) of %s: %s", id, module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*FieldsMetadataResponse); ok {
+		return *v, nil
+	}
+
+	return FieldsMetadataResponse{}, fmt.Errorf("Data retrieved was not 'FieldsMetadataResponse'")
+}
+
+// FieldsMetadataResponse is the data returned by GetFieldsMetadata and GetFieldMetadata
+type FieldsMetadataResponse struct {
+	Fields []FieldMetadata `json:"fields,omitempty"`
+}
+
+// Field returns the metadata of the field with the provided API name
+func (f FieldsMetadataResponse) Field(apiName string) (FieldMetadata, bool) {
+	for _, field := range f.Fields {
+		if field.APIName == apiName {
+			return field, true
+		}
+	}
+	return FieldMetadata{}, false
+}
+
+// DataType is the data_type of a field in the field metadata
+type DataType = string
+
+// Data types of CRM fields
+const (
+	TextType              DataType = "text"
+	TextAreaType          DataType = "textarea"
+	EmailType             DataType = "email"
+	PhoneType             DataType = "phone"
+	WebsiteType           DataType = "website"
+	PickListType          DataType = "picklist"
+	MultiSelectType       DataType = "multiselectpicklist"
+	DateType              DataType = "date"
+	DateTimeType          DataType = "datetime"
+	IntegerType           DataType = "integer"
+	BigIntType            DataType = "bigint"
+	DoubleType            DataType = "double"
+	CurrencyType          DataType = "currency"
+	PercentType           DataType = "percent"
+	BooleanType           DataType = "boolean"
+	LookupType            DataType = "lookup"
+	OwnerLookupType       DataType = "ownerlookup"
+	UserLookupType        DataType = "userlookup"
+	MultiSelectLookupType DataType = "multiselectlookup"
+	MultiUserLookupType   DataType = "multiuserlookup"
+	AutoNumberType        DataType = "autonumber"
+	FormulaType           DataType = "formula"
+	FileUploadType        DataType = "fileupload"
+	ImageUploadType       DataType = "imageupload"
+	ProfileImageType      DataType = "profileimage"
+	SubformType           DataType = "subform"
+	RollupSummaryType     DataType = "rollup_summary"
+)
+
+// FieldMetadata is the metadata of a single field of a module
+type FieldMetadata struct {
+	SystemMandatory       bool   `json:"system_mandatory,omitempty"`
+	Webhook               bool   `json:"webhook,omitempty"`
+	JSONType              string `json:"json_type,omitempty"`
+	FieldLabel            string `json:"field_label,omitempty"`
+	CreatedSource         string `json:"created_source,omitempty"`
+	FieldReadOnly         bool   `json:"field_read_only,omitempty"`
+	DisplayLabel          string `json:"display_label,omitempty"`
+	ReadOnly              bool   `json:"read_only,omitempty"`
+	BusinesscardSupported bool   `json:"businesscard_supported,omitempty"`
+	Currency              struct {
+		RoundingOption string `json:"rounding_option,omitempty"`
+		Precision      int    `json:"precision,omitempty"`
+	} `json:"currency,omitempty"`
+	ID          string `json:"id,omitempty"`
+	CustomField bool   `json:"custom_field,omitempty"`
+	Lookup      struct {
+		DisplayLabel string `json:"display_label,omitempty"`
+		APIName      string `json:"api_name,omitempty"`
+		Module       string `json:"module,omitempty"`
+		ID           string `json:"id,omitempty"`
+	} `json:"lookup,omitempty"`
+	MultiSelectLookup struct {
+		DisplayLabel      string `json:"display_label,omitempty"`
+		LinkingModule     string `json:"linking_module,omitempty"`
+		LookupAPIName     string `json:"lookup_apiname,omitempty"`
+		APIName           string `json:"api_name,omitempty"`
+		ConnectedModule   string `json:"connected_module,omitempty"`
+		ConnectedListName string `json:"connectedlookup_apiname,omitempty"`
+		ID                string `json:"id,omitempty"`
+	} `json:"multiselectlookup,omitempty"`
+	ConvertMapping map[string]interface{} `json:"convert_mapping,omitempty"`
+	Visible        bool                   `json:"visible,omitempty"`
+	Length         int                    `json:"length,omitempty"`
+	ViewType       struct {
+		View        bool `json:"view,omitempty"`
+		Edit        bool `json:"edit,omitempty"`
+		QuickCreate bool `json:"quick_create,omitempty"`
+		Create      bool `json:"create,omitempty"`
+	} `json:"view_type,omitempty"`
+	Subform *struct {
+		Module string `json:"module,omitempty"`
+		ID     string `json:"id,omitempty"`
+	} `json:"subform,omitempty"`
+	APIName         string                 `json:"api_name,omitempty"`
+	Unique          map[string]interface{} `json:"unique,omitempty"`
+	HistoryTracking bool                   `json:"history_tracking,omitempty"`
+	DataType        DataType               `json:"data_type,omitempty"`
+	Formula         struct {
+		ReturnType string `json:"return_type,omitempty"`
+		Expression string `json:"expression,omitempty"`
+	} `json:"formula,omitempty"`
+	DecimalPlace       *int             `json:"decimal_place,omitempty"`
+	MassUpdate         bool             `json:"mass_update,omitempty"`
+	BlueprintSupported bool             `json:"blueprint_supported,omitempty"`
+	PickListValues     []PickListValue  `json:"pick_list_values,omitempty"`
+	AutoNumber         AutoNumberFormat `json:"auto_number,omitempty"`
+	DefaultValue       interface{}      `json:"default_value,omitempty"`
+	Mandatory          bool             `json:"mandatory,omitempty"`
+}
+
+// IsUnique reports whether the field does not allow duplicate values
+func (f FieldMetadata) IsUnique() bool {
+	return len(f.Unique) > 0
+}
+
+// PickListValue is an option of a picklist or multiselect picklist field
+type PickListValue struct {
+	DisplayValue   string `json:"display_value,omitempty"`
+	SequenceNumber int    `json:"sequence_number,omitempty"`
+	ActualValue    string `json:"actual_value,omitempty"`
+	Type           string `json:"type,omitempty"`
+	ID             string `json:"id,omitempty"`
+	Maps           []struct {
+		APIName        string `json:"api_name,omitempty"`
+		PickListValues []struct {
+			DisplayValue string `json:"display_value,omitempty"`
+			ActualValue  string `json:"actual_value,omitempty"`
+		} `json:"pick_list_values,omitempty"`
+	} `json:"maps,omitempty"`
+}
+
+// AutoNumberFormat is the format of an autonumber field
+type AutoNumberFormat struct {
+	Prefix      string `json:"prefix,omitempty"`
+	Suffix      string `json:"suffix,omitempty"`
+	StartNumber int    `json:"start_number,omitempty"`
+}
+
+// GetLayoutsMetadata returns the layouts of the module, with the sections and fields of each layout
+// https://www.zoho.com/crm/developer/docs/api/v2/layouts-meta.html
+func (c *API) GetLayoutsMetadata(module Module) (data LayoutsMetadataResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "layouts",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/layouts", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &LayoutsMetadataResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return LayoutsMetadataResponse{}, fmt.Errorf("Failed to retrieve layouts of %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*LayoutsMetadataResponse); ok {
+		return *v, nil
+	}
+
+	return LayoutsMetadataResponse{}, fmt.Errorf("Data retrieved was not 'LayoutsMetadataResponse'")
+}
+
+// GetLayoutMetadata returns the layout specified by id in the module
+// https://www.zoho.com/crm/developer/docs/api/v2/layouts-meta.html
+func (c *API) GetLayoutMetadata(module Module, id string) (data LayoutsMetadataResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "layouts",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/layouts/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPGet,
+		ResponseData: &LayoutsMetadataResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return LayoutsMetadataResponse{}, fmt.Errorf("Failed to retrieve layout (%s) of %s: %s", id, module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*LayoutsMetadataResponse); ok {
+		return *v, nil
+	}
+
+	return LayoutsMetadataResponse{}, fmt.Errorf("Data retrieved was not 'LayoutsMetadataResponse'")
+}
+
+// LayoutsMetadataResponse is the data returned by GetLayoutsMetadata and GetLayoutMetadata
+type LayoutsMetadataResponse struct {
+	Layouts []LayoutMetadata `json:"layouts,omitempty"`
+}
+
+// LayoutMetadata is the metadata of a single layout of a module
+type LayoutMetadata struct {
+	CreatedTime  Time   `json:"created_time,omitempty"`
+	ModifiedTime Time   `json:"modified_time,omitempty"`
+	CreatedBy    Owner  `json:"created_by,omitempty"`
+	ModifiedBy   Owner  `json:"modified_by,omitempty"`
+	Visible      bool   `json:"visible,omitempty"`
+	Name         string `json:"name,omitempty"`
+	ID           string `json:"id,omitempty"`
+	Status       int    `json:"status,omitempty"`
+	Profiles     []struct {
+		Default bool   `json:"default,omitempty"`
+		Name    string `json:"name,omitempty"`
+		ID      string `json:"id,omitempty"`
+	} `json:"profiles,omitempty"`
+	Sections []LayoutSection `json:"sections,omitempty"`
+}
+
+// LayoutSection is a section of a layout, it holds the fields displayed in the section
+type LayoutSection struct {
+	DisplayLabel     string          `json:"display_label,omitempty"`
+	SequenceNumber   int             `json:"sequence_number,omitempty"`
+	IsSubformSection bool            `json:"isSubformSection,omitempty"`
+	TabTraversal     int             `json:"tab_traversal,omitempty"`
+	APIName          string          `json:"api_name,omitempty"`
+	ColumnCount      int             `json:"column_count,omitempty"`
+	Name             string          `json:"name,omitempty"`
+	GeneratedType    string          `json:"generated_type,omitempty"`
+	Fields           []FieldMetadata `json:"fields,omitempty"`
+}
+
+// GetCustomViewsMetadata returns the custom views of the module
+// https://www.zoho.com/crm/developer/docs/api/v2/custom-view-meta.html
+func (c *API) GetCustomViewsMetadata(module Module) (data CustomViewsMetadataResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "custom_views",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/custom_views", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &CustomViewsMetadataResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return CustomViewsMetadataResponse{}, fmt.Errorf("Failed to retrieve custom views of %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*CustomViewsMetadataResponse); ok {
+		return *v, nil
+	}
+
+	return CustomViewsMetadataResponse{}, fmt.Errorf("Data retrieved was not 'CustomViewsMetadataResponse'")
+}
+
+// GetCustomViewMetadata returns the custom view specified by id in the module, including its criteria
+// https://www.zoho.com/crm/developer/docs/api/v2/custom-view-meta.html
+func (c *API) GetCustomViewMetadata(module Module, id string) (data CustomViewsMetadataResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "custom_views",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/custom_views/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPGet,
+		ResponseData: &CustomViewsMetadataResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return CustomViewsMetadataResponse{}, fmt.Errorf("Failed to retrieve custom view (%s) of %s: %s", id, module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*CustomViewsMetadataResponse); ok {
+		return *v, nil
+	}
+
+	return CustomViewsMetadataResponse{}, fmt.Errorf("Data retrieved was not 'CustomViewsMetadataResponse'")
+}
+
+// CustomViewsMetadataResponse is the data returned by GetCustomViewsMetadata and GetCustomViewMetadata
+type CustomViewsMetadataResponse struct {
+	CustomViews []CustomViewMetadata `json:"custom_views,omitempty"`
+	Info        struct {
+		PerPage     int    `json:"per_page,omitempty"`
+		Default     string `json:"default,omitempty"`
+		Count       int    `json:"count,omitempty"`
+		Page        int    `json:"page,omitempty"`
+		MoreRecords bool   `json:"more_records,omitempty"`
+	} `json:"info,omitempty"`
+}
+
+// CustomViewMetadata is the metadata of a single custom view
+type CustomViewMetadata struct {
+	DisplayValue  string                   `json:"display_value,omitempty"`
+	SharedType    string                   `json:"shared_type,omitempty"`
+	Criteria      *CustomViewCriteria      `json:"criteria,omitempty"`
+	SystemName    string                   `json:"system_name,omitempty"`
+	SharedDetails []map[string]interface{} `json:"shared_details,omitempty"`
+	SortBy        string                   `json:"sort_by,omitempty"`
+	Offline       bool                     `json:"offline,omitempty"`
+	Default       bool                     `json:"default,omitempty"`
+	SystemDefined bool                     `json:"system_defined,omitempty"`
+	Name          string                   `json:"name,omitempty"`
+	ID            string                   `json:"id,omitempty"`
+	Category      string                   `json:"category,omitempty"`
+	Fields        []string                 `json:"fields,omitempty"`
+	Favorite      *int                     `json:"favorite,omitempty"`
+	SortOrder     string                   `json:"sort_order,omitempty"`
+}
+
+// CustomViewCriteria is the filter of a custom view, either a comparison or a group of criteria
+type CustomViewCriteria struct {
+	Comparator    string               `json:"comparator,omitempty"`
+	Field         string               `json:"field,omitempty"`
+	Value         interface{}          `json:"value,omitempty"`
+	GroupOperator string               `json:"group_operator,omitempty"`
+	Group         []CustomViewCriteria `json:"group,omitempty"`
+}
+
+// GetRelatedListsMetadata returns the related lists of the module
+// https://www.zoho.com/crm/developer/docs/api/v2/related-list-meta.html
+func (c *API) GetRelatedListsMetadata(module Module) (data RelatedListsMetadataResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "related_lists",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/related_lists", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &RelatedListsMetadataResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return RelatedListsMetadataResponse{}, fmt.Errorf("Failed to retrieve related lists of %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*RelatedListsMetadataResponse); ok {
+		return *v, nil
+	}
+
+	return RelatedListsMetadataResponse{}, fmt.Errorf("Data retrieved was not 'RelatedListsMetadataResponse'")
+}
+
+// GetRelatedListMetadata returns the related list specified by id in the module
+// https://www.zoho.com/crm/developer/docs/api/v2/related-list-meta.html
+func (c *API) GetRelatedListMetadata(module Module, id string) (data RelatedListsMetadataResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "related_lists",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/related_lists/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPGet,
+		ResponseData: &RelatedListsMetadataResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return RelatedListsMetadataResponse{}, fmt.Errorf("Failed to retrieve related list (%s) of %s: %s", id, module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*RelatedListsMetadataResponse); ok {
+		return *v, nil
+	}
+
+	return RelatedListsMetadataResponse{}, fmt.Errorf("Data retrieved was not 'RelatedListsMetadataResponse'")
+}
+
+// RelatedListsMetadataResponse is the data returned by GetRelatedListsMetadata and GetRelatedListMetadata
+type RelatedListsMetadataResponse struct {
+	RelatedLists []RelatedListMetadata `json:"related_lists,omitempty"`
+}
+
+// RelatedListMetadata is the metadata of a single related list. APIName can be provided to the
+// related records methods as a RelatedList.
+type RelatedListMetadata struct {
+	SequenceNumber  string      `json:"sequence_number,omitempty"`
+	DisplayLabel    string      `json:"display_label,omitempty"`
+	APIName         RelatedList `json:"api_name,omitempty"`
+	Module          string      `json:"module,omitempty"`
+	Name            string      `json:"name,omitempty"`
+	Action          string      `json:"action,omitempty"`
+	ID              string      `json:"id,omitempty"`
+	Href            string      `json:"href,omitempty"`
+	Type            string      `json:"type,omitempty"`
+	ConnectedModule string      `json:"connectedmodule,omitempty"`
+	LinkingModule   string      `json:"linkingmodule,omitempty"`
+}
@@ -124,12 +124,15 @@ func (c *API) CreateNotes(request CreateNoteData) (data CreateNoteResponse, err
 
 // CreateNoteData is the data provided to create 1 or more notes
 type CreateNoteData struct {
-	Data []struct {
-		NoteTitle   string `json:"Note_Title,omitempty"`
-		NoteContent string `json:"Note_Content,omitempty"`
-		ParentID    string `json:"Parent_Id,omitempty"`
-		SeModule    string `json:"se_module,omitempty"`
-	} `json:"data,omitempty"`
+	Data []NoteData `json:"data,omitempty"`
+}
+
+// NoteData is a note provided to CreateNotes, attached to the record specified by ParentID in the module SeModule
+type NoteData struct {
+	NoteTitle   string `json:"Note_Title,omitempty"`
+	NoteContent string `json:"Note_Content,omitempty"`
+	ParentID    string `json:"Parent_Id,omitempty"`
+	SeModule    string `json:"se_module,omitempty"`
 }
 
 // CreateNoteResponse is the data returned by CreateNotes
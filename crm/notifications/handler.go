@@ -0,0 +1,103 @@
+package notifications
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/schmorrison/Zoho/crm"
+)
+
+// Notification is the payload CRM posts to a channels notify URL when a watched event occurs
+type Notification struct {
+	ServerTime     int64                 `json:"server_time,omitempty"`
+	QueryParams    map[string]string     `json:"query_params,omitempty"`
+	Module         crm.Module            `json:"module,omitempty"`
+	ResourceURI    string                `json:"resource_uri,omitempty"`
+	IDs            []string              `json:"ids,omitempty"`
+	AffectedFields []map[string][]string `json:"affected_fields,omitempty"`
+	Operation      Operation             `json:"operation,omitempty"`
+	ChannelID      string                `json:"channel_id,omitempty"`
+	Token          string                `json:"token,omitempty"`
+}
+
+// Time returns the server time of the notification
+func (n Notification) Time() time.Time {
+	return time.Unix(0, n.ServerTime*int64(time.Millisecond))
+}
+
+// Operation is the operation reported in a Notification
+type Operation string
+
+const (
+	// InsertOperation is reported when records were created
+	InsertOperation Operation = "insert"
+	// UpdateOperation is reported when records were modified
+	UpdateOperation Operation = "update"
+	// DeleteOperation is reported when records were deleted
+	DeleteOperation Operation = "delete"
+)
+
+// Handler is an http.Handler that receives notifications from CRM. Requests whose token
+// does not match are rejected with 401, the remaining notifications are passed to OnNotification.
+//
+// Token is compared against every notification, when channels use different tokens
+// VerifyToken can be provided instead and is given the channel ID and token of the notification.
+type Handler struct {
+	Token          string
+	VerifyToken    func(channelID, token string) bool
+	OnNotification func(n Notification) error
+}
+
+// ServeHTTP implements http.Handler
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	n, err := DecodeNotification(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if !h.verify(n) {
+		http.Error(w, "invalid notification token", http.StatusUnauthorized)
+		return
+	}
+
+	if h.OnNotification != nil {
+		if err := h.OnNotification(n); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+func (h *Handler) verify(n Notification) bool {
+	if h.VerifyToken != nil {
+		return h.VerifyToken(n.ChannelID, n.Token)
+	}
+	// the token is compared in constant time so its value can not be guessed from the response time
+	return h.Token != "" && subtle.ConstantTimeCompare([]byte(h.Token), []byte(n.Token)) == 1
+}
+
+// DecodeNotification reads the notification payload from the body of the request
+func DecodeNotification(r *http.Request) (Notification, error) {
+	n := Notification{}
+	if r.Body == nil {
+		return n, fmt.Errorf("Notification request has no body")
+	}
+	defer r.Body.Close()
+
+	if err := json.NewDecoder(r.Body).Decode(&n); err != nil {
+		return Notification{}, fmt.Errorf("Failed to decode notification: %s", err)
+	}
+	return n, nil
+}
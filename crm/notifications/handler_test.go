@@ -0,0 +1,52 @@
+package notifications
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestHandler(t *testing.T) {
+	body := `{"server_time":1612345678000,"module":"Leads","ids":["1"],"operation":"insert","channel_id":"100","token":"secret"}`
+
+	tests := []struct {
+		name    string
+		handler Handler
+		method  string
+		body    string
+		status  int
+	}{
+		{"valid token", Handler{Token: "secret"}, http.MethodPost, body, http.StatusOK},
+		{"invalid token", Handler{Token: "other"}, http.MethodPost, body, http.StatusUnauthorized},
+		{"prefix of the token", Handler{Token: "secre"}, http.MethodPost, body, http.StatusUnauthorized},
+		{"no token", Handler{}, http.MethodPost, strings.Replace(body, "secret", "", 1), http.StatusUnauthorized},
+		{"verify channel", Handler{VerifyToken: func(channelID, token string) bool {
+			return channelID == "100" && token == "secret"
+		}}, http.MethodPost, body, http.StatusOK},
+		{"method", Handler{Token: "secret"}, http.MethodGet, "", http.StatusMethodNotAllowed},
+		{"invalid body", Handler{Token: "secret"}, http.MethodPost, "{", http.StatusBadRequest},
+		{"callback error", Handler{Token: "secret", OnNotification: func(n Notification) error {
+			return fmt.Errorf("failed")
+		}}, http.MethodPost, body, http.StatusInternalServerError},
+	}
+
+	for _, tt := range tests {
+		w := httptest.NewRecorder()
+		tt.handler.ServeHTTP(w, httptest.NewRequest(tt.method, "/notify", strings.NewReader(tt.body)))
+		if w.Code != tt.status {
+			t.Errorf("%s returned status %d, want %d", tt.name, w.Code, tt.status)
+		}
+	}
+
+	var received Notification
+	h := Handler{Token: "secret", OnNotification: func(n Notification) error {
+		received = n
+		return nil
+	}}
+	h.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodPost, "/notify", strings.NewReader(body)))
+	if received.Operation != InsertOperation || received.Module != "Leads" || len(received.IDs) != 1 || received.Time().Unix() != 1612345678 {
+		t.Errorf("received %+v", received)
+	}
+}
@@ -0,0 +1,43 @@
+// Package notifications provides access to the Zoho CRM Notification API.
+// Watch channels are enabled, updated and disabled through the API type, and the
+// Handler type can be mounted on an HTTP server to receive the notifications
+// CRM pushes to the channels notify URL.
+package notifications
+
+import (
+	"fmt"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/crm"
+)
+
+// API is used for interacting with the Zoho CRM Notification API
+type API struct {
+	*zoho.Zoho
+}
+
+// New returns a *notifications.API with the provided zoho.Zoho as an embedded field
+func New(z *zoho.Zoho) *API {
+	return &API{
+		Zoho: z,
+	}
+}
+
+// EventOperation is the operation portion of a watch event
+type EventOperation string
+
+const (
+	// WatchAll subscribes to every create, edit and delete in the module
+	WatchAll EventOperation = "all"
+	// WatchCreate subscribes to record creation in the module
+	WatchCreate EventOperation = "create"
+	// WatchEdit subscribes to record updates in the module
+	WatchEdit EventOperation = "edit"
+	// WatchDelete subscribes to record deletion in the module
+	WatchDelete EventOperation = "delete"
+)
+
+// Event builds the event string expected by the watch API, eg. 'Leads.create'
+func Event(module crm.Module, operation EventOperation) string {
+	return fmt.Sprintf("%s.%s", module, operation)
+}
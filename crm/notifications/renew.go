@@ -0,0 +1,109 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// MaxChannelLifetime is the longest expiry CRM accepts for a watch channel
+const MaxChannelLifetime = 24 * time.Hour
+
+// RenewChannels extends the expiry of the channels specified by channelIDs to now + lifetime.
+// A lifetime of 0 or one greater than MaxChannelLifetime will use MaxChannelLifetime.
+func (c *API) RenewChannels(channelIDs []string, lifetime time.Duration) (data WatchResponse, err error) {
+	if len(channelIDs) == 0 {
+		return WatchResponse{}, fmt.Errorf("Failed to renew notifications, must provide at least 1 channel ID")
+	}
+	if lifetime <= 0 || lifetime > MaxChannelLifetime {
+		lifetime = MaxChannelLifetime
+	}
+
+	details, err := c.allWatchDetails()
+	if err != nil {
+		return WatchResponse{}, err
+	}
+
+	expiry := zoho.Time(time.Now().Add(lifetime))
+	request := WatchData{}
+	for _, id := range channelIDs {
+		channel := WatchChannel{ChannelID: id, ChannelExpiry: &expiry}
+		for _, w := range details {
+			if w.ChannelID != id {
+				continue
+			}
+			// the update replaces the channels events, so the existing events must be resent
+			channel.Events = append(channel.Events, w.Events...)
+			channel.NotifyURL = w.NotifyURL
+			channel.Token = w.Token
+			channel.NotifyOnRelatedAction = w.NotifyOnRelatedAction
+		}
+		if len(channel.Events) == 0 {
+			return WatchResponse{}, fmt.Errorf("Failed to renew notifications, channel %s is not enabled", id)
+		}
+		request.Watch = append(request.Watch, channel)
+	}
+
+	return c.UpdateWatch(request)
+}
+
+// ExpiringChannels returns the IDs of enabled channels that expire within the provided duration
+func (c *API) ExpiringChannels(within time.Duration) ([]string, error) {
+	details, err := c.allWatchDetails()
+	if err != nil {
+		return nil, err
+	}
+
+	deadline := time.Now().Add(within)
+	seen := map[string]bool{}
+	ids := []string{}
+	for _, w := range details {
+		if seen[w.ChannelID] || time.Time(w.ChannelExpiry).After(deadline) {
+			continue
+		}
+		seen[w.ChannelID] = true
+		ids = append(ids, w.ChannelID)
+	}
+	return ids, nil
+}
+
+// allWatchDetails returns the events of every channel, retrieving each page of the watch details
+func (c *API) allWatchDetails() ([]WatchDetails, error) {
+	details := []WatchDetails{}
+	for page := 1; ; page++ {
+		resp, err := c.GetWatchDetails(map[string]zoho.Parameter{"page": zoho.Parameter(strconv.Itoa(page))})
+		if err != nil {
+			return nil, err
+		}
+		details = append(details, resp.Watch...)
+		if !resp.Info.MoreRecords || len(resp.Watch) == 0 {
+			return details, nil
+		}
+	}
+}
+
+// KeepAlive renews the channels specified by channelIDs every interval until the context is done,
+// so channels never reach their expiry. The interval should be comfortably shorter than
+// MaxChannelLifetime. Errors from each renewal are passed to onError when it is not nil.
+func (c *API) KeepAlive(ctx context.Context, channelIDs []string, interval time.Duration, onError func(error)) {
+	if interval <= 0 || interval >= MaxChannelLifetime {
+		interval = MaxChannelLifetime / 2
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if _, err := c.RenewChannels(channelIDs, MaxChannelLifetime); err != nil && onError != nil {
+				onError(err)
+			}
+		}
+	}
+}
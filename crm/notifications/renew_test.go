@@ -0,0 +1,106 @@
+package notifications
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/zohotest"
+)
+
+// watchServer serves two pages of watch details, the body of each other request is sent on updates
+func watchServer(t *testing.T) (*API, chan WatchData, func()) {
+	expiry := time.Now().Add(time.Hour).Format("2006-01-02T15:04:05-07:00")
+	pages := map[string]string{
+		"1": `{"watch":[{"channel_id":"1","events":["Leads.create"],"token":"a","notify_url":"https://example.com/1","channel_expiry":"` + expiry + `"}],"info":{"page":1,"more_records":true}}`,
+		"2": `{"watch":[{"channel_id":"2","events":["Deals.edit"],"token":"b","notify_url":"https://example.com/2","channel_expiry":"` + expiry + `"},{"channel_id":"2","events":["Deals.delete"],"token":"b","notify_url":"https://example.com/2","channel_expiry":"` + expiry + `"}],"info":{"page":2,"more_records":false}}`,
+	}
+	updates := make(chan WatchData, 1)
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			w.Write([]byte(pages[r.URL.Query().Get("page")]))
+			return
+		}
+		var data WatchData
+		json.NewDecoder(r.Body).Decode(&data)
+		updates <- data
+		w.Write([]byte(`{"watch":[{"code":"SUCCESS","status":"success"}]}`))
+	}))
+
+	z := zoho.New()
+	z.CustomHTTPClient(srv.Client())
+	if err := z.SetBaseURL(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	z.SetTokenManager(&zohotest.TokenStore{Token: zoho.AccessTokenResponse{
+		AccessToken: "access",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}})
+	return New(z), updates, srv.Close
+}
+
+func TestRenewChannels(t *testing.T) {
+	c, updates, stop := watchServer(t)
+	defer stop()
+
+	if _, err := c.RenewChannels([]string{"2"}, time.Hour); err != nil {
+		t.Fatal(err)
+	}
+	update := <-updates
+	if len(update.Watch) != 1 {
+		t.Fatalf("renewed %+v", update)
+	}
+	channel := update.Watch[0]
+	if channel.ChannelID != "2" || channel.Token != "b" || !reflect.DeepEqual(channel.Events, []string{"Deals.edit", "Deals.delete"}) {
+		t.Errorf("renewed channel %+v, want the events of every page resent", channel)
+	}
+	if channel.ChannelExpiry == nil || time.Time(*channel.ChannelExpiry).Before(time.Now().Add(59*time.Minute)) {
+		t.Errorf("channel expiry %v", channel.ChannelExpiry)
+	}
+
+	if _, err := c.RenewChannels([]string{"3"}, time.Hour); err == nil {
+		t.Error("renewing a channel which is not enabled did not return an error")
+	}
+}
+
+func TestExpiringChannels(t *testing.T) {
+	c, _, stop := watchServer(t)
+	defer stop()
+
+	ids, err := c.ExpiringChannels(2 * time.Hour)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(ids, []string{"1", "2"}) {
+		t.Errorf("expiring channels %v, want [1 2]", ids)
+	}
+
+	ids, err = c.ExpiringChannels(time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(ids) != 0 {
+		t.Errorf("channels expiring within a minute %v", ids)
+	}
+}
+
+func TestDisableWatchEvents(t *testing.T) {
+	c, updates, stop := watchServer(t)
+	defer stop()
+
+	request := WatchData{Watch: []WatchChannel{{ChannelID: "1", Events: []string{"Leads.create"}}}}
+	if _, err := c.DisableWatchEvents(request); err != nil {
+		t.Fatal(err)
+	}
+	if update := <-updates; !update.Watch[0].DeleteEvents {
+		t.Errorf("sent %+v, want _delete_events", update)
+	}
+	if request.Watch[0].DeleteEvents {
+		t.Error("the request of the caller was modified")
+	}
+}
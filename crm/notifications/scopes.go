@@ -0,0 +1,22 @@
+package notifications
+
+import (
+	zoho "github.com/schmorrison/Zoho"
+)
+
+var methodScopes = zoho.MethodScopes{
+	"EnableWatch":        {"ZohoCRM.notifications.CREATE"},
+	"GetWatchDetails":    {"ZohoCRM.notifications.READ"},
+	"UpdateWatch":        {"ZohoCRM.notifications.UPDATE"},
+	"RenewChannels":      {"ZohoCRM.notifications.UPDATE"},
+	"ExpiringChannels":   {"ZohoCRM.notifications.READ"},
+	"KeepAlive":          {"ZohoCRM.notifications.READ", "ZohoCRM.notifications.UPDATE"},
+	"DisableWatchEvents": {"ZohoCRM.notifications.UPDATE"},
+	"DisableWatch":       {"ZohoCRM.notifications.DELETE"},
+}
+
+// RequiredScopes returns the minimal scopes required by the methods of API, named as in the API, eg.
+// RequiredScopes("EnableWatch", "RenewChannels")
+func RequiredScopes(methods ...string) ([]zoho.ScopeString, error) {
+	return methodScopes.Required(methods...)
+}
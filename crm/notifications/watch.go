@@ -0,0 +1,204 @@
+package notifications
+
+import (
+	"fmt"
+	"strings"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/crm"
+)
+
+// EnableWatch subscribes the channels in the request to the events listed for each channel
+// https://www.zoho.com/crm/developer/docs/api/v2/notifications/enable.html
+func (c *API) EnableWatch(request WatchData) (data WatchResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "notifications",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/actions/watch", c.ZohoTLD),
+		Method:       zoho.HTTPPost,
+		ResponseData: &WatchResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return WatchResponse{}, fmt.Errorf("Failed to enable notifications: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*WatchResponse); ok {
+		return *v, nil
+	}
+
+	return WatchResponse{}, fmt.Errorf("Data returned was not 'WatchResponse'")
+}
+
+// GetWatchDetails returns the channels and events currently subscribed to. The results
+// can be filtered with the 'module' and 'channel_id' parameters.
+// https://www.zoho.com/crm/developer/docs/api/v2/notifications/get-details.html
+func (c *API) GetWatchDetails(params map[string]zoho.Parameter) (data WatchDetailsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "notifications",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/actions/watch", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &WatchDetailsResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module":     "",
+			"channel_id": "",
+			"page":       "",
+			"per_page":   "200",
+		},
+	}
+
+	for k, v := range params {
+		endpoint.URLParameters[k] = v
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return WatchDetailsResponse{}, fmt.Errorf("Failed to retrieve notification details: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*WatchDetailsResponse); ok {
+		return *v, nil
+	}
+
+	return WatchDetailsResponse{}, fmt.Errorf("Data returned was not 'WatchDetailsResponse'")
+}
+
+// UpdateWatch replaces the events, notify URL, token or expiry of existing channels
+// https://www.zoho.com/crm/developer/docs/api/v2/notifications/update-info.html
+func (c *API) UpdateWatch(request WatchData) (data WatchResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "notifications",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/actions/watch", c.ZohoTLD),
+		Method:       zoho.HTTPPut,
+		ResponseData: &WatchResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return WatchResponse{}, fmt.Errorf("Failed to update notifications: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*WatchResponse); ok {
+		return *v, nil
+	}
+
+	return WatchResponse{}, fmt.Errorf("Data returned was not 'WatchResponse'")
+}
+
+// DisableWatchEvents unsubscribes the listed events from each channel in the request,
+// the channels remain enabled for any events that are not listed.
+// https://www.zoho.com/crm/developer/docs/api/v2/notifications/disable-specific.html
+func (c *API) DisableWatchEvents(request WatchData) (data WatchResponse, err error) {
+	// the channels are copied so the callers request is not modified
+	channels := make([]WatchChannel, len(request.Watch))
+	for i, w := range request.Watch {
+		w.DeleteEvents = true
+		channels[i] = w
+	}
+	request.Watch = channels
+
+	endpoint := zoho.Endpoint{
+		Name:         "notifications",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/actions/watch", c.ZohoTLD),
+		Method:       zoho.HTTPPatch,
+		ResponseData: &WatchResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return WatchResponse{}, fmt.Errorf("Failed to disable notification events: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*WatchResponse); ok {
+		return *v, nil
+	}
+
+	return WatchResponse{}, fmt.Errorf("Data returned was not 'WatchResponse'")
+}
+
+// DisableWatch disables the channels specified by channelIDs
+// https://www.zoho.com/crm/developer/docs/api/v2/notifications/disable.html
+func (c *API) DisableWatch(channelIDs []string) (data WatchResponse, err error) {
+	if len(channelIDs) == 0 {
+		return WatchResponse{}, fmt.Errorf("Failed to disable notifications, must provide at least 1 channel ID")
+	}
+
+	endpoint := zoho.Endpoint{
+		Name:         "notifications",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/actions/watch", c.ZohoTLD),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &WatchResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"channel_ids": zoho.Parameter(strings.Join(channelIDs, ",")),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return WatchResponse{}, fmt.Errorf("Failed to disable notifications: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*WatchResponse); ok {
+		return *v, nil
+	}
+
+	return WatchResponse{}, fmt.Errorf("Data returned was not 'WatchResponse'")
+}
+
+// WatchData is the data provided to EnableWatch, UpdateWatch and DisableWatchEvents
+type WatchData struct {
+	Watch []WatchChannel `json:"watch,omitempty"`
+}
+
+// WatchChannel describes a single notification channel. ChannelID is chosen by the caller,
+// the Token is echoed back in every notification so the receiver can verify its origin.
+type WatchChannel struct {
+	ChannelID              string     `json:"channel_id,omitempty"`
+	Events                 []string   `json:"events,omitempty"`
+	ChannelExpiry          *zoho.Time `json:"channel_expiry,omitempty"`
+	Token                  string     `json:"token,omitempty"`
+	NotifyURL              string     `json:"notify_url,omitempty"`
+	NotifyOnRelatedAction  bool       `json:"notify_on_related_action,omitempty"`
+	ReturnAffectedFieldVal bool       `json:"return_affected_field_values,omitempty"`
+	DeleteEvents           bool       `json:"_delete_events,omitempty"`
+}
+
+// WatchResponse is the data returned by EnableWatch, UpdateWatch and the disable methods
+type WatchResponse struct {
+	Watch []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			Events []struct {
+				ChannelExpiry zoho.Time `json:"channel_expiry,omitempty"`
+				ResourceURI   string    `json:"resource_uri,omitempty"`
+				ResourceID    string    `json:"resource_id,omitempty"`
+				ResourceName  string    `json:"resource_name,omitempty"`
+				ChannelID     string    `json:"channel_id,omitempty"`
+			} `json:"events,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"watch,omitempty"`
+}
+
+// WatchDetailsResponse is the data returned by GetWatchDetails
+type WatchDetailsResponse struct {
+	Watch []WatchDetails `json:"watch,omitempty"`
+	Info  crm.PageInfo   `json:"info,omitempty"`
+}
+
+// WatchDetails is the events subscribed to by a channel for a module
+type WatchDetails struct {
+	NotifyOnRelatedAction bool      `json:"notify_on_related_action,omitempty"`
+	ChannelExpiry         zoho.Time `json:"channel_expiry,omitempty"`
+	ResourceURI           string    `json:"resource_uri,omitempty"`
+	ResourceID            string    `json:"resource_id,omitempty"`
+	ResourceName          string    `json:"resource_name,omitempty"`
+	ChannelID             string    `json:"channel_id,omitempty"`
+	Events                []string  `json:"events,omitempty"`
+	Token                 string    `json:"token,omitempty"`
+	NotifyURL             string    `json:"notify_url,omitempty"`
+}
@@ -62,5 +62,126 @@ type ProfilesResponse struct {
 		Description string `json:"description,omitempty"`
 		ID          string `json:"id,omitempty"`
 		Category    bool   `json:"category,omitempty"`
+		// PermissionDetails is only returned by GetProfile
+		PermissionDetails []ProfilePermission `json:"permission_details,omitempty"`
+	} `json:"profiles,omitempty"`
+}
+
+// ProfilePermission is a permission granted by a profile, eg. the permission to delete Leads
+type ProfilePermission struct {
+	DisplayLabel string `json:"display_label,omitempty"`
+	Module       string `json:"module,omitempty"`
+	Name         string `json:"name,omitempty"`
+	ID           string `json:"id,omitempty"`
+	Enabled      bool   `json:"enabled"`
+}
+
+// CloneProfile will create a profile named name with the permissions of the profile specified by id
+// https://www.zoho.com/crm/developer/docs/api/v2/clone-profile.html
+func (c *API) CloneProfile(id, name, description string) (data ProfileActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "profiles",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/settings/profiles/%s/actions/clone",
+			c.ZohoTLD,
+			id,
+		),
+		Method:       zoho.HTTPPost,
+		ResponseData: &ProfileActionResponse{},
+		RequestBody: ProfilesData{
+			Profiles: []ProfileData{{Name: name, Description: description}},
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return ProfileActionResponse{}, fmt.Errorf("Failed to clone profile (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*ProfileActionResponse); ok {
+		return *v, nil
+	}
+
+	return ProfileActionResponse{}, fmt.Errorf("Data retrieved was not 'ProfileActionResponse'")
+}
+
+// UpdateProfile will modify the profile specified by id. Permissions are enabled or disabled by providing
+// their ID and Enabled in PermissionDetails, the IDs are returned by GetProfile.
+// https://www.zoho.com/crm/developer/docs/api/v2/update-profile.html
+func (c *API) UpdateProfile(request ProfileData, id string) (data ProfileActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "profiles",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/settings/profiles/%s",
+			c.ZohoTLD,
+			id,
+		),
+		Method:       zoho.HTTPPut,
+		ResponseData: &ProfileActionResponse{},
+		RequestBody:  ProfilesData{Profiles: []ProfileData{request}},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return ProfileActionResponse{}, fmt.Errorf("Failed to update profile (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*ProfileActionResponse); ok {
+		return *v, nil
+	}
+
+	return ProfileActionResponse{}, fmt.Errorf("Data retrieved was not 'ProfileActionResponse'")
+}
+
+// DeleteProfile will delete the profile specified by id, its users are moved to the profile specified by transferTo
+// https://www.zoho.com/crm/developer/docs/api/v2/delete-profile.html
+func (c *API) DeleteProfile(id, transferTo string) (data ProfileActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "profiles",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/settings/profiles/%s",
+			c.ZohoTLD,
+			id,
+		),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &ProfileActionResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"transfer_to": zoho.Parameter(transferTo),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return ProfileActionResponse{}, fmt.Errorf("Failed to delete profile (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*ProfileActionResponse); ok {
+		return *v, nil
+	}
+
+	return ProfileActionResponse{}, fmt.Errorf("Data retrieved was not 'ProfileActionResponse'")
+}
+
+// ProfilesData is the data provided to CloneProfile and UpdateProfile
+type ProfilesData struct {
+	Profiles []ProfileData `json:"profiles"`
+}
+
+// ProfileData is the name, description and permissions of a profile
+type ProfileData struct {
+	Name              string              `json:"name,omitempty"`
+	Description       string              `json:"description,omitempty"`
+	PermissionDetails []ProfilePermission `json:"permission_details,omitempty"`
+}
+
+// ProfileActionResponse is the data returned by CloneProfile, UpdateProfile and DeleteProfile
+type ProfileActionResponse struct {
+	Profiles []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ID string `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
 	} `json:"profiles,omitempty"`
 }
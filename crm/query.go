@@ -0,0 +1,381 @@
+package crm
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// Operator is a comparison used in a Criteria. Each operator has a representation in the
+// search criteria syntax used by SearchRecords and in COQL, some are only available in COQL.
+type Operator string
+
+const (
+	// Equals matches values equal to the provided value
+	Equals Operator = "equals"
+	// NotEqual matches values not equal to the provided value
+	NotEqual Operator = "not_equal"
+	// StartsWith matches string values beginning with the provided value. In COQL it is rendered as a like
+	// pattern, and as COQL has no escape for the % and _ wildcards they match any characters in the value.
+	StartsWith Operator = "starts_with"
+	// GreaterThan matches values greater than the provided value
+	GreaterThan Operator = "greater_than"
+	// GreaterEqual matches values greater than or equal to the provided value
+	GreaterEqual Operator = "greater_equal"
+	// LessThan matches values less than the provided value
+	LessThan Operator = "less_than"
+	// LessEqual matches values less than or equal to the provided value
+	LessEqual Operator = "less_equal"
+	// In matches values equal to any of the provided values
+	In Operator = "in"
+	// Between matches values between the two provided values
+	Between Operator = "between"
+
+	// NotIn matches values not equal to any of the provided values (COQL only)
+	NotIn Operator = "not_in"
+	// Like matches values with a COQL like pattern, eg. '%Zylker%' (COQL only)
+	Like Operator = "like"
+	// NotLike matches values not matching a COQL like pattern (COQL only)
+	NotLike Operator = "not_like"
+	// NotBetween matches values outside of the two provided values (COQL only)
+	NotBetween Operator = "not_between"
+	// IsNull matches empty values, no value is provided (COQL only)
+	IsNull Operator = "is_null"
+	// IsNotNull matches values that are not empty, no value is provided (COQL only)
+	IsNotNull Operator = "is_not_null"
+)
+
+var coqlOperators = map[Operator]string{
+	Equals:       "=",
+	NotEqual:     "!=",
+	StartsWith:   "like",
+	GreaterThan:  ">",
+	GreaterEqual: ">=",
+	LessThan:     "<",
+	LessEqual:    "<=",
+	In:           "in",
+	NotIn:        "not in",
+	Like:         "like",
+	NotLike:      "not like",
+	Between:      "between",
+	NotBetween:   "not between",
+	IsNull:       "is null",
+	IsNotNull:    "is not null",
+}
+
+var searchOperators = map[Operator]bool{
+	Equals:       true,
+	NotEqual:     true,
+	StartsWith:   true,
+	GreaterThan:  true,
+	GreaterEqual: true,
+	LessThan:     true,
+	LessEqual:    true,
+	In:           true,
+	Between:      true,
+}
+
+// Criteria is a condition on the fields of a record. It is either a single comparison created by Where,
+// or a group of criteria joined with And or Or. The same Criteria can be rendered as the 'criteria'
+// parameter of SearchRecords or as the where clause of a COQL query.
+//
+//    crm.Where("Last_Name", crm.Equals, "Smith (Jr)").And(crm.Where("Lead_Source", crm.In, "Web", "Email"))
+type Criteria struct {
+	field    string
+	operator Operator
+	values   []interface{}
+
+	joiner string
+	group  []Criteria
+}
+
+// Where creates a criteria comparing the field specified by its API name with the values using the operator
+func Where(field string, operator Operator, values ...interface{}) Criteria {
+	return Criteria{field: field, operator: operator, values: values}
+}
+
+// And returns a criteria matching when this criteria and all of the others match
+func (c Criteria) And(others ...Criteria) Criteria {
+	return c.join("and", others)
+}
+
+// Or returns a criteria matching when this criteria or any of the others match
+func (c Criteria) Or(others ...Criteria) Criteria {
+	return c.join("or", others)
+}
+
+func (c Criteria) join(joiner string, others []Criteria) Criteria {
+	if c.joiner == joiner {
+		group := append(append([]Criteria{}, c.group...), others...)
+		return Criteria{joiner: joiner, group: group}
+	}
+	return Criteria{joiner: joiner, group: append([]Criteria{c}, others...)}
+}
+
+// Parameter returns the criteria in the search syntax as a zoho.Parameter, for use as the
+// 'criteria' parameter of SearchRecords
+func (c Criteria) Parameter() (zoho.Parameter, error) {
+	s, err := c.Search()
+	return zoho.Parameter(s), err
+}
+
+// Search renders the criteria in the '(Field:operator:value)' syntax expected by SearchRecords.
+// Parentheses, commas and backslashes in values are escaped.
+func (c Criteria) Search() (string, error) {
+	if c.joiner != "" {
+		parts := make([]string, 0, len(c.group))
+		for _, g := range c.group {
+			s, err := g.Search()
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, s)
+		}
+		return "(" + strings.Join(parts, c.joiner) + ")", nil
+	}
+
+	if c.field == "" {
+		return "", fmt.Errorf("criteria has no field")
+	}
+	if !searchOperators[c.operator] {
+		return "", fmt.Errorf("operator '%s' is not supported in search criteria", c.operator)
+	}
+	if err := c.checkValues(); err != nil {
+		return "", err
+	}
+
+	values := make([]string, 0, len(c.values))
+	for _, v := range c.values {
+		values = append(values, escapeSearchValue(formatValue(v)))
+	}
+
+	return fmt.Sprintf("(%s:%s:%s)", c.field, c.operator, strings.Join(values, ",")), nil
+}
+
+// COQL renders the criteria as a COQL condition, suitable for the where clause of a SelectQuery
+func (c Criteria) COQL() (string, error) {
+	if c.joiner != "" {
+		parts := make([]string, 0, len(c.group))
+		for _, g := range c.group {
+			s, err := g.COQL()
+			if err != nil {
+				return "", err
+			}
+			parts = append(parts, s)
+		}
+		return "(" + strings.Join(parts, " "+c.joiner+" ") + ")", nil
+	}
+
+	if c.field == "" {
+		return "", fmt.Errorf("criteria has no field")
+	}
+	op, ok := coqlOperators[c.operator]
+	if !ok {
+		return "", fmt.Errorf("operator '%s' is not supported in COQL", c.operator)
+	}
+	if err := c.checkValues(); err != nil {
+		return "", err
+	}
+
+	switch c.operator {
+	case IsNull, IsNotNull:
+		return fmt.Sprintf("(%s %s)", c.field, op), nil
+	case In, NotIn:
+		values := make([]string, 0, len(c.values))
+		for _, v := range c.values {
+			values = append(values, coqlValue(v))
+		}
+		return fmt.Sprintf("(%s %s (%s))", c.field, op, strings.Join(values, ", ")), nil
+	case Between, NotBetween:
+		return fmt.Sprintf("(%s %s %s and %s)", c.field, op, coqlValue(c.values[0]), coqlValue(c.values[1])), nil
+	case StartsWith:
+		return fmt.Sprintf("(%s %s %s)", c.field, op, quoteCOQL(formatValue(c.values[0])+"%")), nil
+	}
+
+	return fmt.Sprintf("(%s %s %s)", c.field, op, coqlValue(c.values[0])), nil
+}
+
+func (c Criteria) checkValues() error {
+	switch c.operator {
+	case IsNull, IsNotNull:
+		if len(c.values) != 0 {
+			return fmt.Errorf("operator '%s' takes no values", c.operator)
+		}
+	case In, NotIn:
+		if len(c.values) == 0 {
+			return fmt.Errorf("operator '%s' requires at least 1 value", c.operator)
+		}
+	case Between, NotBetween:
+		if len(c.values) != 2 {
+			return fmt.Errorf("operator '%s' requires 2 values", c.operator)
+		}
+	default:
+		if len(c.values) != 1 {
+			return fmt.Errorf("operator '%s' requires 1 value", c.operator)
+		}
+	}
+	return nil
+}
+
+// formatValue converts a criteria value to its unescaped string form
+func formatValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case string:
+		return t
+	case time.Time:
+		return t.Format("2006-01-02T15:04:05-07:00")
+	case Time:
+		return time.Time(t).Format("2006-01-02T15:04:05-07:00")
+	case Date:
+		return time.Time(t).Format("2006-01-02")
+	case float32:
+		return strconv.FormatFloat(float64(t), 'f', -1, 32)
+	case float64:
+		return strconv.FormatFloat(t, 'f', -1, 64)
+	case Currency:
+		return strconv.FormatFloat(float64(t), 'f', -1, 64)
+	case Decimal:
+		return strconv.FormatFloat(float64(t), 'f', -1, 64)
+	case Percent:
+		return strconv.FormatFloat(float64(t), 'f', -1, 64)
+	case fmt.Stringer:
+		return t.String()
+	}
+	return fmt.Sprint(v)
+}
+
+var searchEscaper = strings.NewReplacer(`\`, `\\`, `(`, `\(`, `)`, `\)`, `,`, `\,`)
+
+func escapeSearchValue(s string) string {
+	return searchEscaper.Replace(s)
+}
+
+// coqlValue renders a value as a COQL literal, numbers and booleans are unquoted
+func coqlValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return strconv.FormatBool(t)
+	case Checkbox:
+		return strconv.FormatBool(bool(t))
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, Number, Long:
+		return fmt.Sprint(t)
+	case float32, float64, Currency, Decimal, Percent:
+		return formatValue(t)
+	}
+	return quoteCOQL(formatValue(v))
+}
+
+var coqlEscaper = strings.NewReplacer(`\`, `\\`, `'`, `\'`)
+
+func quoteCOQL(s string) string {
+	return "'" + coqlEscaper.Replace(s) + "'"
+}
+
+// SortOrder is the direction of a COQL order by clause
+type SortOrder string
+
+const (
+	// Asc sorts in ascending order
+	Asc SortOrder = "asc"
+	// Desc sorts in descending order
+	Desc SortOrder = "desc"
+)
+
+// SelectQuery builds a COQL select statement
+//
+//    crm.Select("Last_Name", "Email").From(crm.LeadsModule).Where(criteria).OrderBy("Created_Time", crm.Desc).Limit(200)
+type SelectQuery struct {
+	fields  []string
+	module  Module
+	where   *Criteria
+	orderBy []string
+	limit   int
+	offset  int
+}
+
+// Select starts a COQL query selecting the fields specified by their API names
+func Select(fields ...string) *SelectQuery {
+	return &SelectQuery{fields: fields}
+}
+
+// From sets the module to query
+func (q *SelectQuery) From(module Module) *SelectQuery {
+	q.module = module
+	return q
+}
+
+// Where sets the condition records must match
+func (q *SelectQuery) Where(c Criteria) *SelectQuery {
+	q.where = &c
+	return q
+}
+
+// OrderBy appends a sort on the field
+func (q *SelectQuery) OrderBy(field string, order SortOrder) *SelectQuery {
+	q.orderBy = append(q.orderBy, fmt.Sprintf("%s %s", field, order))
+	return q
+}
+
+// Limit sets the maximum number of rows returned, COQL allows at most 200 rows per query
+func (q *SelectQuery) Limit(limit int) *SelectQuery {
+	q.limit = limit
+	return q
+}
+
+// Offset sets the number of rows to skip, a limit must also be set. COQL returns at most the first
+// COQLMaxRows rows of a query.
+func (q *SelectQuery) Offset(offset int) *SelectQuery {
+	q.offset = offset
+	return q
+}
+
+// Build returns the COQL statement
+func (q *SelectQuery) Build() (string, error) {
+	if len(q.fields) == 0 {
+		return "", fmt.Errorf("COQL query must select at least 1 field")
+	}
+	if q.module == "" {
+		return "", fmt.Errorf("COQL query must specify a module")
+	}
+	if q.where == nil {
+		return "", fmt.Errorf("COQL query must have a where clause")
+	}
+
+	if q.offset > 0 && q.limit <= 0 {
+		return "", fmt.Errorf("COQL query with an offset must set a limit")
+	}
+	if q.offset+q.limit > COQLMaxRows {
+		return "", fmt.Errorf("COQL query can not return rows beyond the first %d, offset %d and limit %d exceed it", COQLMaxRows, q.offset, q.limit)
+	}
+
+	where, err := q.where.COQL()
+	if err != nil {
+		return "", err
+	}
+
+	b := strings.Builder{}
+	fmt.Fprintf(&b, "select %s from %s where %s", strings.Join(q.fields, ", "), q.module, where)
+	if len(q.orderBy) > 0 {
+		fmt.Fprintf(&b, " order by %s", strings.Join(q.orderBy, ", "))
+	}
+	if q.limit > 0 {
+		fmt.Fprintf(&b, " limit %d", q.limit)
+	}
+	if q.offset > 0 {
+		fmt.Fprintf(&b, " offset %d", q.offset)
+	}
+	return b.String(), nil
+}
+
+// String implements fmt.Stringer, an invalid query returns an empty string
+func (q *SelectQuery) String() string {
+	s, _ := q.Build()
+	return s
+}
@@ -0,0 +1,116 @@
+package crm
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCriteriaSearch(t *testing.T) {
+	tests := []struct {
+		criteria Criteria
+		want     string
+	}{
+		{Where("Last_Name", Equals, "Smith"), "(Last_Name:equals:Smith)"},
+		{Where("Last_Name", Equals, `Smith (Jr), \ Sr`), `(Last_Name:equals:Smith \(Jr\)\, \\ Sr)`},
+		{Where("Lead_Source", In, "Web", "Email"), "(Lead_Source:in:Web,Email)"},
+		{Where("Amount", Between, 10, 20.5), "(Amount:between:10,20.5)"},
+		{
+			Where("Last_Name", Equals, "Smith").And(Where("Email", StartsWith, "john"), Where("Phone", NotEqual, "1")),
+			"((Last_Name:equals:Smith)and(Email:starts_with:john)and(Phone:not_equal:1))",
+		},
+		{
+			Where("Last_Name", Equals, "Smith").Or(Where("Last_Name", Equals, "Jones")).And(Where("City", Equals, "Paris")),
+			"(((Last_Name:equals:Smith)or(Last_Name:equals:Jones))and(City:equals:Paris))",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.criteria.Search()
+		if err != nil {
+			t.Errorf("Search() returned error: %s", err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("Search() = %s, want %s", got, tt.want)
+		}
+	}
+
+	for _, c := range []Criteria{
+		Where("Last_Name", Like, "%Smith%"),
+		Where("Last_Name", IsNull),
+		Where("Last_Name", Equals),
+		Where("Amount", Between, 10),
+		Where("", Equals, "x"),
+	} {
+		if s, err := c.Search(); err == nil {
+			t.Errorf("Search() = %s, want an error", s)
+		}
+	}
+}
+
+func TestCriteriaCOQL(t *testing.T) {
+	created := time.Date(2021, 3, 4, 5, 6, 7, 0, time.FixedZone("", -5*3600))
+
+	tests := []struct {
+		criteria Criteria
+		want     string
+	}{
+		{Where("Last_Name", Equals, "O'Brien"), `(Last_Name = 'O\'Brien')`},
+		{Where("Last_Name", Equals, `back\slash`), `(Last_Name = 'back\\slash')`},
+		{Where("Last_Name", StartsWith, "Sm'"), `(Last_Name like 'Sm\'%')`},
+		{Where("Annual_Revenue", GreaterThan, 1000), "(Annual_Revenue > 1000)"},
+		{Where("Amount", LessEqual, Currency(19.99)), "(Amount <= 19.99)"},
+		{Where("Email_Opt_Out", Equals, true), "(Email_Opt_Out = true)"},
+		{Where("Created_Time", GreaterEqual, created), "(Created_Time >= '2021-03-04T05:06:07-05:00')"},
+		{Where("Lead_Source", NotIn, "Web", "Email"), "(Lead_Source not in ('Web', 'Email'))"},
+		{Where("Amount", NotBetween, 1, 2), "(Amount not between 1 and 2)"},
+		{Where("Phone", IsNull), "(Phone is null)"},
+		{
+			Where("Last_Name", Like, "%Smith%").Or(Where("Company", Equals, "Acme").And(Where("City", IsNotNull))),
+			"((Last_Name like '%Smith%') or ((Company = 'Acme') and (City is not null)))",
+		},
+	}
+
+	for _, tt := range tests {
+		got, err := tt.criteria.COQL()
+		if err != nil {
+			t.Errorf("COQL() returned error: %s", err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("COQL() = %s, want %s", got, tt.want)
+		}
+	}
+
+	for _, c := range []Criteria{
+		Where("Phone", IsNull, "x"),
+		Where("Lead_Source", In),
+		Where("Last_Name", Operator("contains"), "x"),
+	} {
+		if s, err := c.COQL(); err == nil {
+			t.Errorf("COQL() = %s, want an error", s)
+		}
+	}
+}
+
+func TestSelectQueryBuild(t *testing.T) {
+	query := Select("Last_Name", "Email").From(LeadsModule).Where(Where("Lead_Source", Equals, "Web")).
+		OrderBy("Created_Time", Desc).Limit(50).Offset(100)
+
+	want := "select Last_Name, Email from Leads where (Lead_Source = 'Web') order by Created_Time desc limit 50 offset 100"
+	if got, err := query.Build(); err != nil || got != want {
+		t.Errorf("Build() = %s, %v, want %s", got, err, want)
+	}
+
+	for _, q := range []*SelectQuery{
+		Select().From(LeadsModule).Where(Where("Last_Name", IsNotNull)),
+		Select("Last_Name").Where(Where("Last_Name", IsNotNull)),
+		Select("Last_Name").From(LeadsModule),
+		Select("Last_Name").From(LeadsModule).Where(Where("Last_Name", IsNotNull)).Offset(100),
+		Select("Last_Name").From(LeadsModule).Where(Where("Last_Name", IsNotNull)).Limit(200).Offset(COQLMaxRows - 100),
+	} {
+		if s, err := q.Build(); err == nil {
+			t.Errorf("Build() = %s, want an error", s)
+		}
+	}
+}
@@ -0,0 +1,467 @@
+package crm
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Record is a CRM record whose fields are not known at compile time. Unlike the record types in
+// records_types.go, a Record keeps every field it was decoded with, including custom fields, in the
+// order they were received. Fields are read and written with the typed accessors, which validate the
+// value against the field metadata when the record has a Schema.
+//
+// Every field that is set is tracked, so Changes can be sent to UpdateRecords to modify only those
+// fields, and SetNull can be used to clear a field.
+//
+//    schema, _ := c.GetSchema(crm.LeadsModule)
+//    rec := schema.NewRecord()
+//    rec.SetString("Last_Name", "Smith")
+//    c.InsertRecords(crm.InsertRecordsData{Data: []interface{}{rec}}, crm.LeadsModule)
+type Record struct {
+	keys    []string
+	values  map[string]json.RawMessage
+	changed map[string]bool
+	schema  *Schema
+}
+
+// NewRecord returns an empty Record. A schema can be provided to validate the fields of the record.
+func NewRecord(schema *Schema) *Record {
+	return &Record{
+		values:  map[string]json.RawMessage{},
+		changed: map[string]bool{},
+		schema:  schema,
+	}
+}
+
+// SetSchema sets the field metadata used to validate the fields of the record
+func (r *Record) SetSchema(schema *Schema) {
+	r.schema = schema
+}
+
+// ID returns the id of the record, or an empty string for a new record
+func (r *Record) ID() string {
+	var id string
+	if raw, ok := r.values["id"]; ok {
+		json.Unmarshal(raw, &id)
+	}
+	return id
+}
+
+// Fields returns the API names of the fields of the record in the order they were received or set
+func (r *Record) Fields() []string {
+	return append([]string{}, r.keys...)
+}
+
+// Has reports whether the record contains the field
+func (r *Record) Has(field string) bool {
+	_, ok := r.values[field]
+	return ok
+}
+
+// IsNull reports whether the field is absent or null
+func (r *Record) IsNull(field string) bool {
+	raw, ok := r.values[field]
+	return !ok || bytes.Equal(raw, []byte("null"))
+}
+
+// Raw returns the JSON value of the field
+func (r *Record) Raw(field string) (json.RawMessage, bool) {
+	raw, ok := r.values[field]
+	return raw, ok
+}
+
+// Get decodes the value of the field into v. A missing or null field leaves v unchanged.
+func (r *Record) Get(field string, v interface{}) error {
+	if _, err := r.schema.field(field); err != nil {
+		return err
+	}
+	if r.IsNull(field) {
+		return nil
+	}
+	if err := json.Unmarshal(r.values[field], v); err != nil {
+		return fmt.Errorf("Failed to decode field %s: %s", field, err)
+	}
+	return nil
+}
+
+// Set encodes v as the value of the field and marks the field as changed. The value is not
+// validated against the field metadata, the typed setters should be preferred.
+func (r *Record) Set(field string, v interface{}) error {
+	raw, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("Failed to encode field %s: %s", field, err)
+	}
+	r.set(field, raw)
+	return nil
+}
+
+// SetNull clears the field, when the record is updated the field will be emptied in CRM
+func (r *Record) SetNull(field string) error {
+	f, err := r.schema.field(field)
+	if err != nil {
+		return err
+	}
+	if f != nil && (f.ReadOnly || f.FieldReadOnly) {
+		return fmt.Errorf("Field %s is read only", field)
+	}
+	r.set(field, json.RawMessage("null"))
+	return nil
+}
+
+func (r *Record) set(field string, raw json.RawMessage) {
+	if r.values == nil {
+		r.values = map[string]json.RawMessage{}
+		r.changed = map[string]bool{}
+	}
+	if _, ok := r.values[field]; !ok {
+		r.keys = append(r.keys, field)
+	}
+	r.values[field] = raw
+	r.changed[field] = true
+}
+
+// String returns the value of a text, textarea, email, phone, website, picklist or autonumber field
+func (r *Record) String(field string) (string, error) {
+	if err := r.schema.expect(field, TextType, TextAreaType, EmailType, PhoneType, WebsiteType, PickListType, AutoNumberType, FormulaType); err != nil {
+		return "", err
+	}
+	var s string
+	err := r.Get(field, &s)
+	return s, err
+}
+
+// SetString sets the value of a text, textarea, email, phone, website or picklist field. The length
+// of the value, and the picklist options, are validated when the record has a schema.
+func (r *Record) SetString(field, value string) error {
+	if err := r.schema.expectWritable(field, TextType, TextAreaType, EmailType, PhoneType, WebsiteType, PickListType); err != nil {
+		return err
+	}
+	if f, _ := r.schema.field(field); f != nil {
+		if f.Length > 0 && len([]rune(value)) > f.Length {
+			return fmt.Errorf("Value of field %s exceeds the maximum length of %d", field, f.Length)
+		}
+		if f.DataType == PickListType && !f.hasPickListValue(value) {
+			return fmt.Errorf("'%s' is not an option of picklist field %s", value, field)
+		}
+	}
+	return r.Set(field, value)
+}
+
+// Lookup returns the value of a lookup, owner lookup or user lookup field
+func (r *Record) Lookup(field string) (Lookup, error) {
+	if err := r.schema.expect(field, LookupType, OwnerLookupType, UserLookupType); err != nil {
+		return Lookup{}, err
+	}
+	l := Lookup{}
+	err := r.Get(field, &l)
+	return l, err
+}
+
+// SetLookup sets the ID of the record referenced by a lookup, owner lookup or user lookup field
+func (r *Record) SetLookup(field, id string) error {
+	if err := r.schema.expectWritable(field, LookupType, OwnerLookupType, UserLookupType); err != nil {
+		return err
+	}
+	return r.Set(field, Lookup{ID: id})
+}
+
+// Date returns the value of a date or datetime field
+func (r *Record) Date(field string) (time.Time, error) {
+	if err := r.schema.expect(field, DateType, DateTimeType); err != nil {
+		return time.Time{}, err
+	}
+	var s string
+	if err := r.Get(field, &s); err != nil || s == "" {
+		return time.Time{}, err
+	}
+	if t, err := time.Parse("2006-01-02T15:04:05-07:00", s); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse("2006-01-02", s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Failed to parse field %s as a date: %s", field, err)
+	}
+	return t, nil
+}
+
+// SetDate sets the value of a date or datetime field. The value is formatted as a datetime only
+// when the schema reports the field is a datetime field.
+func (r *Record) SetDate(field string, value time.Time) error {
+	if err := r.schema.expectWritable(field, DateType, DateTimeType); err != nil {
+		return err
+	}
+	if f, _ := r.schema.field(field); f != nil && f.DataType == DateTimeType {
+		return r.Set(field, value.Format("2006-01-02T15:04:05-07:00"))
+	}
+	return r.Set(field, value.Format("2006-01-02"))
+}
+
+// Currency returns the value of a currency field
+func (r *Record) Currency(field string) (Currency, error) {
+	if err := r.schema.expect(field, CurrencyType); err != nil {
+		return 0, err
+	}
+	var c Currency
+	err := r.Get(field, &c)
+	return c, err
+}
+
+// SetCurrency sets the value of a currency field, the value is validated against the
+// precision of the field when the record has a schema
+func (r *Record) SetCurrency(field string, value Currency) error {
+	if err := r.schema.expectWritable(field, CurrencyType); err != nil {
+		return err
+	}
+	if f, _ := r.schema.field(field); f != nil && f.DecimalPlace != nil {
+		if decimalPlaces(float64(value)) > *f.DecimalPlace {
+			return fmt.Errorf("Value of field %s has more than %d decimal places", field, *f.DecimalPlace)
+		}
+	}
+	return r.Set(field, value)
+}
+
+// decimalPlaces returns the number of decimal places of the shortest representation of the value,
+// eg. 2 for 19.99, which can not be found by scaling the float as 19.99*100 is 1998.9999999999998
+func decimalPlaces(v float64) int {
+	s := strconv.FormatFloat(v, 'f', -1, 64)
+	if i := strings.IndexByte(s, '.'); i != -1 {
+		return len(s) - i - 1
+	}
+	return 0
+}
+
+// MultiSelect returns the values of a multiselect picklist field
+func (r *Record) MultiSelect(field string) (MultiSelect, error) {
+	if err := r.schema.expect(field, MultiSelectType); err != nil {
+		return nil, err
+	}
+	m := MultiSelect{}
+	err := r.Get(field, &m)
+	return m, err
+}
+
+// SetMultiSelect sets the values of a multiselect picklist field, the values are validated
+// against the picklist options when the record has a schema
+func (r *Record) SetMultiSelect(field string, values ...string) error {
+	if err := r.schema.expectWritable(field, MultiSelectType); err != nil {
+		return err
+	}
+	if f, _ := r.schema.field(field); f != nil {
+		for _, v := range values {
+			if !f.hasPickListValue(v) {
+				return fmt.Errorf("'%s' is not an option of multiselect field %s", v, field)
+			}
+		}
+	}
+	return r.Set(field, MultiSelect(values))
+}
+
+// Changed reports whether the field has been set since the record was decoded or created
+func (r *Record) Changed(field string) bool {
+	return r.changed[field]
+}
+
+// ResetChanges marks every field of the record as unchanged
+func (r *Record) ResetChanges() {
+	r.changed = map[string]bool{}
+}
+
+// Changes returns the id of the record with only the fields that have been set or cleared, so
+// an update does not overwrite fields that were not modified. Cleared fields are sent as null.
+func (r *Record) Changes() RecordChanges {
+	return RecordChanges{record: r}
+}
+
+// RecordChanges is the changed fields of a Record, it is marshalled as a JSON object
+type RecordChanges struct {
+	record *Record
+}
+
+// MarshalJSON implements json.Marshaler
+func (c RecordChanges) MarshalJSON() ([]byte, error) {
+	return c.record.marshal(func(field string) bool {
+		return field == "id" || c.record.changed[field]
+	})
+}
+
+// MarshalJSON implements json.Marshaler, every field of the record is encoded in order. It has a value
+// receiver, unlike the other methods, so that a Record held by value (eg. in []interface{}) is also encoded
+// as its fields rather than as an empty object.
+func (r Record) MarshalJSON() ([]byte, error) {
+	return r.marshal(func(string) bool { return true })
+}
+
+func (r *Record) marshal(include func(field string) bool) ([]byte, error) {
+	b := bytes.Buffer{}
+	b.WriteByte('{')
+	first := true
+	for _, k := range r.keys {
+		if !include(k) {
+			continue
+		}
+		if !first {
+			b.WriteByte(',')
+		}
+		first = false
+		key, err := json.Marshal(k)
+		if err != nil {
+			return nil, err
+		}
+		b.Write(key)
+		b.WriteByte(':')
+		b.Write(r.values[k])
+	}
+	b.WriteByte('}')
+	return b.Bytes(), nil
+}
+
+// UnmarshalJSON implements json.Unmarshaler, the fields are kept in the order received and
+// are not marked as changed
+func (r *Record) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if tok == nil {
+		return nil
+	}
+	if d, ok := tok.(json.Delim); !ok || d != '{' {
+		return fmt.Errorf("Failed to decode record, expected a JSON object")
+	}
+
+	r.keys = nil
+	r.values = map[string]json.RawMessage{}
+	r.changed = map[string]bool{}
+
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key, ok := tok.(string)
+		if !ok {
+			return fmt.Errorf("Failed to decode record, expected a field name")
+		}
+		var raw json.RawMessage
+		if err := dec.Decode(&raw); err != nil {
+			return fmt.Errorf("Failed to decode field %s: %s", key, err)
+		}
+		if _, ok := r.values[key]; !ok {
+			r.keys = append(r.keys, key)
+		}
+		r.values[key] = raw
+	}
+
+	_, err = dec.Token()
+	return err
+}
+
+// RecordsResponse can be provided to ListRecords, GetRecord, SearchRecords and the other methods that
+// accept a response, to decode the results as Records
+type RecordsResponse struct {
+	Data []*Record `json:"data,omitempty"`
+	Info PageInfo  `json:"info,omitempty"`
+}
+
+// SetSchema sets the schema of every record in the response
+func (r *RecordsResponse) SetSchema(schema *Schema) {
+	for _, rec := range r.Data {
+		rec.SetSchema(schema)
+	}
+}
+
+// Schema is the field metadata of a module, used to validate the fields of a Record
+type Schema struct {
+	Module Module
+	fields map[string]FieldMetadata
+}
+
+// NewSchema creates a Schema from the field metadata of the module
+func NewSchema(module Module, metadata FieldsMetadataResponse) *Schema {
+	s := &Schema{
+		Module: module,
+		fields: map[string]FieldMetadata{},
+	}
+	for _, f := range metadata.Fields {
+		s.fields[f.APIName] = f
+	}
+	return s
+}
+
+// GetSchema retrieves the field metadata of the module and returns it as a Schema
+func (c *API) GetSchema(module Module) (*Schema, error) {
+	metadata, err := c.GetFieldsMetadata(module)
+	if err != nil {
+		return nil, err
+	}
+	return NewSchema(module, metadata), nil
+}
+
+// NewRecord returns an empty Record validated by the schema
+func (s *Schema) NewRecord() *Record {
+	return NewRecord(s)
+}
+
+// Field returns the metadata of the field
+func (s *Schema) Field(apiName string) (FieldMetadata, bool) {
+	if s == nil {
+		return FieldMetadata{}, false
+	}
+	f, ok := s.fields[apiName]
+	return f, ok
+}
+
+// field returns the metadata of the field, nil when the schema is nil or the field is a system field
+// (eg. 'id' or '$approved'), or an error when the field is not in the schema
+func (s *Schema) field(apiName string) (*FieldMetadata, error) {
+	if s == nil || apiName == "id" || (len(apiName) > 0 && apiName[0] == '$') {
+		return nil, nil
+	}
+	f, ok := s.fields[apiName]
+	if !ok {
+		return nil, fmt.Errorf("Field %s does not exist in %s", apiName, s.Module)
+	}
+	return &f, nil
+}
+
+// expect returns an error when the schema reports the field has none of the provided data types
+func (s *Schema) expect(apiName string, types ...DataType) error {
+	f, err := s.field(apiName)
+	if err != nil || f == nil {
+		return err
+	}
+	for _, t := range types {
+		if f.DataType == t {
+			return nil
+		}
+	}
+	return fmt.Errorf("Field %s is of type %s, not %v", apiName, f.DataType, types)
+}
+
+// expectWritable is expect, but additionally rejects read only fields
+func (s *Schema) expectWritable(apiName string, types ...DataType) error {
+	if err := s.expect(apiName, types...); err != nil {
+		return err
+	}
+	if f, _ := s.field(apiName); f != nil && (f.ReadOnly || f.FieldReadOnly) {
+		return fmt.Errorf("Field %s is read only", apiName)
+	}
+	return nil
+}
+
+func (f FieldMetadata) hasPickListValue(v string) bool {
+	if len(f.PickListValues) == 0 {
+		return true
+	}
+	for _, p := range f.PickListValues {
+		if p.ActualValue == v || p.DisplayValue == v {
+			return true
+		}
+	}
+	return false
+}
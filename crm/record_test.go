@@ -0,0 +1,141 @@
+package crm
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSetCurrencyDecimalPlaces(t *testing.T) {
+	places := 2
+	schema := NewSchema(LeadsModule, FieldsMetadataResponse{Fields: []FieldMetadata{
+		{APIName: "Amount", DataType: CurrencyType, DecimalPlace: &places},
+	}})
+
+	tests := []struct {
+		value Currency
+		valid bool
+	}{
+		{19.99, true},
+		{0.07, true},
+		{100, true},
+		{0.1, true},
+		{1.005, false},
+		{0.001, false},
+	}
+
+	for _, tt := range tests {
+		err := schema.NewRecord().SetCurrency("Amount", tt.value)
+		if tt.valid && err != nil {
+			t.Errorf("SetCurrency(%v) returned error: %s", tt.value, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("SetCurrency(%v) did not return an error", tt.value)
+		}
+	}
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	data := `{"id":"1","Last_Name":"Smith","Custom_Field__c":{"nested":[1,2]},"Email":null,"Amount":10.5}`
+	rec := &Record{}
+	if err := json.Unmarshal([]byte(data), rec); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"id", "Last_Name", "Custom_Field__c", "Email", "Amount"}
+	if !reflect.DeepEqual(rec.Fields(), want) {
+		t.Errorf("fields %v, want %v", rec.Fields(), want)
+	}
+	for _, f := range want {
+		if rec.Changed(f) {
+			t.Errorf("decoded field %s is marked as changed", f)
+		}
+	}
+
+	for _, v := range []interface{}{rec, *rec} {
+		b, err := json.Marshal(v)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(b) != data {
+			t.Errorf("%T encoded as %s, want %s", v, b, data)
+		}
+	}
+}
+
+func TestRecordChanges(t *testing.T) {
+	rec := &Record{}
+	if err := json.Unmarshal([]byte(`{"id":"1","Last_Name":"Smith","Phone":"555","Email":"a@example.com"}`), rec); err != nil {
+		t.Fatal(err)
+	}
+
+	if err := rec.SetString("Phone", "556"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.SetNull("Email"); err != nil {
+		t.Fatal(err)
+	}
+	if err := rec.SetString("Website", "example.com"); err != nil {
+		t.Fatal(err)
+	}
+
+	if !rec.Changed("Phone") || !rec.Changed("Email") || rec.Changed("Last_Name") {
+		t.Errorf("changed Phone %t, Email %t, Last_Name %t", rec.Changed("Phone"), rec.Changed("Email"), rec.Changed("Last_Name"))
+	}
+	if !rec.IsNull("Email") || rec.IsNull("Phone") {
+		t.Errorf("IsNull Email %t, Phone %t", rec.IsNull("Email"), rec.IsNull("Phone"))
+	}
+
+	b, err := json.Marshal(rec.Changes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"id":"1","Phone":"556","Email":null,"Website":"example.com"}`; string(b) != want {
+		t.Errorf("changes encoded as %s, want %s", b, want)
+	}
+	if want := []string{"id", "Last_Name", "Phone", "Email", "Website"}; !reflect.DeepEqual(rec.Fields(), want) {
+		t.Errorf("fields %v, want %v", rec.Fields(), want)
+	}
+
+	rec.ResetChanges()
+	b, err = json.Marshal(rec.Changes())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"id":"1"}` {
+		t.Errorf("changes after ResetChanges encoded as %s", b)
+	}
+}
+
+func TestRecordSchema(t *testing.T) {
+	schema := NewSchema(LeadsModule, FieldsMetadataResponse{Fields: []FieldMetadata{
+		{APIName: "Last_Name", DataType: TextType, Length: 5},
+		{APIName: "Lead_Status", DataType: PickListType, PickListValues: []PickListValue{{ActualValue: "New"}}},
+		{APIName: "Created_Time", DataType: DateTimeType, ReadOnly: true},
+	}})
+
+	tests := []struct {
+		name string
+		set  func(r *Record) error
+		ok   bool
+	}{
+		{"string", func(r *Record) error { return r.SetString("Last_Name", "Smith") }, true},
+		{"too long", func(r *Record) error { return r.SetString("Last_Name", "Smithson") }, false},
+		{"picklist", func(r *Record) error { return r.SetString("Lead_Status", "New") }, true},
+		{"picklist option", func(r *Record) error { return r.SetString("Lead_Status", "Old") }, false},
+		{"wrong type", func(r *Record) error { return r.SetCurrency("Last_Name", 1) }, false},
+		{"unknown field", func(r *Record) error { return r.SetString("Nickname", "x") }, false},
+		{"read only", func(r *Record) error { return r.SetNull("Created_Time") }, false},
+		{"null", func(r *Record) error { return r.SetNull("Last_Name") }, true},
+	}
+
+	for _, tt := range tests {
+		r := schema.NewRecord()
+		if err := tt.set(r); (err == nil) != tt.ok {
+			t.Errorf("%s returned %v, want ok %t", tt.name, err, tt.ok)
+		}
+		if !tt.ok && len(r.Fields()) != 0 {
+			t.Errorf("%s set fields %v after an error", tt.name, r.Fields())
+		}
+	}
+}
@@ -121,13 +121,12 @@ type InsertRecordsResponse struct {
 // https://www.zoho.com/crm/help/api/v2/#ra-update-records
 //
 // When performing an update, because the natural state of the records fields in this package is to 'omitempty',
-// if you want to empty the fields contents you will need to embed the records type in a struct in your own package,
-// and override the field with a field that has a json tag that does not contain 'omitempty'.
+// if you want to empty the fields contents you will need to wrap the record with zoho.WithNulls, providing the
+// API names of the fields to clear. A Record can instead be cleared with SetNull, and sent using Changes.
 // eg.
-//    type struct Account {
-//        crm.Account
-//        CustomField string `json:"Custom_Field"`
-//     }
+//    crm.UpdateRecordsData{
+//        Data: []interface{}{zoho.WithNulls(account, "Phone", "Custom_Field")},
+//    }
 func (c *API) UpdateRecords(
 	request UpdateRecordsData,
 	module Module,
@@ -376,7 +375,8 @@ type ListDeletedRecordsResponse struct {
 }
 
 // SearchRecords is used for searching records in the specified module using the parameters.
-// Parameters are 'criteria', 'email', 'phone', and 'word'
+// Parameters are 'criteria', 'email', 'phone', and 'word'. The 'criteria' parameter can be built with Where,
+// eg. crm.Where("Email", crm.Equals, email).Parameter()
 // https://www.zoho.com/crm/help/api/v2/#ra-search-records
 func (c *API) SearchRecords(
 	response interface{},
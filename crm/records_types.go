@@ -342,6 +342,32 @@ type Deal struct {
 }
 
 type Event struct {
+	Data []EventData `json:"data,omitempty"`
+	Info PageInfo    `json:"info,omitempty"`
+}
+
+// EventData is a record of the Events module, see CreateEvents
+type EventData struct {
+	ID                string             `json:"id,omitempty"`
+	EventTitle        string             `json:"Event_Title,omitempty"`
+	Owner             *Owner             `json:"Owner,omitempty"`
+	StartDateTime     *Time              `json:"Start_DateTime,omitempty"`
+	EndDateTime       *Time              `json:"End_DateTime,omitempty"`
+	AllDay            bool               `json:"All_day,omitempty"`
+	Venue             string             `json:"Venue,omitempty"`
+	Description       string             `json:"Description,omitempty"`
+	WhoID             *Lookup            `json:"Who_Id,omitempty"`
+	WhatID            *Lookup            `json:"What_Id,omitempty"`
+	SeModule          Module             `json:"$se_module,omitempty"`
+	Participants      []EventParticipant `json:"Participants,omitempty"`
+	SendNotification  bool               `json:"$send_notification,omitempty"`
+	RemindAt          *Reminder          `json:"Remind_At,omitempty"`
+	RecurringActivity *Recurrence        `json:"Recurring_Activity,omitempty"`
+	CheckInStatus     string             `json:"Check_In_Status,omitempty"`
+	CreatedBy         *Lookup            `json:"Created_By,omitempty"`
+	ModifiedBy        *Lookup            `json:"Modified_By,omitempty"`
+	CreatedTime       *Time              `json:"Created_Time,omitempty"`
+	ModifiedTime      *Time              `json:"Modified_Time,omitempty"`
 }
 
 type Invoice struct {
@@ -372,6 +398,30 @@ type Solution struct {
 }
 
 type Task struct {
+	Data []TaskData `json:"data,omitempty"`
+	Info PageInfo   `json:"info,omitempty"`
+}
+
+// TaskData is a record of the Tasks module, see CreateTasks
+type TaskData struct {
+	ID                    string      `json:"id,omitempty"`
+	Subject               string      `json:"Subject,omitempty"`
+	Owner                 *Owner      `json:"Owner,omitempty"`
+	DueDate               *Date       `json:"Due_Date,omitempty"`
+	Status                string      `json:"Status,omitempty"`
+	Priority              string      `json:"Priority,omitempty"`
+	Description           string      `json:"Description,omitempty"`
+	WhoID                 *Lookup     `json:"Who_Id,omitempty"`
+	WhatID                *Lookup     `json:"What_Id,omitempty"`
+	SeModule              Module      `json:"$se_module,omitempty"`
+	SendNotificationEmail bool        `json:"Send_Notification_Email,omitempty"`
+	RemindAt              *Reminder   `json:"Remind_At,omitempty"`
+	RecurringActivity     *Recurrence `json:"Recurring_Activity,omitempty"`
+	ClosedTime            *Time       `json:"Closed_Time,omitempty"`
+	CreatedBy             *Lookup     `json:"Created_By,omitempty"`
+	ModifiedBy            *Lookup     `json:"Modified_By,omitempty"`
+	CreatedTime           *Time       `json:"Created_Time,omitempty"`
+	ModifiedTime          *Time       `json:"Modified_Time,omitempty"`
 }
 
 type Vendor struct {
@@ -0,0 +1,278 @@
+package crm
+
+import (
+	"fmt"
+	"strings"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// RelatedList is the API name of a related list of a module, custom related lists use
+// the API name shown in the modules related list metadata
+type RelatedList string
+
+// Common related lists
+const (
+	RelatedAccounts        RelatedList = "Accounts"
+	RelatedActivities      RelatedList = "Activities"
+	RelatedActivityHistory RelatedList = "Activities_History"
+	RelatedAttachments     RelatedList = "Attachments"
+	RelatedCampaigns       RelatedList = "Campaigns"
+	RelatedCases           RelatedList = "Cases"
+	RelatedContactRoles    RelatedList = "Contact_Roles"
+	RelatedContacts        RelatedList = "Contacts"
+	RelatedDeals           RelatedList = "Deals"
+	RelatedEmails          RelatedList = "Emails"
+	RelatedInvoices        RelatedList = "Invoices"
+	RelatedLeads           RelatedList = "Leads"
+	RelatedNotes           RelatedList = "Notes"
+	RelatedPriceBooks      RelatedList = "Price_Books"
+	RelatedProducts        RelatedList = "Products"
+	RelatedPurchaseOrders  RelatedList = "Purchase_Orders"
+	RelatedQuotes          RelatedList = "Quotes"
+	RelatedSalesOrders     RelatedList = "Sales_Orders"
+	RelatedSolutions       RelatedList = "Solutions"
+	RelatedStageHistory    RelatedList = "Stage_History"
+	RelatedVendors         RelatedList = "Vendors"
+)
+
+// GetRelatedRecords will return the records in the related list of the record specified by module and ID.
+// The results are decoded into response, which should be one of the record types in this package for
+// the related module. The results can be paginated with the 'page' and 'per_page' parameters.
+// https://www.zoho.com/crm/developer/docs/api/v2/get-related-records.html
+func (c *API) GetRelatedRecords(
+	response interface{},
+	module Module,
+	recordID string,
+	relatedList RelatedList,
+	params map[string]zoho.Parameter,
+) (data interface{}, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "related_records",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/%s",
+			c.ZohoTLD,
+			module,
+			recordID,
+			relatedList,
+		),
+		Method:       zoho.HTTPGet,
+		ResponseData: response,
+		URLParameters: map[string]zoho.Parameter{
+			"fields":   "",
+			"page":     "",
+			"per_page": "200",
+		},
+	}
+
+	for k, v := range params {
+		endpoint.URLParameters[k] = v
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to retrieve %s related to %s (%s): %s", relatedList, module, recordID, err)
+	}
+
+	if endpoint.ResponseData != nil {
+		return endpoint.ResponseData, nil
+	}
+
+	return nil, fmt.Errorf("Data returned was nil")
+}
+
+// UpdateRelatedRecord will link the related record to the record specified by module and ID, or update the
+// relation details (eg. a Contact_Role, or a List_Price in a price book) if they are already linked
+// https://www.zoho.com/crm/developer/docs/api/v2/update-related-records.html
+func (c *API) UpdateRelatedRecord(
+	request UpdateRelatedRecordData,
+	module Module,
+	recordID string,
+	relatedList RelatedList,
+	relatedRecordID string,
+) (data UpdateRelatedRecordResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "related_records",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/%s/%s",
+			c.ZohoTLD,
+			module,
+			recordID,
+			relatedList,
+			relatedRecordID,
+		),
+		Method:       zoho.HTTPPut,
+		ResponseData: &UpdateRelatedRecordResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UpdateRelatedRecordResponse{}, fmt.Errorf(
+			"Failed to update %s (%s) related to %s (%s): %s",
+			relatedList,
+			relatedRecordID,
+			module,
+			recordID,
+			err,
+		)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UpdateRelatedRecordResponse); ok {
+		return *v, nil
+	}
+
+	return UpdateRelatedRecordResponse{}, fmt.Errorf("Data returned was not 'UpdateRelatedRecordResponse'")
+}
+
+// UpdateRelatedRecords will link or update many related records at once, each element of Data
+// must contain the 'id' of the related record
+// https://www.zoho.com/crm/developer/docs/api/v2/update-related-records.html
+func (c *API) UpdateRelatedRecords(
+	request UpdateRelatedRecordData,
+	module Module,
+	recordID string,
+	relatedList RelatedList,
+) (data UpdateRelatedRecordResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "related_records",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/%s",
+			c.ZohoTLD,
+			module,
+			recordID,
+			relatedList,
+		),
+		Method:       zoho.HTTPPut,
+		ResponseData: &UpdateRelatedRecordResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UpdateRelatedRecordResponse{}, fmt.Errorf(
+			"Failed to update %s related to %s (%s): %s",
+			relatedList,
+			module,
+			recordID,
+			err,
+		)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UpdateRelatedRecordResponse); ok {
+		return *v, nil
+	}
+
+	return UpdateRelatedRecordResponse{}, fmt.Errorf("Data returned was not 'UpdateRelatedRecordResponse'")
+}
+
+// UpdateRelatedRecordData is the data provided to UpdateRelatedRecord and UpdateRelatedRecords. Data holds the
+// relation details, eg. []map[string]interface{}{{"Contact_Role": "12345"}}
+type UpdateRelatedRecordData struct {
+	Data interface{} `json:"data,omitempty"`
+}
+
+// UpdateRelatedRecordResponse is the data returned by UpdateRelatedRecord and UpdateRelatedRecords
+type UpdateRelatedRecordResponse struct {
+	Data []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ID string `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"data,omitempty"`
+}
+
+// DelinkRelatedRecord will remove the relation between the record specified by module and ID and the related record,
+// neither record is deleted
+// https://www.zoho.com/crm/developer/docs/api/v2/delink-related-records.html
+func (c *API) DelinkRelatedRecord(
+	module Module,
+	recordID string,
+	relatedList RelatedList,
+	relatedRecordID string,
+) (data DelinkRelatedRecordResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "related_records",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/%s/%s",
+			c.ZohoTLD,
+			module,
+			recordID,
+			relatedList,
+			relatedRecordID,
+		),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &DelinkRelatedRecordResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return DelinkRelatedRecordResponse{}, fmt.Errorf(
+			"Failed to delink %s (%s) from %s (%s): %s",
+			relatedList,
+			relatedRecordID,
+			module,
+			recordID,
+			err,
+		)
+	}
+
+	if v, ok := endpoint.ResponseData.(*DelinkRelatedRecordResponse); ok {
+		return *v, nil
+	}
+
+	return DelinkRelatedRecordResponse{}, fmt.Errorf("Data returned was not 'DelinkRelatedRecordResponse'")
+}
+
+// DelinkRelatedRecords will remove the relations between the record specified by module and ID and each of the related records
+// https://www.zoho.com/crm/developer/docs/api/v2/delink-related-records.html
+func (c *API) DelinkRelatedRecords(
+	module Module,
+	recordID string,
+	relatedList RelatedList,
+	relatedRecordIDs []string,
+) (data DelinkRelatedRecordResponse, err error) {
+	if len(relatedRecordIDs) == 0 {
+		return DelinkRelatedRecordResponse{}, fmt.Errorf(
+			"Failed to delink related records, must provide at least 1 ID",
+		)
+	}
+
+	endpoint := zoho.Endpoint{
+		Name: "related_records",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/%s",
+			c.ZohoTLD,
+			module,
+			recordID,
+			relatedList,
+		),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &DelinkRelatedRecordResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"ids": zoho.Parameter(strings.Join(relatedRecordIDs, ",")),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return DelinkRelatedRecordResponse{}, fmt.Errorf(
+			"Failed to delink %s from %s (%s): %s",
+			relatedList,
+			module,
+			recordID,
+			err,
+		)
+	}
+
+	if v, ok := endpoint.ResponseData.(*DelinkRelatedRecordResponse); ok {
+		return *v, nil
+	}
+
+	return DelinkRelatedRecordResponse{}, fmt.Errorf("Data returned was not 'DelinkRelatedRecordResponse'")
+}
+
+// DelinkRelatedRecordResponse is the data returned by DelinkRelatedRecord and DelinkRelatedRecords
+type DelinkRelatedRecordResponse = UpdateRelatedRecordResponse
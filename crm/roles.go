@@ -64,3 +64,108 @@ type RolesResponse struct {
 		AdminUser    bool   `json:"admin_user,omitempty"`
 	} `json:"roles,omitempty"`
 }
+
+// CreateRole will add a role to the role hierarchy, ReportingTo must be the ID of the roles superior
+// https://www.zoho.com/crm/developer/docs/api/v2/create-roles.html
+func (c *API) CreateRole(request RoleData) (data RoleActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "roles",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/roles", c.ZohoTLD),
+		Method:       zoho.HTTPPost,
+		ResponseData: &RoleActionResponse{},
+		RequestBody:  RolesData{Roles: []RoleData{request}},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return RoleActionResponse{}, fmt.Errorf("Failed to create role: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*RoleActionResponse); ok {
+		return *v, nil
+	}
+
+	return RoleActionResponse{}, fmt.Errorf("Data retrieved was not 'RoleActionResponse'")
+}
+
+// UpdateRole will modify the role specified by id, changing ReportingTo moves the role within the hierarchy
+// https://www.zoho.com/crm/developer/docs/api/v2/update-roles.html
+func (c *API) UpdateRole(request RoleData, id string) (data RoleActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "roles",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/settings/roles/%s",
+			c.ZohoTLD,
+			id,
+		),
+		Method:       zoho.HTTPPut,
+		ResponseData: &RoleActionResponse{},
+		RequestBody:  RolesData{Roles: []RoleData{request}},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return RoleActionResponse{}, fmt.Errorf("Failed to update role (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*RoleActionResponse); ok {
+		return *v, nil
+	}
+
+	return RoleActionResponse{}, fmt.Errorf("Data retrieved was not 'RoleActionResponse'")
+}
+
+// DeleteRole will delete the role specified by id, the users and subordinate roles are moved to the role specified by transferTo
+// https://www.zoho.com/crm/developer/docs/api/v2/delete-role.html
+func (c *API) DeleteRole(id, transferTo string) (data RoleActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "roles",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/settings/roles/%s",
+			c.ZohoTLD,
+			id,
+		),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &RoleActionResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"transfer_to_id": zoho.Parameter(transferTo),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return RoleActionResponse{}, fmt.Errorf("Failed to delete role (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*RoleActionResponse); ok {
+		return *v, nil
+	}
+
+	return RoleActionResponse{}, fmt.Errorf("Data retrieved was not 'RoleActionResponse'")
+}
+
+// RolesData is the data provided to CreateRole and UpdateRole
+type RolesData struct {
+	Roles []RoleData `json:"roles"`
+}
+
+// RoleData is a role in the role hierarchy, ReportingTo is the ID of the superior role
+type RoleData struct {
+	Name           string `json:"name,omitempty"`
+	DisplayLabel   string `json:"display_label,omitempty"`
+	ReportingTo    string `json:"reporting_to,omitempty"`
+	Description    string `json:"description,omitempty"`
+	ShareWithPeers *bool  `json:"share_with_peers,omitempty"`
+}
+
+// RoleActionResponse is the data returned by CreateRole, UpdateRole and DeleteRole
+type RoleActionResponse struct {
+	Roles []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ID string `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"roles,omitempty"`
+}
@@ -0,0 +1,256 @@
+package crm
+
+import (
+	"strings"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// moduleScope is replaced by the module a method is used with in RequiredModuleScopes
+const moduleScope = "{module}"
+
+const (
+	moduleRead   zoho.ScopeString = "ZohoCRM.modules." + moduleScope + ".READ"
+	moduleCreate zoho.ScopeString = "ZohoCRM.modules." + moduleScope + ".CREATE"
+	moduleUpdate zoho.ScopeString = "ZohoCRM.modules." + moduleScope + ".UPDATE"
+	moduleDelete zoho.ScopeString = "ZohoCRM.modules." + moduleScope + ".DELETE"
+)
+
+var methodScopes = zoho.MethodScopes{
+	// activities.go
+	"CreateEvents": {"ZohoCRM.modules.events.CREATE"},
+	"CreateTasks":  {"ZohoCRM.modules.tasks.CREATE"},
+
+	// approvals.go
+	"GetApprovals":   {"ZohoCRM.modules.approvals.READ"},
+	"ApproveRecord":  {"ZohoCRM.modules.approvals.UPDATE"},
+	"RejectRecord":   {"ZohoCRM.modules.approvals.UPDATE"},
+	"DelegateRecord": {"ZohoCRM.modules.approvals.UPDATE"},
+	"ResubmitRecord": {"ZohoCRM.modules.approvals.UPDATE"},
+
+	// attachments.go
+	"ListAttachments":        {moduleRead},
+	"UploadAttachment":       {moduleCreate},
+	"UploadAttachmentReader": {moduleCreate},
+	"UploadAttachmentURL":    {moduleCreate},
+	"DownloadAttachment":     {moduleRead},
+	"DeleteAttachment":       {moduleDelete},
+	"UploadFile":             {"ZohoCRM.files.CREATE"},
+	"UploadFileReader":       {"ZohoCRM.files.CREATE"},
+	"UploadPhoto":            {moduleUpdate},
+	"UploadPhotoReader":      {moduleUpdate},
+	"DownloadPhoto":          {moduleRead},
+	"DeletePhoto":            {moduleUpdate},
+
+	// blueprints.go and blueprint_transitions.go
+	"GetBlueprintTransitions":    {moduleRead},
+	"ExecuteBlueprintTransition": {moduleUpdate},
+	"GetBlueprint":               {moduleRead},
+	"UpdateBlueprint":            {moduleUpdate},
+
+	// bulk_read.go and bulk_write.go
+	"CreateBulkReadJob":      {"ZohoCRM.bulk.READ", moduleRead},
+	"GetBulkReadJob":         {"ZohoCRM.bulk.READ"},
+	"WaitForBulkReadJob":     {"ZohoCRM.bulk.READ"},
+	"DownloadBulkReadResult": {"ZohoCRM.bulk.READ"},
+	"OpenBulkReadResult":     {"ZohoCRM.bulk.READ"},
+	"ReadBulkReadResult":     {"ZohoCRM.bulk.READ"},
+	"UploadBulkFile":         {"ZohoFiles.files.ALL"},
+	"CreateBulkWriteJob":     {"ZohoCRM.bulk.CREATE", moduleCreate, moduleUpdate},
+	"GetBulkWriteJob":        {"ZohoCRM.bulk.READ"},
+	"WaitForBulkWriteJob":    {"ZohoCRM.bulk.READ"},
+	"GetBulkWriteResult":     {"ZohoCRM.bulk.READ"},
+
+	// convert_lead.go
+	"ConvertLeadWith": {
+		"ZohoCRM.modules.leads.UPDATE",
+		"ZohoCRM.modules.contacts.CREATE",
+		"ZohoCRM.modules.accounts.CREATE",
+		"ZohoCRM.modules.deals.CREATE",
+	},
+
+	// coql.go and query.go
+	"QueryRecords":    {"ZohoCRM.coql.READ", moduleRead},
+	"QueryAllRecords": {"ZohoCRM.coql.READ", moduleRead},
+
+	// currencies.go
+	"GetCurrencies":       {"ZohoCRM.settings.currencies.READ"},
+	"GetCurrency":         {"ZohoCRM.settings.currencies.READ"},
+	"AddCurrencies":       {"ZohoCRM.settings.currencies.CREATE"},
+	"UpdateCurrencies":    {"ZohoCRM.settings.currencies.UPDATE"},
+	"EnableMultiCurrency": {"ZohoCRM.settings.currencies.CREATE"},
+	"UpdateBaseCurrency":  {"ZohoCRM.settings.currencies.UPDATE"},
+
+	// duplicates.go
+	"FindDuplicates":   {"ZohoCRM.settings.fields.READ", "ZohoCRM.coql.READ", moduleRead},
+	"FindDuplicatesBy": {"ZohoCRM.coql.READ", moduleRead},
+
+	// emails.go
+	"SendMail":          {"ZohoCRM.send_mail.all.CREATE"},
+	"InlineImage":       {},
+	"GetFromAddresses":  {"ZohoCRM.settings.emails.READ"},
+	"GetEmails":         {"ZohoCRM.emails.READ"},
+	"GetEmail":          {"ZohoCRM.emails.READ"},
+	"GetEmailTemplates": {"ZohoCRM.templates.email.READ"},
+	"GetEmailTemplate":  {"ZohoCRM.templates.email.READ"},
+
+	// functions.go
+	"ExecuteFunction":        {"ZohoCRM.functions.execute.CREATE"},
+	"ExecuteFunctionWithKey": {},
+
+	// mass_actions.go
+	"ChangeOwner":         {moduleUpdate},
+	"ReassignRecords":     {moduleUpdate},
+	"MassUpdate":          {"ZohoCRM.mass_update." + moduleScope + ".UPDATE"},
+	"GetMassUpdateStatus": {"ZohoCRM.mass_update." + moduleScope + ".READ"},
+	"WaitForMassUpdate":   {"ZohoCRM.mass_update." + moduleScope + ".READ"},
+
+	// metadata.go and modules.go
+	"GetFieldsMetadata":       {"ZohoCRM.settings.fields.READ"},
+	"GetFieldMetadata":        {"ZohoCRM.settings.fields.READ"},
+	"GetLayoutsMetadata":      {"ZohoCRM.settings.layouts.READ"},
+	"GetLayoutMetadata":       {"ZohoCRM.settings.layouts.READ"},
+	"GetCustomViewsMetadata":  {"ZohoCRM.settings.custom_views.READ"},
+	"GetCustomViewMetadata":   {"ZohoCRM.settings.custom_views.READ"},
+	"GetRelatedListsMetadata": {"ZohoCRM.settings.related_lists.READ"},
+	"GetRelatedListMetadata":  {"ZohoCRM.settings.related_lists.READ"},
+	"GetModules":              {"ZohoCRM.settings.modules.READ"},
+	"GetSchema":               {"ZohoCRM.settings.fields.READ"},
+
+	// notes.go
+	"GetNotes":         {"ZohoCRM.modules.notes.READ"},
+	"GetNote":          {"ZohoCRM.modules.notes.READ"},
+	"CreateNotes":      {"ZohoCRM.modules.notes.CREATE"},
+	"CreateRecordNote": {"ZohoCRM.modules.notes.CREATE"},
+	"UpdateNote":       {"ZohoCRM.modules.notes.UPDATE"},
+	"DeleteNote":       {"ZohoCRM.modules.notes.DELETE"},
+	"DeleteNotes":      {"ZohoCRM.modules.notes.DELETE"},
+
+	// organization.go
+	"GetOrganization": {"ZohoCRM.org.READ"},
+
+	// profiles.go
+	"GetProfiles":   {"ZohoCRM.settings.profiles.READ"},
+	"GetProfile":    {"ZohoCRM.settings.profiles.READ"},
+	"CloneProfile":  {"ZohoCRM.settings.profiles.CREATE"},
+	"UpdateProfile": {"ZohoCRM.settings.profiles.UPDATE"},
+	"DeleteProfile": {"ZohoCRM.settings.profiles.DELETE"},
+
+	// records.go
+	"ListRecords":        {moduleRead},
+	"InsertRecords":      {moduleCreate},
+	"UpdateRecords":      {moduleUpdate},
+	"UpsertRecords":      {moduleCreate, moduleUpdate},
+	"DeleteRecords":      {moduleDelete},
+	"ListDeletedRecords": {moduleRead},
+	"SearchRecords":      {moduleRead},
+	"GetRecord":          {moduleRead},
+	"InsertRecord":       {moduleCreate},
+	"UpdateRecord":       {moduleUpdate},
+	"DeleteRecord":       {moduleDelete},
+	"ConvertLead": {
+		"ZohoCRM.modules.leads.UPDATE",
+		"ZohoCRM.modules.contacts.CREATE",
+		"ZohoCRM.modules.accounts.CREATE",
+		"ZohoCRM.modules.deals.CREATE",
+	},
+
+	// related_lists.go
+	"GetRelatedRecords":    {moduleRead},
+	"UpdateRelatedRecord":  {moduleUpdate},
+	"UpdateRelatedRecords": {moduleUpdate},
+	"DelinkRelatedRecord":  {moduleUpdate},
+	"DelinkRelatedRecords": {moduleUpdate},
+
+	// roles.go
+	"GetRoles":   {"ZohoCRM.settings.roles.READ"},
+	"GetRole":    {"ZohoCRM.settings.roles.READ"},
+	"CreateRole": {"ZohoCRM.settings.roles.CREATE"},
+	"UpdateRole": {"ZohoCRM.settings.roles.UPDATE"},
+	"DeleteRole": {"ZohoCRM.settings.roles.DELETE"},
+
+	// sharing.go
+	"GetRecordSharing":    {"ZohoCRM.share." + moduleScope + ".READ"},
+	"ShareRecord":         {"ZohoCRM.share." + moduleScope + ".CREATE"},
+	"UpdateRecordSharing": {"ZohoCRM.share." + moduleScope + ".UPDATE"},
+	"RevokeRecordSharing": {"ZohoCRM.share." + moduleScope + ".DELETE"},
+	"RevokeRecordShare":   {"ZohoCRM.share." + moduleScope + ".DELETE"},
+	"LockRecord":          {"ZohoCRM.locking_information.CREATE"},
+	"GetRecordLocks":      {"ZohoCRM.locking_information.READ"},
+	"UnlockRecord":        {"ZohoCRM.locking_information.DELETE"},
+
+	// tags.go
+	"GetTags":    {"ZohoCRM.settings.tags.READ"},
+	"CreateTags": {"ZohoCRM.settings.tags.CREATE"},
+	"UpdateTags": {"ZohoCRM.settings.tags.UPDATE"},
+	"DeleteTag":  {"ZohoCRM.settings.tags.DELETE"},
+	"MergeTags":  {"ZohoCRM.settings.tags.UPDATE"},
+	"AddTags":    {moduleUpdate},
+	"RemoveTags": {moduleUpdate},
+
+	// territories.go
+	"GetTerritories":       {"ZohoCRM.settings.territories.READ"},
+	"GetTerritory":         {"ZohoCRM.settings.territories.READ"},
+	"CreateTerritory":      {"ZohoCRM.settings.territories.CREATE"},
+	"UpdateTerritory":      {"ZohoCRM.settings.territories.UPDATE"},
+	"DeleteTerritory":      {"ZohoCRM.settings.territories.DELETE"},
+	"AssignTerritoryUsers": {"ZohoCRM.settings.territories.UPDATE"},
+	"RemoveTerritoryUser":  {"ZohoCRM.settings.territories.UPDATE"},
+
+	// users.go
+	"GetUsers":    {"ZohoCRM.users.READ"},
+	"GetUser":     {"ZohoCRM.users.READ"},
+	"AddUser":     {"ZohoCRM.users.CREATE"},
+	"UpdateUser":  {"ZohoCRM.users.UPDATE"},
+	"UpdateUsers": {"ZohoCRM.users.UPDATE"},
+	"DeleteUser":  {"ZohoCRM.users.DELETE"},
+
+	// variables.go
+	"GetVariables":      {"ZohoCRM.settings.variables.READ"},
+	"GetVariable":       {"ZohoCRM.settings.variables.READ"},
+	"CreateVariables":   {"ZohoCRM.settings.variables.CREATE"},
+	"UpdateVariables":   {"ZohoCRM.settings.variables.UPDATE"},
+	"SetVariable":       {"ZohoCRM.settings.variables.UPDATE"},
+	"DeleteVariable":    {"ZohoCRM.settings.variables.DELETE"},
+	"GetVariableGroups": {"ZohoCRM.settings.variable_groups.READ"},
+	"GetVariableGroup":  {"ZohoCRM.settings.variable_groups.READ"},
+
+	// workflows.go
+	"GetWorkflowRules": {"ZohoCRM.settings.workflow_rules.READ"},
+	"GetWorkflowRule":  {"ZohoCRM.settings.workflow_rules.READ"},
+	"TriggerWorkflows": {moduleUpdate},
+}
+
+// RequiredScopes returns the minimal scopes required by the methods of API, named as in the API, eg.
+// RequiredScopes("ListRecords", "GetUsers"). Methods on the records of a module require ZohoCRM.modules.ALL,
+// use RequiredModuleScopes to require only the operation on the modules used.
+func RequiredScopes(methods ...string) ([]zoho.ScopeString, error) {
+	return RequiredModuleScopes(nil, methods...)
+}
+
+// RequiredModuleScopes returns the minimal scopes required by the methods of API when used with the modules, eg.
+// RequiredModuleScopes([]Module{LeadsModule}, "ListRecords") returns ZohoCRM.modules.leads.READ
+func RequiredModuleScopes(modules []Module, methods ...string) ([]zoho.ScopeString, error) {
+	required, err := methodScopes.Required(methods...)
+	if err != nil {
+		return nil, err
+	}
+
+	scopes := []zoho.ScopeString{}
+	for _, s := range required {
+		if !strings.Contains(string(s), moduleScope) {
+			scopes = append(scopes, s)
+			continue
+		}
+
+		if len(modules) == 0 {
+			// eg. ZohoCRM.mass_update.{module}.UPDATE is granted by ZohoCRM.mass_update.ALL
+			scopes = append(scopes, zoho.ScopeString(strings.SplitN(string(s), "."+moduleScope, 2)[0]+".ALL"))
+			continue
+		}
+		for _, m := range modules {
+			scopes = append(scopes, zoho.ScopeString(strings.Replace(string(s), moduleScope, strings.ToLower(string(m)), 1)))
+		}
+	}
+	return zoho.CompactScopes(scopes...), nil
+}
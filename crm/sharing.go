@@ -0,0 +1,367 @@
+package crm
+
+import (
+	"fmt"
+	"strings"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// SharePermission is the access granted to the user or role a record is shared with
+type SharePermission = string
+
+const (
+	// ShareReadOnly allows the record to be viewed
+	ShareReadOnly SharePermission = "read_only"
+	// ShareReadWrite allows the record to be viewed and edited
+	ShareReadWrite SharePermission = "read_write"
+	// ShareFullAccess allows the record to be viewed, edited and deleted
+	ShareFullAccess SharePermission = "full_access"
+)
+
+// GetRecordSharing will return the users and roles the record specified by module and ID is shared with
+// https://www.zoho.com/crm/developer/docs/api/v2/get-shared-record-details.html
+func (c *API) GetRecordSharing(module Module, id string) (data ShareRecordsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "share",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/actions/share",
+			c.ZohoTLD,
+			module,
+			id,
+		),
+		Method:       zoho.HTTPGet,
+		ResponseData: &ShareRecordsResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"view": "summary",
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return ShareRecordsResponse{}, fmt.Errorf("Failed to retrieve sharing of %s (%s): %s", module, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*ShareRecordsResponse); ok {
+		return *v, nil
+	}
+
+	return ShareRecordsResponse{}, fmt.Errorf("Data returned was not 'ShareRecordsResponse'")
+}
+
+// ShareRecordsResponse is the data returned by GetRecordSharing
+type ShareRecordsResponse struct {
+	Share []struct {
+		ShareRelatedRecords bool            `json:"share_related_records,omitempty"`
+		SharedThrough       *ShareTarget    `json:"shared_through,omitempty"`
+		SharedTime          Time            `json:"shared_time,omitempty"`
+		Permission          SharePermission `json:"permission,omitempty"`
+		SharedBy            *Lookup         `json:"shared_by,omitempty"`
+		User                *Lookup         `json:"user,omitempty"`
+		SharedWith          *ShareTarget    `json:"shared_with,omitempty"`
+	} `json:"share,omitempty"`
+	ShareableUser []Lookup `json:"shareable_user,omitempty"`
+}
+
+// ShareTarget is the user or role a record is shared with, Type is 'users' or 'roles'
+type ShareTarget struct {
+	Name string `json:"name,omitempty"`
+	ID   string `json:"id,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// ShareData is a user or role to share a record with. It should be created with ShareWithUser or ShareWithRole.
+type ShareData struct {
+	User                *Lookup         `json:"user,omitempty"`
+	SharedWith          *ShareTarget    `json:"shared_with,omitempty"`
+	Permission          SharePermission `json:"permission,omitempty"`
+	ShareRelatedRecords bool            `json:"share_related_records"`
+}
+
+// ShareWithUser returns the ShareData to share a record with the user specified by userID
+func ShareWithUser(userID string, permission SharePermission, shareRelatedRecords bool) ShareData {
+	return ShareData{
+		User:                &Lookup{ID: userID},
+		Permission:          permission,
+		ShareRelatedRecords: shareRelatedRecords,
+	}
+}
+
+// ShareWithRole returns the ShareData to share a record with every user in the role specified by roleID
+func ShareWithRole(roleID string, permission SharePermission, shareRelatedRecords bool) ShareData {
+	return ShareData{
+		SharedWith:          &ShareTarget{ID: roleID, Type: "roles"},
+		Permission:          permission,
+		ShareRelatedRecords: shareRelatedRecords,
+	}
+}
+
+// ShareRecord will share the record specified by module and ID with the users and roles
+// https://www.zoho.com/crm/developer/docs/api/v2/share-records.html
+func (c *API) ShareRecord(module Module, id string, shares ...ShareData) (data ShareRecordResponse, err error) {
+	return c.shareRecord(zoho.HTTPPost, module, id, shares)
+}
+
+// UpdateRecordSharing will change the permissions of users and roles the record is already shared with
+// https://www.zoho.com/crm/developer/docs/api/v2/update-sharing-permissions.html
+func (c *API) UpdateRecordSharing(module Module, id string, shares ...ShareData) (data ShareRecordResponse, err error) {
+	return c.shareRecord(zoho.HTTPPut, module, id, shares)
+}
+
+func (c *API) shareRecord(method zoho.HTTPMethod, module Module, id string, shares []ShareData) (data ShareRecordResponse, err error) {
+	if len(shares) == 0 {
+		return ShareRecordResponse{}, fmt.Errorf("Failed to share record, must provide at least 1 user or role")
+	}
+
+	endpoint := zoho.Endpoint{
+		Name: "share",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/actions/share",
+			c.ZohoTLD,
+			module,
+			id,
+		),
+		Method:       method,
+		ResponseData: &ShareRecordResponse{},
+		RequestBody: struct {
+			Share []ShareData `json:"share"`
+		}{shares},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return ShareRecordResponse{}, fmt.Errorf("Failed to share %s (%s): %s", module, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*ShareRecordResponse); ok {
+		return *v, nil
+	}
+
+	return ShareRecordResponse{}, fmt.Errorf("Data returned was not 'ShareRecordResponse'")
+}
+
+// RevokeRecordSharing will stop sharing the record specified by module and ID with every user and role
+// https://www.zoho.com/crm/developer/docs/api/v2/revoke-shared-record.html
+func (c *API) RevokeRecordSharing(module Module, id string) (data ShareRecordResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "share",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/%s/%s/actions/share",
+			c.ZohoTLD,
+			module,
+			id,
+		),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &ShareRecordResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return ShareRecordResponse{}, fmt.Errorf("Failed to revoke sharing of %s (%s): %s", module, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*ShareRecordResponse); ok {
+		return *v, nil
+	}
+
+	return ShareRecordResponse{}, fmt.Errorf("Data returned was not 'ShareRecordResponse'")
+}
+
+// RevokeRecordShare will stop sharing the record with the users and roles specified by ID. CRM only supports
+// revoking every share of a record, so the sharing is revoked and then restored for the remaining users and roles.
+// Shares made through a group or a sharing rule can not be restored by the API, they are returned in NotRestored.
+// When restoring fails, the *RevokeShareError returned holds the shares which were lost.
+func (c *API) RevokeRecordShare(module Module, id string, ids ...string) (data RevokeRecordShareResponse, err error) {
+	current, err := c.GetRecordSharing(module, id)
+	if err != nil {
+		return RevokeRecordShareResponse{}, err
+	}
+
+	revoke := map[string]bool{}
+	for _, i := range ids {
+		revoke[i] = true
+	}
+
+	for _, s := range current.Share {
+		target := s.SharedWith
+		if s.User != nil {
+			target = &ShareTarget{Name: s.User.Name, ID: s.User.ID, Type: "users"}
+		}
+		if target == nil || revoke[target.ID] {
+			continue
+		}
+
+		if s.SharedThrough != nil && s.SharedThrough.Type != "" && s.SharedThrough.Type != "users" && s.SharedThrough.Type != "roles" {
+			data.NotRestored = append(data.NotRestored, *target)
+			continue
+		}
+		if s.User != nil {
+			data.Restored = append(data.Restored, ShareWithUser(s.User.ID, s.Permission, s.ShareRelatedRecords))
+		} else {
+			data.Restored = append(data.Restored, ShareData{
+				SharedWith:          &ShareTarget{ID: target.ID, Type: target.Type},
+				Permission:          s.Permission,
+				ShareRelatedRecords: s.ShareRelatedRecords,
+			})
+		}
+	}
+
+	data.ShareRecordResponse, err = c.RevokeRecordSharing(module, id)
+	if err != nil || len(data.Restored) == 0 {
+		return data, err
+	}
+
+	data.ShareRecordResponse, err = c.ShareRecord(module, id, data.Restored...)
+	if err != nil {
+		return data, &RevokeShareError{Module: module, ID: id, Lost: data.Restored, Err: err}
+	}
+	return data, nil
+}
+
+// RevokeRecordShareResponse is the data returned by RevokeRecordShare
+type RevokeRecordShareResponse struct {
+	ShareRecordResponse
+	// Restored are the shares of the other users and roles, which were shared again after revoking every share
+	Restored []ShareData
+	// NotRestored are the other users and roles the record was shared with through a group or a sharing rule
+	NotRestored []ShareTarget
+}
+
+// RevokeShareError is returned by RevokeRecordShare when every share of the record was revoked, but the shares
+// of the other users and roles could not be restored. Lost can be passed to ShareRecord to restore them.
+type RevokeShareError struct {
+	Module Module
+	ID     string
+	Lost   []ShareData
+	Err    error
+}
+
+func (e *RevokeShareError) Error() string {
+	ids := make([]string, 0, len(e.Lost))
+	for _, s := range e.Lost {
+		if s.User != nil {
+			ids = append(ids, "user "+s.User.ID)
+		} else if s.SharedWith != nil {
+			ids = append(ids, "role "+s.SharedWith.ID)
+		}
+	}
+	return fmt.Sprintf(
+		"Failed to restore sharing of %s (%s), access was revoked from %s: %s",
+		e.Module, e.ID, strings.Join(ids, ", "), e.Err,
+	)
+}
+
+// ShareRecordResponse is the data returned by ShareRecord, UpdateRecordSharing and RevokeRecordSharing
+type ShareRecordResponse struct {
+	Share []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ID string `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"share,omitempty"`
+	Code    string `json:"code,omitempty"`
+	Message string `json:"message,omitempty"`
+	Status  string `json:"status,omitempty"`
+}
+
+// LockRecord will lock the record specified by module and ID, preventing other users from editing it
+// https://www.zoho.com/crm/developer/docs/api/v2.1/lock-records.html
+func (c *API) LockRecord(module Module, id, reason string) (data RecordLockResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "locking",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2.1/%s/%s/Locking_Information__s",
+			c.ZohoTLD,
+			module,
+			id,
+		),
+		Method:       zoho.HTTPPost,
+		ResponseData: &RecordLockResponse{},
+		RequestBody: InsertRecordsData{
+			Data: []map[string]interface{}{{"Locked_Reason__s": reason}},
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return RecordLockResponse{}, fmt.Errorf("Failed to lock %s (%s): %s", module, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*RecordLockResponse); ok {
+		return *v, nil
+	}
+
+	return RecordLockResponse{}, fmt.Errorf("Data returned was not 'RecordLockResponse'")
+}
+
+// GetRecordLocks will return the locks of the record specified by module and ID
+// https://www.zoho.com/crm/developer/docs/api/v2.1/lock-records.html
+func (c *API) GetRecordLocks(module Module, id string) (data RecordLocksResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "locking",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2.1/%s/%s/Locking_Information__s",
+			c.ZohoTLD,
+			module,
+			id,
+		),
+		Method:       zoho.HTTPGet,
+		ResponseData: &RecordLocksResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return RecordLocksResponse{}, fmt.Errorf("Failed to retrieve locks of %s (%s): %s", module, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*RecordLocksResponse); ok {
+		return *v, nil
+	}
+
+	return RecordLocksResponse{}, fmt.Errorf("Data returned was not 'RecordLocksResponse'")
+}
+
+// UnlockRecord will remove the lock specified by lockID from the record specified by module and ID
+// https://www.zoho.com/crm/developer/docs/api/v2.1/lock-records.html
+func (c *API) UnlockRecord(module Module, id, lockID string) (data RecordLockResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "locking",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2.1/%s/%s/Locking_Information__s/%s",
+			c.ZohoTLD,
+			module,
+			id,
+			lockID,
+		),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &RecordLockResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return RecordLockResponse{}, fmt.Errorf("Failed to unlock %s (%s): %s", module, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*RecordLockResponse); ok {
+		return *v, nil
+	}
+
+	return RecordLockResponse{}, fmt.Errorf("Data returned was not 'RecordLockResponse'")
+}
+
+// RecordLocksResponse is the data returned by GetRecordLocks
+type RecordLocksResponse struct {
+	Data []struct {
+		ID           string `json:"id,omitempty"`
+		LockedBy     *Owner `json:"Locked_By__s,omitempty"`
+		LockedTime   Time   `json:"Locked_Time__s,omitempty"`
+		LockedReason string `json:"Locked_Reason__s,omitempty"`
+		LockSource   string `json:"Lock_Source__s,omitempty"`
+	} `json:"data,omitempty"`
+	Info PageInfo `json:"info,omitempty"`
+}
+
+// RecordLockResponse is the data returned by LockRecord and UnlockRecord, the ID of the lock is in the details
+type RecordLockResponse = UpdateRelatedRecordResponse
@@ -0,0 +1,98 @@
+package crm
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/zohotest"
+)
+
+// sharingServer serves the share endpoint of a Leads record, failing the restore when failShare is set
+func sharingServer(t *testing.T, failShare bool) (*API, *[]string, func()) {
+	var requests []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := ioutil.ReadAll(r.Body)
+		requests = append(requests, r.Method+" "+string(body))
+		w.Header().Set("Content-Type", "application/json")
+		switch r.Method {
+		case http.MethodGet:
+			w.Write([]byte(`{"share":[
+				{"user":{"id":"1","name":"Keep"},"permission":"read_write","share_related_records":true,"shared_through":{"type":"users","id":"1"}},
+				{"user":{"id":"2","name":"Revoke"},"permission":"read_only"},
+				{"shared_with":{"id":"3","name":"Sales","type":"roles"},"permission":"read_only"},
+				{"user":{"id":"4","name":"Rule"},"permission":"read_only","shared_through":{"type":"rules","id":"9"}}
+			]}`))
+		case http.MethodDelete:
+			w.Write([]byte(`{"share":[{"code":"SUCCESS","status":"success","message":"sharing revoked"}]}`))
+		case http.MethodPost:
+			if failShare {
+				w.WriteHeader(http.StatusBadRequest)
+				w.Write([]byte(`{"share":[{"code":"INVALID_DATA","status":"error","message":"invalid data"}]}`))
+				return
+			}
+			w.Write([]byte(`{"share":[{"code":"SUCCESS","status":"success","message":"record shared"}]}`))
+		}
+	}))
+
+	z := zoho.New()
+	z.CustomHTTPClient(srv.Client())
+	if err := z.SetBaseURL(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	z.SetTokenManager(&zohotest.TokenStore{Token: zoho.AccessTokenResponse{
+		AccessToken: "access",
+		ExpiresAt:   time.Now().Add(time.Hour),
+	}})
+	return New(z), &requests, srv.Close
+}
+
+func TestRevokeRecordShare(t *testing.T) {
+	c, requests, stop := sharingServer(t, false)
+	defer stop()
+
+	res, err := c.RevokeRecordShare(LeadsModule, "100", "2")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(res.Restored) != 2 || res.Restored[0].User.ID != "1" || res.Restored[1].SharedWith.ID != "3" {
+		t.Errorf("restored %+v", res.Restored)
+	}
+	if len(res.NotRestored) != 1 || res.NotRestored[0].ID != "4" {
+		t.Errorf("not restored %+v", res.NotRestored)
+	}
+
+	if len(*requests) != 3 {
+		t.Fatalf("sent requests %v", *requests)
+	}
+	var restore struct {
+		Share []ShareData `json:"share"`
+	}
+	body := strings.TrimPrefix((*requests)[2], "POST ")
+	if err := json.Unmarshal([]byte(body), &restore); err != nil {
+		t.Fatal(err)
+	}
+	if len(restore.Share) != 2 || restore.Share[0].Permission != ShareReadWrite || !restore.Share[0].ShareRelatedRecords {
+		t.Errorf("restored shares %s", body)
+	}
+}
+
+func TestRevokeRecordShareRestoreFails(t *testing.T) {
+	c, _, stop := sharingServer(t, true)
+	defer stop()
+
+	_, err := c.RevokeRecordShare(LeadsModule, "100", "2")
+	revokeErr, ok := err.(*RevokeShareError)
+	if !ok {
+		t.Fatalf("returned error %v, want a *RevokeShareError", err)
+	}
+	if len(revokeErr.Lost) != 2 || !strings.Contains(err.Error(), "user 1, role 3") {
+		t.Errorf("returned error %s, lost %+v", err, revokeErr.Lost)
+	}
+}
@@ -0,0 +1,240 @@
+package crm
+
+import (
+	"fmt"
+	"strings"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// GetTags will return the tags of the module
+// https://www.zoho.com/crm/developer/docs/api/v2/get-tag-list.html
+func (c *API) GetTags(module Module) (data TagsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "tags",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/tags", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &TagsResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return TagsResponse{}, fmt.Errorf("Failed to retrieve tags of %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*TagsResponse); ok {
+		return *v, nil
+	}
+
+	return TagsResponse{}, fmt.Errorf("Data returned was not 'TagsResponse'")
+}
+
+// TagsResponse is the data returned by GetTags
+type TagsResponse struct {
+	Tags []struct {
+		CreatedBy    *Lookup `json:"created_by,omitempty"`
+		ModifiedBy   *Lookup `json:"modified_by,omitempty"`
+		CreatedTime  Time    `json:"created_time,omitempty"`
+		ModifiedTime Time    `json:"modified_time,omitempty"`
+		Name         string  `json:"name,omitempty"`
+		ID           string  `json:"id,omitempty"`
+	} `json:"tags,omitempty"`
+	Info struct {
+		Count        int `json:"count,omitempty"`
+		AllowedCount int `json:"allowed_count,omitempty"`
+	} `json:"info,omitempty"`
+}
+
+// CreateTags will create tags with the provided names in the module
+// https://www.zoho.com/crm/developer/docs/api/v2/create-tags.html
+func (c *API) CreateTags(module Module, names ...string) (data TagActionResponse, err error) {
+	request := TagsData{}
+	for _, n := range names {
+		request.Tags = append(request.Tags, TagData{Name: n})
+	}
+
+	endpoint := zoho.Endpoint{
+		Name:         "tags",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/tags", c.ZohoTLD),
+		Method:       zoho.HTTPPost,
+		ResponseData: &TagActionResponse{},
+		RequestBody:  request,
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return TagActionResponse{}, fmt.Errorf("Failed to create tags in %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*TagActionResponse); ok {
+		return *v, nil
+	}
+
+	return TagActionResponse{}, fmt.Errorf("Data returned was not 'TagActionResponse'")
+}
+
+// UpdateTags will rename the tags of the module, the ID of each tag must be provided
+// https://www.zoho.com/crm/developer/docs/api/v2/update-tags.html
+func (c *API) UpdateTags(request TagsData, module Module) (data TagActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "tags",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/tags", c.ZohoTLD),
+		Method:       zoho.HTTPPut,
+		ResponseData: &TagActionResponse{},
+		RequestBody:  request,
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return TagActionResponse{}, fmt.Errorf("Failed to update tags in %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*TagActionResponse); ok {
+		return *v, nil
+	}
+
+	return TagActionResponse{}, fmt.Errorf("Data returned was not 'TagActionResponse'")
+}
+
+// DeleteTag will delete the tag specified by id, the tag is removed from every record
+// https://www.zoho.com/crm/developer/docs/api/v2/delete-tag.html
+func (c *API) DeleteTag(id string) (data TagActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "tags",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/tags/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &TagActionResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return TagActionResponse{}, fmt.Errorf("Failed to delete tag (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*TagActionResponse); ok {
+		return *v, nil
+	}
+
+	return TagActionResponse{}, fmt.Errorf("Data returned was not 'TagActionResponse'")
+}
+
+// MergeTags will merge the tag specified by id into the tag specified by intoID
+// https://www.zoho.com/crm/developer/docs/api/v2/merge-tags.html
+func (c *API) MergeTags(id, intoID string) (data TagActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "tags",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/tags/%s/actions/merge", c.ZohoTLD, id),
+		Method:       zoho.HTTPPost,
+		ResponseData: &TagActionResponse{},
+		RequestBody: map[string]interface{}{
+			"tags": []map[string]string{{"conflict_id": intoID}},
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return TagActionResponse{}, fmt.Errorf("Failed to merge tag (%s) into (%s): %s", id, intoID, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*TagActionResponse); ok {
+		return *v, nil
+	}
+
+	return TagActionResponse{}, fmt.Errorf("Data returned was not 'TagActionResponse'")
+}
+
+// TagsData is the data provided to UpdateTags
+type TagsData struct {
+	Tags []TagData `json:"tags"`
+}
+
+// TagData is the name of a tag, and its ID when it is updated
+type TagData struct {
+	ID   string `json:"id,omitempty"`
+	Name string `json:"name"`
+}
+
+// TagActionResponse is the data returned by CreateTags, UpdateTags, DeleteTag and MergeTags
+type TagActionResponse struct {
+	Tags []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			CreatedBy    *Lookup `json:"created_by,omitempty"`
+			ModifiedBy   *Lookup `json:"modified_by,omitempty"`
+			CreatedTime  Time    `json:"created_time,omitempty"`
+			ModifiedTime Time    `json:"modified_time,omitempty"`
+			ID           string  `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"tags,omitempty"`
+}
+
+// AddTags will add the tags to the records specified by IDs, tags that do not exist are created. When
+// overWrite is true, the existing tags of the records are replaced.
+// https://www.zoho.com/crm/developer/docs/api/v2/add-tags.html
+func (c *API) AddTags(module Module, ids []string, tags []string, overWrite bool) (data RecordTagsResponse, err error) {
+	return c.recordTags("add_tags", module, ids, tags, overWrite)
+}
+
+// RemoveTags will remove the tags from the records specified by IDs
+// https://www.zoho.com/crm/developer/docs/api/v2/remove-tags.html
+func (c *API) RemoveTags(module Module, ids []string, tags []string) (data RecordTagsResponse, err error) {
+	return c.recordTags("remove_tags", module, ids, tags, false)
+}
+
+func (c *API) recordTags(action string, module Module, ids []string, tags []string, overWrite bool) (data RecordTagsResponse, err error) {
+	if len(ids) == 0 || len(tags) == 0 {
+		return RecordTagsResponse{}, fmt.Errorf("Failed to %s, must provide at least 1 ID and tag", strings.Replace(action, "_", " ", 1))
+	}
+
+	endpoint := zoho.Endpoint{
+		Name:         "tags",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/%s/actions/%s", c.ZohoTLD, module, action),
+		Method:       zoho.HTTPPost,
+		ResponseData: &RecordTagsResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"ids":       zoho.Parameter(strings.Join(ids, ",")),
+			"tag_names": zoho.Parameter(strings.Join(tags, ",")),
+		},
+	}
+	if overWrite {
+		endpoint.URLParameters["over_write"] = "true"
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return RecordTagsResponse{}, fmt.Errorf("Failed to %s of %s: %s", strings.Replace(action, "_", " ", 1), module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*RecordTagsResponse); ok {
+		return *v, nil
+	}
+
+	return RecordTagsResponse{}, fmt.Errorf("Data returned was not 'RecordTagsResponse'")
+}
+
+// RecordTagsResponse is the data returned by AddTags and RemoveTags
+type RecordTagsResponse struct {
+	Data []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ID   string   `json:"id,omitempty"`
+			Tags []string `json:"tags,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"data,omitempty"`
+	LockedCount  int  `json:"locked_count,omitempty"`
+	SuccessCount int  `json:"success_count,omitempty"`
+	WfScheduler  bool `json:"wf_scheduler,omitempty"`
+}
@@ -0,0 +1,245 @@
+package crm
+
+import (
+	"fmt"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// GetTerritories will return the list of territories in this CRM organization
+// https://www.zoho.com/crm/developer/docs/api/v2/get-territories.html
+func (c *API) GetTerritories() (data TerritoriesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "territories",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/territories", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &TerritoriesResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return TerritoriesResponse{}, fmt.Errorf("Failed to retrieve territories: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*TerritoriesResponse); ok {
+		return *v, nil
+	}
+
+	return TerritoriesResponse{}, fmt.Errorf("Data retrieved was not 'TerritoriesResponse'")
+}
+
+// GetTerritory will return the territory specified by id
+// https://www.zoho.com/crm/developer/docs/api/v2/get-territories.html
+func (c *API) GetTerritory(id string) (data TerritoriesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "territories",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/settings/territories/%s",
+			c.ZohoTLD,
+			id,
+		),
+		Method:       zoho.HTTPGet,
+		ResponseData: &TerritoriesResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return TerritoriesResponse{}, fmt.Errorf("Failed to retrieve territory (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*TerritoriesResponse); ok {
+		return *v, nil
+	}
+
+	return TerritoriesResponse{}, fmt.Errorf("Data retrieved was not 'TerritoriesResponse'")
+}
+
+// TerritoriesResponse is the data returned by GetTerritories and GetTerritory
+type TerritoriesResponse struct {
+	Territories []struct {
+		CreatedTime  Time    `json:"created_time,omitempty"`
+		ModifiedTime Time    `json:"modified_time,omitempty"`
+		Manager      *Lookup `json:"manager,omitempty"`
+		ReportingTo  *Lookup `json:"reporting_to,omitempty"`
+		ParentID     string  `json:"parent_id,omitempty"`
+		// AccountRuleCriteria and DealRuleCriteria are the raw criteria that assign records to the territory
+		AccountRuleCriteria interface{} `json:"account_rule_criteria,omitempty"`
+		DealRuleCriteria    interface{} `json:"deal_rule_criteria,omitempty"`
+		Name                string      `json:"name,omitempty"`
+		ModifiedBy          *Lookup     `json:"modified_by,omitempty"`
+		Description         string      `json:"description,omitempty"`
+		ID                  string      `json:"id,omitempty"`
+		CreatedBy           *Lookup     `json:"created_by,omitempty"`
+		PermissionType      string      `json:"permission_type,omitempty"`
+	} `json:"territories,omitempty"`
+	Info PageInfo `json:"info,omitempty"`
+}
+
+// CreateTerritory will add a territory to the territory hierarchy
+// https://www.zoho.com/crm/developer/docs/api/v2/add-territories.html
+func (c *API) CreateTerritory(request TerritoryData) (data TerritoryActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "territories",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/territories", c.ZohoTLD),
+		Method:       zoho.HTTPPost,
+		ResponseData: &TerritoryActionResponse{},
+		RequestBody:  TerritoriesData{Territories: []TerritoryData{request}},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return TerritoryActionResponse{}, fmt.Errorf("Failed to create territory: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*TerritoryActionResponse); ok {
+		return *v, nil
+	}
+
+	return TerritoryActionResponse{}, fmt.Errorf("Data retrieved was not 'TerritoryActionResponse'")
+}
+
+// UpdateTerritory will modify the territory specified by id
+// https://www.zoho.com/crm/developer/docs/api/v2/update-territories.html
+func (c *API) UpdateTerritory(request TerritoryData, id string) (data TerritoryActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "territories",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/settings/territories/%s",
+			c.ZohoTLD,
+			id,
+		),
+		Method:       zoho.HTTPPut,
+		ResponseData: &TerritoryActionResponse{},
+		RequestBody:  TerritoriesData{Territories: []TerritoryData{request}},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return TerritoryActionResponse{}, fmt.Errorf("Failed to update territory (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*TerritoryActionResponse); ok {
+		return *v, nil
+	}
+
+	return TerritoryActionResponse{}, fmt.Errorf("Data retrieved was not 'TerritoryActionResponse'")
+}
+
+// DeleteTerritory will delete the territory specified by id
+// https://www.zoho.com/crm/developer/docs/api/v2/delete-territories.html
+func (c *API) DeleteTerritory(id string) (data TerritoryActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "territories",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/settings/territories/%s",
+			c.ZohoTLD,
+			id,
+		),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &TerritoryActionResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return TerritoryActionResponse{}, fmt.Errorf("Failed to delete territory (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*TerritoryActionResponse); ok {
+		return *v, nil
+	}
+
+	return TerritoryActionResponse{}, fmt.Errorf("Data retrieved was not 'TerritoryActionResponse'")
+}
+
+// AssignTerritoryUsers will add the users specified by userIDs to the territory specified by id
+// https://www.zoho.com/crm/developer/docs/api/v2/associate-users-territory.html
+func (c *API) AssignTerritoryUsers(id string, userIDs []string) (data UserActionResponse, err error) {
+	if len(userIDs) == 0 {
+		return UserActionResponse{}, fmt.Errorf("Failed to assign users to territory, must provide at least 1 ID")
+	}
+
+	users := UsersData{}
+	for _, u := range userIDs {
+		users.Users = append(users.Users, UserData{ID: u})
+	}
+
+	endpoint := zoho.Endpoint{
+		Name: "territories",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/settings/territories/%s/users",
+			c.ZohoTLD,
+			id,
+		),
+		Method:       zoho.HTTPPut,
+		ResponseData: &UserActionResponse{},
+		RequestBody:  users,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UserActionResponse{}, fmt.Errorf("Failed to assign users to territory (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UserActionResponse); ok {
+		return *v, nil
+	}
+
+	return UserActionResponse{}, fmt.Errorf("Data retrieved was not 'UserActionResponse'")
+}
+
+// RemoveTerritoryUser will remove the user specified by userID from the territory specified by id
+// https://www.zoho.com/crm/developer/docs/api/v2/remove-users-territory.html
+func (c *API) RemoveTerritoryUser(id, userID string) (data UserActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name: "territories",
+		URL: fmt.Sprintf(
+			"https://www.zohoapis.%s/crm/v2/settings/territories/%s/users/%s",
+			c.ZohoTLD,
+			id,
+			userID,
+		),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &UserActionResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UserActionResponse{}, fmt.Errorf("Failed to remove user (%s) from territory (%s): %s", userID, id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UserActionResponse); ok {
+		return *v, nil
+	}
+
+	return UserActionResponse{}, fmt.Errorf("Data retrieved was not 'UserActionResponse'")
+}
+
+// TerritoriesData is the data provided to CreateTerritory and UpdateTerritory
+type TerritoriesData struct {
+	Territories []TerritoryData `json:"territories"`
+}
+
+// TerritoryData is a territory in the territory hierarchy. Manager is the ID of the user managing the territory,
+// and ReportingTo the ID of the parent territory. PermissionType is 'read_only' or 'read_write'.
+type TerritoryData struct {
+	Name           string  `json:"name,omitempty"`
+	Description    string  `json:"description,omitempty"`
+	Manager        *Lookup `json:"manager,omitempty"`
+	ReportingTo    *Lookup `json:"reporting_to,omitempty"`
+	PermissionType string  `json:"permission_type,omitempty"`
+	// AccountRuleCriteria and DealRuleCriteria assign Accounts and Deals matching the criteria to the territory
+	AccountRuleCriteria interface{} `json:"account_rule_criteria,omitempty"`
+	DealRuleCriteria    interface{} `json:"deal_rule_criteria,omitempty"`
+}
+
+// TerritoryActionResponse is the data returned by CreateTerritory, UpdateTerritory and DeleteTerritory
+type TerritoryActionResponse struct {
+	Territories []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ID string `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"territories,omitempty"`
+}
@@ -51,7 +51,7 @@ type SingleLine string
 func (s *SingleLine) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
 		t := SingleLine("")
-		s = &t
+		*s = t
 		return nil
 	}
 
@@ -61,15 +61,12 @@ func (s *SingleLine) UnmarshalJSON(data []byte) error {
 	}
 
 	tp := SingleLine(t)
-	s = &tp
+	*s = tp
 	return nil
 }
 
 func (s SingleLine) MarshalJSON() ([]byte, error) {
-	if string(s) == "" {
-		return []byte{}, nil
-	}
-	return []byte(s), nil
+	return json.Marshal(string(s))
 }
 
 // MultiLine is the field type in Zoho that defines a multiline input field, like text area in HTML
@@ -78,7 +75,7 @@ type MultiLine string
 func (s *MultiLine) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
 		t := MultiLine("")
-		s = &t
+		*s = t
 		return nil
 	}
 
@@ -88,15 +85,12 @@ func (s *MultiLine) UnmarshalJSON(data []byte) error {
 	}
 
 	tp := MultiLine(t)
-	s = &tp
+	*s = tp
 	return nil
 }
 
 func (s MultiLine) MarshalJSON() ([]byte, error) {
-	if string(s) == "" {
-		return []byte{}, nil
-	}
-	return []byte(s), nil
+	return json.Marshal(string(s))
 }
 
 // Email is the field type in Zoho that defines an email address field
@@ -105,7 +99,7 @@ type Email string
 func (s *Email) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
 		t := Email("")
-		s = &t
+		*s = t
 		return nil
 	}
 
@@ -115,15 +109,12 @@ func (s *Email) UnmarshalJSON(data []byte) error {
 	}
 
 	tp := Email(t)
-	s = &tp
+	*s = tp
 	return nil
 }
 
 func (s Email) MarshalJSON() ([]byte, error) {
-	if string(s) == "" {
-		return []byte{}, nil
-	}
-	return []byte(s), nil
+	return json.Marshal(string(s))
 }
 
 // Phone is the field type in Zoho that defines a phone number field
@@ -132,7 +123,7 @@ type Phone string
 func (s *Phone) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
 		t := Phone("")
-		s = &t
+		*s = t
 		return nil
 	}
 
@@ -142,15 +133,12 @@ func (s *Phone) UnmarshalJSON(data []byte) error {
 	}
 
 	tp := Phone(t)
-	s = &tp
+	*s = tp
 	return nil
 }
 
 func (s Phone) MarshalJSON() ([]byte, error) {
-	if string(s) == "" {
-		return []byte{}, nil
-	}
-	return []byte(s), nil
+	return json.Marshal(string(s))
 }
 
 // PickList is the field type in Zoho that defines a dropdown that has been selected
@@ -159,7 +147,7 @@ type PickList string
 func (s *PickList) UnmarshalJSON(data []byte) error {
 	if string(data) == "null" {
 		t := PickList("")
-		s = &t
+		*s = t
 		return nil
 	}
 
@@ -169,13 +157,10 @@ func (s *PickList) UnmarshalJSON(data []byte) error {
 	}
 
 	tp := PickList(t)
-	s = &tp
+	*s = tp
 	return nil
 }
 
 func (s PickList) MarshalJSON() ([]byte, error) {
-	if string(s) == "" {
-		return []byte{}, nil
-	}
-	return []byte(s), nil
+	return json.Marshal(string(s))
 }
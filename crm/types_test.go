@@ -0,0 +1,49 @@
+package crm
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// TestStringTypesJSON checks the string field types are encoded as JSON strings, and that decoding sets
+// the value of the field
+func TestStringTypesJSON(t *testing.T) {
+	type record struct {
+		Name     SingleLine `json:"Name"`
+		Notes    MultiLine  `json:"Notes,omitempty"`
+		Email    Email      `json:"Email"`
+		Phone    Phone      `json:"Phone"`
+		Status   PickList   `json:"Status"`
+		Optional SingleLine `json:"Optional,omitempty"`
+	}
+
+	in := record{Name: "Smith \"Jr\"", Notes: "line 1\nline 2", Email: "a@b.com", Phone: "555-0100"}
+	b, err := json.Marshal(in)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"Name":"Smith \"Jr\"","Notes":"line 1\nline 2","Email":"a@b.com","Phone":"555-0100","Status":""}`
+	if string(b) != want {
+		t.Errorf("marshalled %s, want %s", b, want)
+	}
+
+	var out record
+	if err := json.Unmarshal(b, &out); err != nil {
+		t.Fatal(err)
+	}
+	if out != in {
+		t.Errorf("unmarshalled %+v, want %+v", out, in)
+	}
+
+	out = record{Name: "previous"}
+	if err := json.Unmarshal([]byte(`{"Name":null,"Status":"Open"}`), &out); err != nil {
+		t.Fatal(err)
+	}
+	if out.Name != "" || out.Status != "Open" {
+		t.Errorf("unmarshalled %+v, want an empty Name and Status 'Open'", out)
+	}
+
+	if err := json.Unmarshal([]byte(`{"Name":12}`), &out); err == nil {
+		t.Error("unmarshalling a number did not return an error")
+	}
+}
@@ -170,3 +170,143 @@ type UsersResponse struct {
 	} `json:"users,omitempty"`
 	Info PageInfo `json:"info,omitempty"`
 }
+
+// AddUser will add a user to the CRM organization, an invitation is sent to the users email. The Role,
+// Profile, FirstName, LastName and Email of the user must be provided.
+// https://www.zoho.com/crm/developer/docs/api/v2/add-user.html
+func (c *API) AddUser(request UserData) (data UserActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "users",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/users", c.ZohoTLD),
+		Method:       zoho.HTTPPost,
+		ResponseData: &UserActionResponse{},
+		RequestBody:  UsersData{Users: []UserData{request}},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UserActionResponse{}, fmt.Errorf("Failed to add user: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UserActionResponse); ok {
+		return *v, nil
+	}
+
+	return UserActionResponse{}, fmt.Errorf("Data retrieved was not 'UserActionResponse'")
+}
+
+// UpdateUser will modify the user specified by id, a user can be deactivated by setting the Status to 'disabled'
+// https://www.zoho.com/crm/developer/docs/api/v2/update-user.html
+func (c *API) UpdateUser(request UserData, id string) (data UserActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "users",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/users/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPPut,
+		ResponseData: &UserActionResponse{},
+		RequestBody:  UsersData{Users: []UserData{request}},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UserActionResponse{}, fmt.Errorf("Failed to update user (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UserActionResponse); ok {
+		return *v, nil
+	}
+
+	return UserActionResponse{}, fmt.Errorf("Data retrieved was not 'UserActionResponse'")
+}
+
+// UpdateUsers will modify many users at once, the ID of each user must be provided
+// https://www.zoho.com/crm/developer/docs/api/v2/update-user.html
+func (c *API) UpdateUsers(request UsersData) (data UserActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "users",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/users", c.ZohoTLD),
+		Method:       zoho.HTTPPut,
+		ResponseData: &UserActionResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UserActionResponse{}, fmt.Errorf("Failed to update users: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UserActionResponse); ok {
+		return *v, nil
+	}
+
+	return UserActionResponse{}, fmt.Errorf("Data retrieved was not 'UserActionResponse'")
+}
+
+// DeleteUser will remove the user specified by id from the CRM organization
+// https://www.zoho.com/crm/developer/docs/api/v2/delete-user.html
+func (c *API) DeleteUser(id string) (data UserActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "users",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/users/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &UserActionResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return UserActionResponse{}, fmt.Errorf("Failed to delete user (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*UserActionResponse); ok {
+		return *v, nil
+	}
+
+	return UserActionResponse{}, fmt.Errorf("Data retrieved was not 'UserActionResponse'")
+}
+
+// UsersData is the data provided to UpdateUsers
+type UsersData struct {
+	Users []UserData `json:"users"`
+}
+
+// UserData is the data provided to AddUser and UpdateUser. Role and Profile are the IDs of the users role and profile.
+type UserData struct {
+	ID            string   `json:"id,omitempty"`
+	Role          string   `json:"role,omitempty"`
+	Profile       string   `json:"profile,omitempty"`
+	FirstName     string   `json:"first_name,omitempty"`
+	LastName      string   `json:"last_name,omitempty"`
+	Email         string   `json:"email,omitempty"`
+	Phone         string   `json:"phone,omitempty"`
+	Mobile        string   `json:"mobile,omitempty"`
+	Website       string   `json:"website,omitempty"`
+	Fax           string   `json:"fax,omitempty"`
+	Dob           string   `json:"dob,omitempty"`
+	Alias         string   `json:"alias,omitempty"`
+	Street        string   `json:"street,omitempty"`
+	City          string   `json:"city,omitempty"`
+	State         string   `json:"state,omitempty"`
+	Zip           string   `json:"zip,omitempty"`
+	Country       string   `json:"country,omitempty"`
+	CountryLocale string   `json:"country_locale,omitempty"`
+	Language      string   `json:"language,omitempty"`
+	Locale        string   `json:"locale,omitempty"`
+	TimeFormat    string   `json:"time_format,omitempty"`
+	DateFormat    string   `json:"date_format,omitempty"`
+	TimeZone      string   `json:"time_zone,omitempty"`
+	ReportingTo   *Lookup  `json:"Reporting_To,omitempty"`
+	Territories   []Lookup `json:"territories,omitempty"`
+	// Status is 'active' or 'disabled'
+	Status string `json:"status,omitempty"`
+}
+
+// UserActionResponse is the data returned by AddUser, UpdateUser, UpdateUsers and DeleteUser
+type UserActionResponse struct {
+	Users []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ID string `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"users,omitempty"`
+}
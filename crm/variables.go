@@ -0,0 +1,262 @@
+package crm
+
+import (
+	"fmt"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// GetVariables will return the CRM variables, the 'group' parameter filters the variables by
+// the ID or API name of their variable group
+// https://www.zoho.com/crm/developer/docs/api/v2/get-variables.html
+func (c *API) GetVariables(params map[string]zoho.Parameter) (data VariablesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "variables",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/variables", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &VariablesResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"group": "",
+		},
+	}
+
+	for k, v := range params {
+		endpoint.URLParameters[k] = v
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return VariablesResponse{}, fmt.Errorf("Failed to retrieve variables: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*VariablesResponse); ok {
+		return *v, nil
+	}
+
+	return VariablesResponse{}, fmt.Errorf("Data returned was not 'VariablesResponse'")
+}
+
+// GetVariable will return the variable specified by the ID or API name, a variable referenced by API name
+// must provide its group as the 'group' parameter
+// https://www.zoho.com/crm/developer/docs/api/v2/get-variables.html
+func (c *API) GetVariable(id string, params map[string]zoho.Parameter) (data VariablesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "variables",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/variables/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPGet,
+		ResponseData: &VariablesResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"group": "",
+		},
+	}
+
+	for k, v := range params {
+		endpoint.URLParameters[k] = v
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return VariablesResponse{}, fmt.Errorf("Failed to retrieve variable (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*VariablesResponse); ok {
+		return *v, nil
+	}
+
+	return VariablesResponse{}, fmt.Errorf("Data returned was not 'VariablesResponse'")
+}
+
+// VariablesResponse is the data returned by GetVariables and GetVariable
+type VariablesResponse struct {
+	Variables []Variable `json:"variables,omitempty"`
+}
+
+// Variable is a CRM variable, Type is one of 'integer', 'text', 'percent', 'decimal', 'currency', 'date',
+// 'datetime', 'email', 'phone', 'url', 'checkbox' or 'textarea'
+type Variable struct {
+	APIName       string         `json:"api_name,omitempty"`
+	Name          string         `json:"name,omitempty"`
+	Description   string         `json:"description,omitempty"`
+	ID            string         `json:"id,omitempty"`
+	Type          string         `json:"type,omitempty"`
+	VariableGroup *VariableGroup `json:"variable_group,omitempty"`
+	Value         interface{}    `json:"value,omitempty"`
+}
+
+// Variable returns the variable specified by API name
+func (v VariablesResponse) Variable(apiName string) (Variable, bool) {
+	for _, variable := range v.Variables {
+		if variable.APIName == apiName {
+			return variable, true
+		}
+	}
+	return Variable{}, false
+}
+
+// CreateVariables will create the variables, the Name, APIName, Type and VariableGroup of each must be provided
+// https://www.zoho.com/crm/developer/docs/api/v2/create-variables.html
+func (c *API) CreateVariables(request VariablesData) (data VariableActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "variables",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/variables", c.ZohoTLD),
+		Method:       zoho.HTTPPost,
+		ResponseData: &VariableActionResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return VariableActionResponse{}, fmt.Errorf("Failed to create variables: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*VariableActionResponse); ok {
+		return *v, nil
+	}
+
+	return VariableActionResponse{}, fmt.Errorf("Data returned was not 'VariableActionResponse'")
+}
+
+// UpdateVariables will modify the variables, the ID of each must be provided
+// https://www.zoho.com/crm/developer/docs/api/v2/update-variables.html
+func (c *API) UpdateVariables(request VariablesData) (data VariableActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "variables",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/variables", c.ZohoTLD),
+		Method:       zoho.HTTPPut,
+		ResponseData: &VariableActionResponse{},
+		RequestBody:  request,
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return VariableActionResponse{}, fmt.Errorf("Failed to update variables: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*VariableActionResponse); ok {
+		return *v, nil
+	}
+
+	return VariableActionResponse{}, fmt.Errorf("Data returned was not 'VariableActionResponse'")
+}
+
+// SetVariable will set the value of the variable specified by id
+// https://www.zoho.com/crm/developer/docs/api/v2/update-variables.html
+func (c *API) SetVariable(id string, value interface{}) (data VariableActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "variables",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/variables/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPPut,
+		ResponseData: &VariableActionResponse{},
+		RequestBody: VariablesData{
+			Variables: []Variable{{ID: id, Value: value}},
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return VariableActionResponse{}, fmt.Errorf("Failed to update variable (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*VariableActionResponse); ok {
+		return *v, nil
+	}
+
+	return VariableActionResponse{}, fmt.Errorf("Data returned was not 'VariableActionResponse'")
+}
+
+// DeleteVariable will delete the variable specified by id
+// https://www.zoho.com/crm/developer/docs/api/v2/delete-variables.html
+func (c *API) DeleteVariable(id string) (data VariableActionResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "variables",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/variables/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPDelete,
+		ResponseData: &VariableActionResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return VariableActionResponse{}, fmt.Errorf("Failed to delete variable (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*VariableActionResponse); ok {
+		return *v, nil
+	}
+
+	return VariableActionResponse{}, fmt.Errorf("Data returned was not 'VariableActionResponse'")
+}
+
+// VariablesData is the data provided to CreateVariables and UpdateVariables
+type VariablesData struct {
+	Variables []Variable `json:"variables"`
+}
+
+// VariableActionResponse is the data returned by CreateVariables, UpdateVariables, SetVariable and DeleteVariable
+type VariableActionResponse struct {
+	Variables []struct {
+		Code    string `json:"code,omitempty"`
+		Details struct {
+			ID string `json:"id,omitempty"`
+		} `json:"details,omitempty"`
+		Message string `json:"message,omitempty"`
+		Status  string `json:"status,omitempty"`
+	} `json:"variables,omitempty"`
+}
+
+// GetVariableGroups will return the variable groups
+// https://www.zoho.com/crm/developer/docs/api/v2/get-variable-groups.html
+func (c *API) GetVariableGroups() (data VariableGroupsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "variable_groups",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/variable_groups", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &VariableGroupsResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return VariableGroupsResponse{}, fmt.Errorf("Failed to retrieve variable groups: %s", err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*VariableGroupsResponse); ok {
+		return *v, nil
+	}
+
+	return VariableGroupsResponse{}, fmt.Errorf("Data returned was not 'VariableGroupsResponse'")
+}
+
+// GetVariableGroup will return the variable group specified by the ID or API name
+// https://www.zoho.com/crm/developer/docs/api/v2/get-variable-groups.html
+func (c *API) GetVariableGroup(id string) (data VariableGroupsResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "variable_groups",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v2/settings/variable_groups/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPGet,
+		ResponseData: &VariableGroupsResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return VariableGroupsResponse{}, fmt.Errorf("Failed to retrieve variable group (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*VariableGroupsResponse); ok {
+		return *v, nil
+	}
+
+	return VariableGroupsResponse{}, fmt.Errorf("Data returned was not 'VariableGroupsResponse'")
+}
+
+// VariableGroupsResponse is the data returned by GetVariableGroups and GetVariableGroup
+type VariableGroupsResponse struct {
+	VariableGroups []VariableGroup `json:"variable_groups,omitempty"`
+}
+
+// VariableGroup is a group of CRM variables, a group is referenced by its ID or Name
+type VariableGroup struct {
+	DisplayLabel string `json:"display_label,omitempty"`
+	APIName      string `json:"api_name,omitempty"`
+	Name         string `json:"name,omitempty"`
+	Description  string `json:"description,omitempty"`
+	ID           string `json:"id,omitempty"`
+}
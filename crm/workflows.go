@@ -0,0 +1,104 @@
+package crm
+
+import (
+	"fmt"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// Triggers which can be provided to InsertRecordsData and UpdateRecordsData
+const (
+	WorkflowTrigger  = "workflow"
+	ApprovalTrigger  = "approval"
+	BlueprintTrigger = "blueprint"
+)
+
+// GetWorkflowRules will return the workflow rules of the module
+// https://www.zoho.com/crm/developer/docs/api/v6/get-workflow-rules.html
+func (c *API) GetWorkflowRules(module Module) (data WorkflowRulesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "workflow_rules",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v6/settings/workflow_rules", c.ZohoTLD),
+		Method:       zoho.HTTPGet,
+		ResponseData: &WorkflowRulesResponse{},
+		URLParameters: map[string]zoho.Parameter{
+			"module": zoho.Parameter(module),
+		},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return WorkflowRulesResponse{}, fmt.Errorf("Failed to retrieve workflow rules of %s: %s", module, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*WorkflowRulesResponse); ok {
+		return *v, nil
+	}
+
+	return WorkflowRulesResponse{}, fmt.Errorf("Data returned was not 'WorkflowRulesResponse'")
+}
+
+// GetWorkflowRule will return the workflow rule specified by id
+// https://www.zoho.com/crm/developer/docs/api/v6/get-workflow-rules.html
+func (c *API) GetWorkflowRule(id string) (data WorkflowRulesResponse, err error) {
+	endpoint := zoho.Endpoint{
+		Name:         "workflow_rules",
+		URL:          fmt.Sprintf("https://www.zohoapis.%s/crm/v6/settings/workflow_rules/%s", c.ZohoTLD, id),
+		Method:       zoho.HTTPGet,
+		ResponseData: &WorkflowRulesResponse{},
+	}
+
+	err = c.Zoho.HTTPRequest(&endpoint)
+	if err != nil {
+		return WorkflowRulesResponse{}, fmt.Errorf("Failed to retrieve workflow rule (%s): %s", id, err)
+	}
+
+	if v, ok := endpoint.ResponseData.(*WorkflowRulesResponse); ok {
+		return *v, nil
+	}
+
+	return WorkflowRulesResponse{}, fmt.Errorf("Data returned was not 'WorkflowRulesResponse'")
+}
+
+// WorkflowRulesResponse is the data returned by GetWorkflowRules and GetWorkflowRule
+type WorkflowRulesResponse struct {
+	WorkflowRules []struct {
+		ID          string `json:"id,omitempty"`
+		Name        string `json:"name,omitempty"`
+		Description string `json:"description,omitempty"`
+		Module      struct {
+			APIName Module `json:"api_name,omitempty"`
+			ID      string `json:"id,omitempty"`
+		} `json:"module,omitempty"`
+		Status struct {
+			Active bool `json:"active,omitempty"`
+			Delete bool `json:"delete,omitempty"`
+		} `json:"status,omitempty"`
+		ExecuteWhen struct {
+			Type    string                 `json:"type,omitempty"`
+			Details map[string]interface{} `json:"details,omitempty"`
+		} `json:"execute_when,omitempty"`
+		Conditions    []map[string]interface{} `json:"conditions,omitempty"`
+		CreatedBy     *Lookup                  `json:"created_by,omitempty"`
+		ModifiedBy    *Lookup                  `json:"modified_by,omitempty"`
+		CreatedTime   *Time                    `json:"created_time,omitempty"`
+		ModifiedTime  *Time                    `json:"modified_time,omitempty"`
+		LastExecuted  *Time                    `json:"last_executed_time,omitempty"`
+		ExecutedCount int                      `json:"executed_count,omitempty"`
+	} `json:"workflow_rules,omitempty"`
+	Info PageInfo `json:"info,omitempty"`
+}
+
+// TriggerWorkflows will update the records with the workflow trigger, firing the workflow rules of the module which
+// execute when a record is edited. Each record must contain its id, and the fields the rules are conditioned on.
+// https://www.zoho.com/crm/developer/docs/api/v2/update-records.html
+func (c *API) TriggerWorkflows(module Module, records ...interface{}) (data UpdateRecordsResponse, err error) {
+	if len(records) == 0 {
+		return UpdateRecordsResponse{}, fmt.Errorf("Failed to trigger workflows, must provide at least 1 record")
+	}
+
+	return c.UpdateRecords(UpdateRecordsData{
+		Data:    records,
+		Trigger: []string{WorkflowTrigger},
+	}, module)
+}
@@ -0,0 +1,79 @@
+// Package datastore provides a zoho.TokenLoaderSaver persisting tokens to the App Engine datastore.
+// It is a separate package so that programs outside of App Engine do not import google.golang.org/appengine,
+// which remains a requirement of the module.
+//
+//    z := zoho.New()
+//    z.SetTokenManager(datastore.Manager{Request: r, TokensKey: "crm"})
+package datastore
+
+import (
+	"fmt"
+	"net/http"
+
+	zoho "github.com/schmorrison/Zoho"
+	"google.golang.org/appengine"
+	"google.golang.org/appengine/datastore"
+)
+
+// Manager is a TokenManager that satisfies the zoho.TokenLoaderSaver interface
+// When instantiating, user must provide the *http.Request for the current app engine request
+// and the token key where the tokens are to be saved to/loaded from.
+type Manager struct {
+	Request         *http.Request
+	EntityNamespace string
+	TokensKey       string
+}
+
+// LoadAccessAndRefreshToken will use datastore package to get tokens from the datastore under the entity namespace
+// 'ZohoAccessTokens' unless a value is provided to the EntityNamespace field
+func (d Manager) LoadAccessAndRefreshToken() (zoho.AccessTokenResponse, error) {
+	t := zoho.TokenWrapper{}
+	if d.Request == nil || d.TokensKey == "" {
+		return zoho.AccessTokenResponse{}, fmt.Errorf("Must provide the *http.Request for the current request and a valid token key")
+	}
+
+	ctx := appengine.NewContext(d.Request)
+	k := datastore.NewKey(ctx, d.entity(), d.TokensKey, 0, nil)
+
+	if err := datastore.Get(ctx, k, &t); err != nil {
+		return zoho.AccessTokenResponse{}, fmt.Errorf("Failed to retrieve tokens from datastore: %s", err)
+	}
+
+	if t.Token.ExpiresAt.IsZero() {
+		t.Token.ExpiresAt = t.Expires
+	}
+	if t.CheckExpiry() {
+		return t.Token, zoho.ErrTokenExpired
+	}
+
+	return t.Token, nil
+}
+
+// SaveTokens will use datastore package to put tokens to the datastore under the entity namespace
+// 'ZohoAccessTokens' unless a value is provided to the EntityNamespace field
+func (d Manager) SaveTokens(t zoho.AccessTokenResponse) error {
+	if d.Request == nil || d.TokensKey == "" {
+		return fmt.Errorf("Must provide the *http.Request for the current request and a valid token key")
+	}
+
+	ctx := appengine.NewContext(d.Request)
+	k := datastore.NewKey(ctx, d.entity(), d.TokensKey, 0, nil)
+
+	v := zoho.TokenWrapper{
+		Token: t,
+	}
+	v.SetExpiry()
+
+	if _, err := datastore.Put(ctx, k, &v); err != nil {
+		return fmt.Errorf("Failed to save tokens to datastore: %s", err)
+	}
+
+	return nil
+}
+
+func (d Manager) entity() string {
+	if d.EntityNamespace != "" {
+		return d.EntityNamespace
+	}
+	return "ZohoAccessTokens"
+}
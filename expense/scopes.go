@@ -0,0 +1,16 @@
+package expense
+
+import (
+	zoho "github.com/schmorrison/Zoho"
+)
+
+var methodScopes = zoho.MethodScopes{
+	"GetExpenseReports": {"ZohoExpense.expensereport.READ"},
+	"GetOrganization":   {"ZohoExpense.orgsettings.READ"},
+}
+
+// RequiredScopes returns the minimal scopes required by the methods of API, named as in the API, eg.
+// RequiredScopes("GetExpenseReports")
+func RequiredScopes(methods ...string) ([]zoho.ScopeString, error) {
+	return methodScopes.Required(methods...)
+}
@@ -0,0 +1,184 @@
+package zoho
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// WebFlow is the authorization code flow of a web application: the user is redirected to the URL returned by
+// Start, and Zoho redirects back to RedirectURI where Callback exchanges the code for tokens. The state returned
+// by Start must be kept, eg. in the user's session, and passed to Callback to protect against CSRF.
+// https://www.zoho.com/accounts/protocol/oauth/web-apps/authorization.html
+//
+//    flow := z.WebFlow(clientID, clientSecret, "https://example.com/oauth/callback", scopes...)
+//    authURL, state, err := flow.Start()
+//    ...
+//    tokens, err := flow.Callback(r, state)
+type WebFlow struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURI  string
+	Scopes       []ScopeString
+
+	// Consent shows the consent screen even if the user already consented, a new refresh token is only
+	// returned when it is shown
+	Consent bool
+
+	z *Zoho
+}
+
+// WebFlow returns a WebFlow obtaining the tokens of z
+func (z *Zoho) WebFlow(clientID, clientSecret, redirectURI string, scopes ...ScopeString) *WebFlow {
+	return &WebFlow{
+		ClientID:     clientID,
+		ClientSecret: clientSecret,
+		RedirectURI:  redirectURI,
+		Scopes:       scopes,
+		z:            z,
+	}
+}
+
+// NewState returns a random value to be used as the state of a WebFlow
+func NewState() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("Failed to generate state: %s", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Start returns the authorization URL the user must be redirected to, and a new state which must be kept to
+// validate the callback
+func (f *WebFlow) Start() (authURL, state string, err error) {
+	state, err = NewState()
+	if err != nil {
+		return "", "", err
+	}
+	return f.AuthURL(state), state, nil
+}
+
+// AuthURL returns the authorization URL with the provided state
+func (f *WebFlow) AuthURL(state string) string {
+	return f.z.authorizationCodeURL(joinScopes(f.Scopes), f.ClientID, f.RedirectURI, state, f.Consent)
+}
+
+// Callback validates the request redirected to RedirectURI and exchanges its code for tokens, which are held and
+// saved like those of GenerateTokenRequest
+func (f *WebFlow) Callback(r *http.Request, state string) (AccessTokenResponse, error) {
+	return f.Exchange(r.URL.Query(), state)
+}
+
+// Exchange validates the query parameters of the callback against the state returned by Start and exchanges the
+// code for tokens. When the user's account is in another data center, indicated by the 'accounts-server'
+// parameter, z is switched to that data center. The APIs read the data center without locking, so the callback
+// must be handled before z is shared with goroutines calling them.
+func (f *WebFlow) Exchange(query url.Values, state string) (AccessTokenResponse, error) {
+	if e := query.Get("error"); e != "" {
+		return AccessTokenResponse{}, fmt.Errorf("Authorization was not granted: %s", e)
+	}
+
+	if state == "" || subtle.ConstantTimeCompare([]byte(query.Get("state")), []byte(state)) != 1 {
+		return AccessTokenResponse{}, ErrInvalidState
+	}
+
+	code := query.Get("code")
+	if code == "" {
+		return AccessTokenResponse{}, fmt.Errorf("No code was recieved from oAuth2 flow")
+	}
+
+	if server := query.Get("accounts-server"); server != "" {
+		if err := f.z.setAccountsServer(server); err != nil {
+			return AccessTokenResponse{}, err
+		}
+	}
+
+	f.z.setClient(f.ClientID, f.ClientSecret, f.RedirectURI)
+	err := f.z.postTokenRequest(f.z.GenerateTokenURL(code, f.ClientID, f.ClientSecret), "generate token")
+	if err != nil {
+		return AccessTokenResponse{}, err
+	}
+
+	f.z.tokenMu.Lock()
+	f.z.oauth.scopes = f.Scopes
+	f.z.tokenMu.Unlock()
+	return f.z.token(), nil
+}
+
+// SelfClientTokenRequest exchanges a grant token generated for a Self Client in the API console for access and
+// refresh tokens, which are held and saved. Unlike GenerateTokenRequest, saved tokens are always replaced.
+// https://www.zoho.com/accounts/protocol/oauth/self-client/authorization-code-flow.html
+func (z *Zoho) SelfClientTokenRequest(clientID, clientSecret, grantToken string) error {
+	z.setClient(clientID, clientSecret, "")
+	return z.postTokenRequest(z.GenerateTokenURL(grantToken, clientID, clientSecret), "generate token")
+}
+
+// ClientCredentialsRequest obtains an access token for a Self Client with the client credentials grant, without
+// any user interaction. The soid identifies the organization, eg. "ZohoCRM.<org ID>". No refresh token is
+// returned, a new access token is requested in the same way when it expires.
+// https://www.zoho.com/accounts/protocol/oauth/self-client/client-credentials-flow.html
+func (z *Zoho) ClientCredentialsRequest(clientID, clientSecret string, scopes []ScopeString, soid string) error {
+	z.setClient(clientID, clientSecret, "")
+	z.tokenMu.Lock()
+	z.oauth.scopes = scopes
+	z.oauth.soid = soid
+	z.oauth.clientCredentials = true
+	z.tokenMu.Unlock()
+
+	return z.singleRefresh(false)
+}
+
+func (z *Zoho) clientCredentialsURL() string {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
+
+	q := url.Values{}
+	q.Set("client_id", z.oauth.clientID)
+	q.Set("client_secret", z.oauth.clientSecret)
+	q.Set("grant_type", "client_credentials")
+	q.Set("scope", joinScopes(z.oauth.scopes))
+	q.Set("soid", z.oauth.soid)
+
+	return fmt.Sprintf("%s%s?%s", z.oauth.baseURL, oauthGenerateTokenRequestSlug, q.Encode())
+}
+
+var accountsServer = regexp.MustCompile(`^accounts\.zoho(cloud)?\.([a-z]{2,3}(\.[a-z]{2})?)$`)
+
+// setAccountsServer switches z to the data center of the accounts server returned in an authorization callback.
+// Only Zoho accounts servers are accepted, as the client secret is sent to it.
+func (z *Zoho) setAccountsServer(server string) error {
+	u, err := url.Parse(server)
+	if err != nil || u.Scheme != "https" || !accountsServer.MatchString(u.Host) {
+		return fmt.Errorf("Invalid accounts server '%s'", server)
+	}
+
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
+	z.oauth.baseURL = fmt.Sprintf("https://%s/oauth/v2/", u.Host)
+	z.ZohoTLD = accountsServer.FindStringSubmatch(u.Host)[2]
+	return nil
+}
+
+// isLocalhost reports whether the URL is on localhost or a loopback address
+func isLocalhost(u *url.URL) bool {
+	host := u.Hostname()
+	if strings.EqualFold(host, "localhost") {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
+
+func joinScopes(scopes []ScopeString) string {
+	s := make([]string, len(scopes))
+	for i, a := range scopes {
+		s[i] = string(a)
+	}
+	return strings.Join(s, ",")
+}
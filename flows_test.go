@@ -0,0 +1,164 @@
+package zoho
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// tokenServer serves the token endpoint, recording the query of each token request
+type tokenServer struct {
+	*httptest.Server
+	mu      sync.Mutex
+	queries []url.Values
+	body    string
+}
+
+func newTokenServer(t *testing.T, body string) (*tokenServer, *Zoho, func()) {
+	ts := &tokenServer{body: body}
+	ts.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path != "/oauth/v2/token" {
+			w.Write([]byte(`{"data":[]}`))
+			return
+		}
+		ts.mu.Lock()
+		ts.queries = append(ts.queries, r.URL.Query())
+		ts.mu.Unlock()
+		w.Write([]byte(ts.body))
+	}))
+
+	dir, err := ioutil.TempDir("", "zoho")
+	if err != nil {
+		t.Fatal(err)
+	}
+	z := New()
+	z.CustomHTTPClient(ts.Client())
+	if err := z.SetBaseURL(ts.URL); err != nil {
+		t.Fatal(err)
+	}
+	z.SetTokensFile(filepath.Join(dir, "tokens"))
+	return ts, z, func() {
+		ts.Close()
+		os.RemoveAll(dir)
+	}
+}
+
+func (ts *tokenServer) requests() []url.Values {
+	ts.mu.Lock()
+	defer ts.mu.Unlock()
+	return append([]url.Values{}, ts.queries...)
+}
+
+func TestWebFlow(t *testing.T) {
+	ts, z, stop := newTokenServer(t, `{"access_token":"access","refresh_token":"refresh","expires_in":3600}`)
+	defer stop()
+
+	flow := z.WebFlow("id", "secret", "https://example.com/callback", "ZohoCRM.modules.ALL")
+	authURL, state, err := flow.Start()
+	if err != nil {
+		t.Fatal(err)
+	}
+	u, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := u.Query()
+	if state == "" || q.Get("state") != state || q.Get("client_id") != "id" || q.Get("redirect_uri") != "https://example.com/callback" || q.Get("scope") != "ZohoCRM.modules.ALL" {
+		t.Errorf("authorization URL %s, state %s", authURL, state)
+	}
+	if _, other, _ := flow.Start(); other == state {
+		t.Error("Start returned the same state twice")
+	}
+
+	tests := []struct {
+		name  string
+		query url.Values
+		state string
+		err   error
+	}{
+		{"wrong state", url.Values{"code": {"c"}, "state": {"other"}}, state, ErrInvalidState},
+		{"missing state", url.Values{"code": {"c"}}, state, ErrInvalidState},
+		{"no state kept", url.Values{"code": {"c"}, "state": {""}}, "", ErrInvalidState},
+		{"denied", url.Values{"error": {"access_denied"}, "state": {state}}, state, nil},
+		{"no code", url.Values{"state": {state}}, state, nil},
+		{"accounts server", url.Values{"code": {"c"}, "state": {state}, "accounts-server": {"https://accounts.example.com"}}, state, nil},
+	}
+	for _, tt := range tests {
+		_, err := flow.Exchange(tt.query, tt.state)
+		if err == nil || (tt.err != nil && err != tt.err) {
+			t.Errorf("%s returned %v, want an error", tt.name, err)
+		}
+	}
+	if n := len(ts.requests()); n != 0 {
+		t.Fatalf("%d token requests were sent for invalid callbacks", n)
+	}
+
+	r := httptest.NewRequest(http.MethodGet, "https://example.com/callback?code=c&state="+state+"&accounts-server=https%3A%2F%2Faccounts.zoho.eu", nil)
+	tokens, err := flow.Callback(r, state)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if tokens.AccessToken != "access" || tokens.RefreshToken != "refresh" {
+		t.Errorf("returned tokens %+v", tokens)
+	}
+	requests := ts.requests()
+	if len(requests) != 1 || requests[0].Get("code") != "c" || requests[0].Get("grant_type") != "authorization_code" || requests[0].Get("redirect_uri") != "https://example.com/callback" {
+		t.Errorf("token requests %v", requests)
+	}
+	if z.ZohoTLD != "eu" || !strings.HasPrefix(z.RefreshTokenURL(), "https://accounts.zoho.eu/oauth/v2/token?") {
+		t.Errorf("data center %s, refresh token URL %s, want the eu data center", z.ZohoTLD, z.RefreshTokenURL())
+	}
+}
+
+func TestSelfClientTokenRequest(t *testing.T) {
+	ts, z, stop := newTokenServer(t, `{"access_token":"access","refresh_token":"refresh","expires_in":3600}`)
+	defer stop()
+
+	if err := z.SelfClientTokenRequest("id", "secret", "grant"); err != nil {
+		t.Fatal(err)
+	}
+	requests := ts.requests()
+	if len(requests) != 1 || requests[0].Get("code") != "grant" || requests[0].Get("grant_type") != "authorization_code" || requests[0].Get("redirect_uri") != "" {
+		t.Errorf("token requests %v", requests)
+	}
+	saved, err := z.LoadAccessAndRefreshToken()
+	if err != nil || saved.RefreshToken != "refresh" {
+		t.Errorf("saved tokens %+v, %v", saved, err)
+	}
+}
+
+func TestClientCredentialsRequest(t *testing.T) {
+	// the token expires within TokenRefreshMargin, so each API request obtains a new one
+	ts, z, stop := newTokenServer(t, `{"access_token":"access","expires_in":30}`)
+	defer stop()
+
+	if err := z.ClientCredentialsRequest("id", "secret", []ScopeString{"ZohoCRM.modules.ALL", "ZohoCRM.settings.READ"}, "ZohoCRM.123"); err != nil {
+		t.Fatal(err)
+	}
+	var data map[string]interface{}
+	err := z.HTTPRequest(&Endpoint{Name: "records", URL: "https://www.zohoapis.com/crm/v2/Leads", Method: HTTPGet, ResponseData: &data})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	requests := ts.requests()
+	if len(requests) != 2 {
+		t.Fatalf("sent %d token requests, want the client credentials to be requested again", len(requests))
+	}
+	for _, q := range requests {
+		if q.Get("grant_type") != "client_credentials" || q.Get("scope") != "ZohoCRM.modules.ALL,ZohoCRM.settings.READ" || q.Get("soid") != "ZohoCRM.123" || q.Get("client_secret") != "secret" {
+			t.Errorf("token request %v", q)
+		}
+	}
+	if token := z.token(); token.RefreshToken != "" || !token.ExpiresAt.After(time.Now()) {
+		t.Errorf("held token %+v", token)
+	}
+}
@@ -5,5 +5,5 @@ go 1.13
 require (
 	github.com/hashicorp/go-retryablehttp v0.7.0
 	github.com/schmorrison/go-querystring v1.1.1
-	google.golang.org/appengine v1.6.6
+	google.golang.org/appengine v1.6.6 // only imported by the datastore package
 )
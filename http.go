@@ -28,6 +28,12 @@ type Endpoint struct {
 	Headers       map[string]string
 	BodyFormat    BodyFormat
 	Attachment    string
+
+	// AttachmentReader is used as the file contents of a FILE body instead of opening Attachment,
+	// in which case Attachment is only used for the file name
+	AttachmentReader io.Reader
+	// AttachmentField is the name of the multipart form field for a FILE body, by default 'attachment'
+	AttachmentField string
 }
 
 // Parameter is used to provide URL Parameters to zoho endpoints
@@ -39,6 +45,7 @@ const (
 	JSON_STRING = "jsonString"
 	FILE        = "file"
 	URL         = "url" // Added new BodyFormat option
+	FORM        = "form"
 )
 
 // HTTPRequest is the function which actually performs the request to a Zoho endpoint as specified by the provided endpoint
@@ -47,13 +54,92 @@ func (z *Zoho) HTTPRequest(endpoint *Endpoint) (err error) {
 		return fmt.Errorf("Failed, you must pass a pointer in the ResponseData field of endpoint")
 	}
 
-	// Load and renew access token if expired
-	err = z.CheckForSavedTokens()
-	if err == ErrTokenExpired {
-		err := z.RefreshTokenRequest()
+	req, err := z.newRequest(endpoint)
+	if err != nil {
+		return err
+	}
+
+	_, err = z.chain(z.doRequest)(endpoint, req)
+	return err
+}
+
+// doRequest is the Handler of HTTPRequest, it performs the request and decodes the response
+func (z *Zoho) doRequest(endpoint *Endpoint, req *http.Request) (*http.Response, error) {
+	resp, err := z.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to perform request for %s: %s", endpoint.Name, err)
+	}
+
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return resp, fmt.Errorf("Failed to read body of response for %s: got status %s: %s", endpoint.Name, resolveStatus(resp), err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	dataType := reflect.TypeOf(endpoint.ResponseData).Elem()
+	data := reflect.New(dataType).Interface()
+
+	if len(body) > 0 { // Avoid failed to unmarshal if there is no result
+		err = json.Unmarshal(body, data)
 		if err != nil {
-			return fmt.Errorf("Failed to refresh the access token: %s: %s", endpoint.Name, err)
+			return resp, fmt.Errorf("Failed to unmarshal data from response for %s: got status %s: %s", endpoint.Name, resolveStatus(resp), err)
 		}
+
+		// Search for hidden errors (appears on success response)
+		if bytes.Contains(body, []byte(`"status":"error"`)) {
+			return resp, fmt.Errorf("%s", string(body))
+		}
+	}
+
+	endpoint.ResponseData = data
+
+	return resp, nil
+}
+
+// HTTPStreamRequest performs the request specified by the endpoint like HTTPRequest, but the response body
+// is not decoded. It is used for endpoints that return files rather than JSON. The caller must close the
+// body of the returned response. Responses without a 2xx status code are returned as an error.
+func (z *Zoho) HTTPStreamRequest(endpoint *Endpoint) (*http.Response, error) {
+	req, err := z.newRequest(endpoint)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := z.chain(z.doStreamRequest)(endpoint, req)
+	if err != nil {
+		return nil, err
+	}
+	if resp == nil {
+		return nil, fmt.Errorf("Failed to perform request for %s: no response was returned by the middleware", endpoint.Name)
+	}
+	return resp, nil
+}
+
+// doStreamRequest is the Handler of HTTPStreamRequest, the body of the response returned is not read
+func (z *Zoho) doStreamRequest(endpoint *Endpoint, req *http.Request) (*http.Response, error) {
+	resp, err := z.client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to perform request for %s: %s", endpoint.Name, err)
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		defer resp.Body.Close()
+		body, _ := ioutil.ReadAll(resp.Body)
+		resp.Body = ioutil.NopCloser(bytes.NewReader(body))
+		return resp, fmt.Errorf("Failed to perform request for %s: got status %s: %s", endpoint.Name, resp.Status, string(body))
+	}
+
+	return resp, nil
+}
+
+// newRequest ensures a valid access token is held, then builds the *http.Request for the endpoint
+func (z *Zoho) newRequest(endpoint *Endpoint) (*http.Request, error) {
+	// Load and renew access token if expiring
+	accessToken, err := z.accessToken()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to refresh the access token: %s: %s", endpoint.Name, err)
 	}
 
 	// Retrieve URL parameters
@@ -77,7 +163,7 @@ func (z *Zoho) HTTPRequest(endpoint *Endpoint) (err error) {
 			// JSON Marshal the body
 			marshalledBody, err := json.Marshal(endpoint.RequestBody)
 			if err != nil {
-				return fmt.Errorf("Failed to create json from request body: %s", err)
+				return nil, fmt.Errorf("Failed to create json from request body: %s", err)
 			}
 
 			reqBody = bytes.NewReader(marshalledBody)
@@ -85,7 +171,7 @@ func (z *Zoho) HTTPRequest(endpoint *Endpoint) (err error) {
 		contentType = "application/json; charset=UTF-8"
 	}
 
-	if endpoint.BodyFormat == JSON_STRING || endpoint.BodyFormat == FILE {
+	if endpoint.BodyFormat == JSON_STRING || endpoint.BodyFormat == FILE || endpoint.BodyFormat == FORM {
 		// Create a multipart form
 		var b bytes.Buffer
 		w := multipart.NewWriter(&b)
@@ -95,39 +181,65 @@ func (z *Zoho) HTTPRequest(endpoint *Endpoint) (err error) {
 			// Use the form to create the proper field
 			fw, err := w.CreateFormField("JSONString")
 			if err != nil {
-				return err
+				return nil, err
 			}
 			// Copy the request body JSON into the field
 			if _, err = io.Copy(fw, reqBody); err != nil {
-				return err
+				return nil, err
 			}
 
 			// Close the multipart writer to set the terminating boundary
 			err = w.Close()
 			if err != nil {
-				return err
+				return nil, err
 			}
 
 		case FILE:
-			// Retreive the file contents
-			fileReader, err := os.Open(endpoint.Attachment)
-			if err != nil {
-				return err
+			// Retreive the file contents, either from the provided reader or the file path
+			fileReader := endpoint.AttachmentReader
+			if fileReader == nil {
+				file, err := os.Open(endpoint.Attachment)
+				if err != nil {
+					return nil, err
+				}
+				defer file.Close()
+				fileReader = file
+			}
+
+			fieldName := endpoint.AttachmentField
+			if fieldName == "" {
+				fieldName = "attachment"
 			}
-			defer fileReader.Close()
 			// Create the correct form field
-			part, err := w.CreateFormFile("attachment", filepath.Base(endpoint.Attachment))
+			part, err := w.CreateFormFile(fieldName, filepath.Base(endpoint.Attachment))
 			if err != nil {
-				return err
+				return nil, err
 			}
 			// copy the file contents to the form
 			if _, err = io.Copy(part, fileReader); err != nil {
-				return err
+				return nil, err
 			}
 
 			err = w.Close()
 			if err != nil {
-				return err
+				return nil, err
+			}
+
+		case FORM:
+			// Write each value of the request body as a form field
+			fields, ok := endpoint.RequestBody.(map[string]string)
+			if !ok {
+				return nil, fmt.Errorf("Failed, the RequestBody of a FORM endpoint must be a map[string]string")
+			}
+			for k, v := range fields {
+				if err := w.WriteField(k, v); err != nil {
+					return nil, err
+				}
+			}
+
+			err := w.Close()
+			if err != nil {
+				return nil, err
 			}
 		}
 
@@ -139,7 +251,7 @@ func (z *Zoho) HTTPRequest(endpoint *Endpoint) (err error) {
 	if endpoint.BodyFormat == URL {
 		body, err := query.Values(endpoint.RequestBody) // send struct into the newly imported package
 		if err != nil {
-			return err
+			return nil, err
 		}
 
 		reqBody = strings.NewReader(body.Encode()) // write to body
@@ -148,49 +260,24 @@ func (z *Zoho) HTTPRequest(endpoint *Endpoint) (err error) {
 
 	req, err = http.NewRequest(string(endpoint.Method), fmt.Sprintf("%s?%s", endpointURL, q.Encode()), reqBody)
 	if err != nil {
-		return fmt.Errorf("Failed to create a request for %s: %s", endpoint.Name, err)
+		return nil, fmt.Errorf("Failed to create a request for %s: %s", endpoint.Name, err)
 	}
 
 	req.Header.Set("Content-Type", contentType)
 
-	// Add global authorization header
-	req.Header.Add("Authorization", "Zoho-oauthtoken "+z.oauth.token.AccessToken)
+	// Add global authorization header, requests authorized otherwise (eg. by an API key) may have no token
+	if accessToken != "" {
+		req.Header.Add("Authorization", "Zoho-oauthtoken "+accessToken)
+	}
 
 	// Add specific endpoint headers
 	for k, v := range endpoint.Headers {
 		req.Header.Add(k, v)
 	}
 
-	resp, err := z.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("Failed to perform request for %s: %s", endpoint.Name, err)
-	}
-
-	defer resp.Body.Close()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf("Failed to read body of response for %s: got status %s: %s", endpoint.Name, resolveStatus(resp), err)
-	}
-
-	dataType := reflect.TypeOf(endpoint.ResponseData).Elem()
-	data := reflect.New(dataType).Interface()
-
-	if len(body) > 0 { // Avoid failed to unmarshal if there is no result
-		err = json.Unmarshal(body, data)
-		if err != nil {
-			return fmt.Errorf("Failed to unmarshal data from response for %s: got status %s: %s", endpoint.Name, resolveStatus(resp), err)
-		}
-
-		// Search for hidden errors (appears on success response)
-		if bytes.Contains(body, []byte(`"status":"error"`)) {
-			return fmt.Errorf("%s", string(body))
-		}
-	}
-
-	endpoint.ResponseData = data
+	z.rewriteRequest(req)
 
-	return nil
+	return req, nil
 }
 
 // HTTPStatusCode is a type for resolving the returned HTTP Status Code Content
@@ -251,4 +338,6 @@ const (
 	HTTPPut HTTPMethod = "PUT"
 	// HTTPDelete is the DELETE method for http requests
 	HTTPDelete HTTPMethod = "DELETE"
+	// HTTPPatch is the PATCH method for http requests
+	HTTPPatch HTTPMethod = "PATCH"
 )
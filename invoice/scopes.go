@@ -0,0 +1,35 @@
+package invoice
+
+import (
+	zoho "github.com/schmorrison/Zoho"
+)
+
+var methodScopes = zoho.MethodScopes{
+	"CreateContact":          {"ZohoInvoice.contacts.CREATE"},
+	"GetContact":             {"ZohoInvoice.contacts.READ"},
+	"ListContacts":           {"ZohoInvoice.contacts.READ"},
+	"UpdateContact":          {"ZohoInvoice.contacts.UPDATE"},
+	"CreateContactPerson":    {"ZohoInvoice.contacts.CREATE"},
+	"ListContactPersons":     {"ZohoInvoice.contacts.READ"},
+	"DeleteContactPerson":    {"ZohoInvoice.contacts.DELETE"},
+	"CreateInvoice":          {"ZohoInvoice.invoices.CREATE"},
+	"GetInvoice":             {"ZohoInvoice.invoices.READ"},
+	"ListInvoices":           {"ZohoInvoice.invoices.READ"},
+	"UpdateInvoice":          {"ZohoInvoice.invoices.UPDATE"},
+	"CreateRecurringInvoice": {"ZohoInvoice.invoices.CREATE"},
+	"GetRecurringInvoice":    {"ZohoInvoice.invoices.READ"},
+	"ListRecurringInvoices":  {"ZohoInvoice.invoices.READ"},
+	"UpdateRecurringInvoice": {"ZohoInvoice.invoices.UPDATE"},
+	"StopRecurringInvoice":   {"ZohoInvoice.invoices.UPDATE"},
+	"CreateItem":             {"ZohoInvoice.settings.CREATE"},
+	"ListItems":              {"ZohoInvoice.settings.READ"},
+	"CreatePayment":          {"ZohoInvoice.customerpayments.CREATE"},
+	"RetrievePayment":        {"ZohoInvoice.customerpayments.READ"},
+	"ListCustomerPayments":   {"ZohoInvoice.customerpayments.READ"},
+}
+
+// RequiredScopes returns the minimal scopes required by the methods of API, named as in the API, eg.
+// RequiredScopes("CreateInvoice", "ListContacts")
+func RequiredScopes(methods ...string) ([]zoho.ScopeString, error) {
+	return methodScopes.Required(methods...)
+}
@@ -75,6 +75,16 @@ type UpdateContactRequest struct {
 	IsTaxable        string                  `json:"is_taxable,omitempty"`
 	Facebook         string                  `json:"facebook,omitempty"`
 	Twitter          string                  `json:"twitter,omitempty"`
+
+	// NullFields clears contact fields, eg. "website" or "billing_address.street2"
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdateContactRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateContactRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdateContactResponse struct {
@@ -88,6 +88,16 @@ type UpdateInvoiceRequest struct {
 	Reason                string               `json:"reason,omitempty"`
 	TaxAuthorityId        string               `json:"tax_authority_id,omitempty"`
 	TaxExemptionId        string               `json:"tax_exemption_id,omitempty"`
+
+	// NullFields clears invoice fields, eg. "salesperson_id", "notes" or "terms"
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdateInvoiceRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateInvoiceRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdateInvoiceResponse struct {
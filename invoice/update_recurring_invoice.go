@@ -81,6 +81,16 @@ type UpdateRecurringInvoiceRequest struct {
 	PaymentOptions      PaymentOptions       `json:"payment_options,omitempty"`
 	TaxAuthorityId      string               `json:"tax_authority_id,omitempty"`
 	TaxExemptionId      string               `json:"tax_exemption_id,omitempty"`
+
+	// NullFields clears fields of the profile, eg. "end_date" to make it recur indefinitely
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdateRecurringInvoiceRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateRecurringInvoiceRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdateRecurringInvoiceResponse struct {
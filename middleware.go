@@ -0,0 +1,100 @@
+package zoho
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Handler performs the request built for an endpoint. The Handler of HTTPRequest decodes the response into
+// endpoint.ResponseData and returns the response with its body already read, the Body holds a copy of it. The
+// Handler of HTTPStreamRequest returns the response with its body unread, it must not be consumed by middleware.
+// The response may be nil when the request failed.
+type Handler func(endpoint *Endpoint, req *http.Request) (*http.Response, error)
+
+// Middleware wraps the Handler of every request made to an endpoint, by every product package. It may change the
+// request before calling next, eg. to add headers, inspect the response and endpoint.ResponseData after calling
+// next, or return without calling next, eg. after setting endpoint.ResponseData from a cache. The requests of the
+// OAuth flows do not pass through the middleware.
+//
+//    z.Use(func(next zoho.Handler) zoho.Handler {
+//        return func(endpoint *zoho.Endpoint, req *http.Request) (*http.Response, error) {
+//            req.Header.Set("X-Request-ID", newRequestID())
+//            return next(endpoint, req)
+//        }
+//    })
+type Middleware func(next Handler) Handler
+
+// Use appends middleware to the chain around requests, the first middleware registered is the outermost.
+// Middleware should be registered before the *Zoho is used.
+func (z *Zoho) Use(middleware ...Middleware) {
+	z.middlewareMu.Lock()
+	defer z.middlewareMu.Unlock()
+	z.middleware = append(z.middleware, middleware...)
+}
+
+// chain wraps the handler in the middleware registered
+func (z *Zoho) chain(h Handler) Handler {
+	z.middlewareMu.Lock()
+	defer z.middlewareMu.Unlock()
+
+	for i := len(z.middleware) - 1; i >= 0; i-- {
+		h = z.middleware[i](h)
+	}
+	return h
+}
+
+// LogRequests returns a Middleware logging the name, method and URL of each request, its status and duration.
+// Tokens and credentials in the URL are redacted, see RedactURL, and headers are not logged.
+//
+//    z.Use(zoho.LogRequests(log.Printf))
+func LogRequests(logf func(format string, v ...interface{})) Middleware {
+	return func(next Handler) Handler {
+		return func(endpoint *Endpoint, req *http.Request) (*http.Response, error) {
+			start := time.Now()
+			resp, err := next(endpoint, req)
+
+			status := "no response"
+			if resp != nil {
+				status = resp.Status
+			}
+			if err != nil {
+				logf("zoho: %s %s %s: %s in %s: %s", endpoint.Name, req.Method, RedactURL(req.URL), status, time.Since(start), err)
+			} else {
+				logf("zoho: %s %s %s: %s in %s", endpoint.Name, req.Method, RedactURL(req.URL), status, time.Since(start))
+			}
+			return resp, err
+		}
+	}
+}
+
+// redactedParams are the URL parameters holding tokens or credentials
+var redactedParams = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"client_id":     true,
+	"client_secret": true,
+	"code":          true,
+	"token":         true,
+	"zapikey":       true,
+}
+
+// RedactURL returns the URL with the values of the parameters holding tokens or credentials replaced, for logging
+func RedactURL(u *url.URL) string {
+	q := u.Query()
+	redacted := false
+	for k := range q {
+		if redactedParams[strings.ToLower(k)] {
+			q.Set(k, "REDACTED")
+			redacted = true
+		}
+	}
+	if !redacted {
+		return u.String()
+	}
+
+	c := *u
+	c.RawQuery = q.Encode()
+	return c.String()
+}
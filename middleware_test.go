@@ -0,0 +1,118 @@
+package zoho
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestUseOrder(t *testing.T) {
+	var header string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		header = r.Header.Get("X-Order")
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	z := New()
+	z.CustomHTTPClient(srv.Client())
+	if err := z.SetBaseURL(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	z.SetTokenManager(EnvStore{RefreshTokenVar: "ZOHO_TEST_UNSET", AccessTokenVar: "ZOHO_TEST_UNSET"})
+
+	var calls []string
+	trace := func(name string) Middleware {
+		return func(next Handler) Handler {
+			return func(endpoint *Endpoint, req *http.Request) (*http.Response, error) {
+				calls = append(calls, name+" before")
+				req.Header.Set("X-Order", req.Header.Get("X-Order")+name)
+				resp, err := next(endpoint, req)
+				calls = append(calls, name+" after")
+				return resp, err
+			}
+		}
+	}
+	z.Use(trace("a"), trace("b"))
+	z.Use(trace("c"))
+
+	var data map[string]interface{}
+	if err := z.HTTPRequest(&Endpoint{Name: "records", URL: "https://www.zohoapis.com/crm/v2/Leads", Method: HTTPGet, ResponseData: &data}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{"a before", "b before", "c before", "c after", "b after", "a after"}
+	if !reflect.DeepEqual(calls, want) {
+		t.Errorf("middleware called in order %v, want %v", calls, want)
+	}
+	if header != "abc" {
+		t.Errorf("server received header %q, want the changes of every middleware in order", header)
+	}
+}
+
+func TestLogRequests(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	z := New()
+	z.CustomHTTPClient(srv.Client())
+	if err := z.SetBaseURL(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	z.SetTokenManager(EnvStore{RefreshTokenVar: "ZOHO_TEST_UNSET", AccessTokenVar: "ZOHO_TEST_UNSET"})
+
+	var logged []string
+	z.Use(LogRequests(func(format string, v ...interface{}) {
+		logged = append(logged, fmt.Sprintf(format, v...))
+	}))
+
+	var data map[string]interface{}
+	err := z.HTTPRequest(&Endpoint{
+		Name:          "records",
+		URL:           "https://www.zohoapis.com/crm/v2/Leads",
+		Method:        HTTPGet,
+		ResponseData:  &data,
+		URLParameters: map[string]Parameter{"access_token": "secret", "fields": "Last_Name"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(logged) != 1 {
+		t.Fatalf("logged %q, want one line", logged)
+	}
+	if line := logged[0]; !strings.HasPrefix(line, "zoho: records GET ") || !strings.Contains(line, "200 OK") || strings.Contains(line, "secret") || !strings.Contains(line, "fields=Last_Name") {
+		t.Errorf("logged %q", line)
+	}
+}
+
+func TestRedactURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://accounts.zoho.com/oauth/v2/token?access_token=a&refresh_token=r&client_secret=s", "https://accounts.zoho.com/oauth/v2/token?access_token=REDACTED&client_secret=REDACTED&refresh_token=REDACTED"},
+		{"https://accounts.zoho.com/oauth/v2/token?client_id=i&code=c&grant_type=authorization_code", "https://accounts.zoho.com/oauth/v2/token?client_id=REDACTED&code=REDACTED&grant_type=authorization_code"},
+		{"https://accounts.zoho.com/oauth/v2/token/revoke?Token=t", "https://accounts.zoho.com/oauth/v2/token/revoke?Token=REDACTED"},
+		{"https://www.zohoapis.com/crm/v2/functions/f/actions/execute?auth_type=apikey&zapikey=k", "https://www.zohoapis.com/crm/v2/functions/f/actions/execute?auth_type=apikey&zapikey=REDACTED"},
+		{"https://www.zohoapis.com/crm/v2/Leads?fields=Last_Name&page=2", "https://www.zohoapis.com/crm/v2/Leads?fields=Last_Name&page=2"},
+	}
+
+	for _, tt := range tests {
+		u, err := url.Parse(tt.url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := RedactURL(u); got != tt.want {
+			t.Errorf("RedactURL(%s) = %s, want %s", tt.url, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,66 @@
+package zoho
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// NullFields is a field mask of the JSON names of fields that should be sent as null, to clear the
+// field in Zoho. The request types of the update methods use 'omitempty', so a field that has not been
+// set can not be told apart from one that should be emptied. Update request types have a NullFields
+// field for this purpose, and other values (eg. the records provided to crm.UpdateRecords) can be
+// wrapped with WithNulls.
+//
+// Nested fields are named with a dot, eg. "billing_address.street2".
+type NullFields []string
+
+// WithNulls wraps v so that it is marshalled with the provided fields set to null
+//
+//    crm.UpdateRecordsData{Data: []interface{}{zoho.WithNulls(account, "Phone", "Website")}}
+func WithNulls(v interface{}, fields ...string) json.Marshaler {
+	return withNulls{value: v, nulls: fields}
+}
+
+type withNulls struct {
+	value interface{}
+	nulls NullFields
+}
+
+func (w withNulls) MarshalJSON() ([]byte, error) {
+	return MarshalWithNulls(w.value, w.nulls)
+}
+
+// MarshalWithNulls marshals v, which must marshal to a JSON object, and then sets each of the fields
+// in nulls to null. It is used to implement json.Marshaler on the update request types.
+func MarshalWithNulls(v interface{}, nulls NullFields) ([]byte, error) {
+	b, err := json.Marshal(v)
+	if err != nil || len(nulls) == 0 {
+		return b, err
+	}
+
+	// decode numbers as json.Number, so large IDs are not rounded
+	obj := map[string]interface{}{}
+	dec := json.NewDecoder(bytes.NewReader(b))
+	dec.UseNumber()
+	if err := dec.Decode(&obj); err != nil {
+		return nil, fmt.Errorf("Failed to set null fields, value is not a JSON object: %s", err)
+	}
+
+	for _, field := range nulls {
+		path := strings.Split(field, ".")
+		parent := obj
+		for _, p := range path[:len(path)-1] {
+			child, ok := parent[p].(map[string]interface{})
+			if !ok {
+				child = map[string]interface{}{}
+				parent[p] = child
+			}
+			parent = child
+		}
+		parent[path[len(path)-1]] = nil
+	}
+
+	return json.Marshal(obj)
+}
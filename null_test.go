@@ -0,0 +1,61 @@
+package zoho
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestMarshalWithNulls(t *testing.T) {
+	type address struct {
+		Street  string `json:"street,omitempty"`
+		Street2 string `json:"street2,omitempty"`
+	}
+	type contact struct {
+		ID      int64    `json:"contact_id,omitempty"`
+		Name    string   `json:"contact_name,omitempty"`
+		Phone   string   `json:"phone,omitempty"`
+		Billing *address `json:"billing_address,omitempty"`
+	}
+
+	tests := []struct {
+		value contact
+		nulls NullFields
+		want  string
+	}{
+		{contact{Name: "Acme"}, nil, `{"contact_name":"Acme"}`},
+		{contact{Name: "Acme"}, NullFields{"phone"}, `{"contact_name":"Acme","phone":null}`},
+		{
+			contact{ID: 460000000026049, Billing: &address{Street: "Main St"}},
+			NullFields{"billing_address.street2"},
+			`{"billing_address":{"street":"Main St","street2":null},"contact_id":460000000026049}`,
+		},
+		{contact{}, NullFields{"billing_address.street2"}, `{"billing_address":{"street2":null}}`},
+		{contact{ID: 9007199254740993}, NullFields{"phone"}, `{"contact_id":9007199254740993,"phone":null}`},
+	}
+
+	for _, tt := range tests {
+		b, err := MarshalWithNulls(tt.value, tt.nulls)
+		if err != nil {
+			t.Errorf("MarshalWithNulls(%+v, %v) returned error: %s", tt.value, tt.nulls, err)
+			continue
+		}
+		if string(b) != tt.want {
+			t.Errorf("MarshalWithNulls(%+v, %v) = %s, want %s", tt.value, tt.nulls, b, tt.want)
+		}
+	}
+
+	if _, err := MarshalWithNulls([]string{"a"}, NullFields{"phone"}); err == nil {
+		t.Error("MarshalWithNulls of a value which is not an object did not return an error")
+	}
+}
+
+func TestWithNulls(t *testing.T) {
+	records := []interface{}{WithNulls(map[string]interface{}{"id": "1", "Phone": "555"}, "Phone", "Website")}
+	b, err := json.Marshal(map[string]interface{}{"data": records})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"data":[{"Phone":null,"Website":null,"id":"1"}]}`; string(b) != want {
+		t.Errorf("got %s, want %s", b, want)
+	}
+}
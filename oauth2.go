@@ -13,23 +13,32 @@ import (
 )
 
 func (z *Zoho) SetRefreshToken(refreshToken string) {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
 	z.oauth.token.RefreshToken = refreshToken
 }
 
 // GetRefreshToken is used to obtain the oAuth2 refresh token
 func (z *Zoho) GetRefreshToken() string {
-	return z.oauth.token.RefreshToken
+	return z.token().RefreshToken
 }
 
 func (z *Zoho) SetClientID(clientID string) {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
 	z.oauth.clientID = clientID
 }
 
 func (z *Zoho) SetClientSecret(clientSecret string) {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
 	z.oauth.clientSecret = clientSecret
 }
 
 func (z *Zoho) RefreshTokenURL() string {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
+
 	q := url.Values{}
 	q.Set("client_id", z.oauth.clientID)
 	q.Set("client_secret", z.oauth.clientSecret)
@@ -39,12 +48,28 @@ func (z *Zoho) RefreshTokenURL() string {
 	return fmt.Sprintf("%s%s?%s", z.oauth.baseURL, oauthGenerateTokenRequestSlug, q.Encode())
 }
 
-// RefreshTokenRequest is used to refresh the oAuth2 access token
+// RefreshTokenRequest is used to refresh the oAuth2 access token. It is safe to call concurrently, callers
+// requesting a refresh while one is in progress wait for it and share its result.
 func (z *Zoho) RefreshTokenRequest() (err error) {
-	tokenURL := z.RefreshTokenURL()
-	resp, err := z.client.Post(tokenURL, "application/x-www-form-urlencoded", nil)
+	return z.singleRefresh(false)
+}
+
+func (z *Zoho) refreshTokenRequest() (err error) {
+	z.tokenMu.Lock()
+	clientCredentials := z.oauth.clientCredentials
+	z.tokenMu.Unlock()
+
+	if clientCredentials {
+		return z.postTokenRequest(z.clientCredentialsURL(), "request client credentials token")
+	}
+	return z.postTokenRequest(z.RefreshTokenURL(), "refresh token")
+}
+
+// postTokenRequest posts to the token URL, then holds and saves the tokens returned. The action is used in errors.
+func (z *Zoho) postTokenRequest(tokenURL, action string) (err error) {
+	resp, err := z.postForm(tokenURL)
 	if err != nil {
-		return fmt.Errorf("Failed while requesting refresh token: %s", err)
+		return fmt.Errorf("Failed while requesting %s: %s", action, err)
 	}
 
 	defer func() {
@@ -56,16 +81,16 @@ func (z *Zoho) RefreshTokenRequest() (err error) {
 	body, err := ioutil.ReadAll(resp.Body)
 	if err != nil {
 		return fmt.Errorf(
-			"Failed to read request body on request to %s%s: %s",
-			z.oauth.baseURL,
-			oauthGenerateTokenRequestSlug,
+			"Failed to read request body on request to %s: %s",
+			strings.SplitN(tokenURL, "?", 2)[0],
 			err,
 		)
 	}
 
 	if resp.StatusCode != 200 {
 		return fmt.Errorf(
-			"Got non-200 status code from request to refresh token: %s\n%s",
+			"Got non-200 status code from request to %s: %s\n%s",
+			action,
 			resp.Status,
 			string(body),
 		)
@@ -75,7 +100,8 @@ func (z *Zoho) RefreshTokenRequest() (err error) {
 	err = json.Unmarshal(body, &tokenResponse)
 	if err != nil {
 		return fmt.Errorf(
-			"Failed to unmarshal access token response from request to refresh token: %s",
+			"Failed to unmarshal access token response from request to %s: %s",
+			action,
 			err,
 		)
 	}
@@ -89,12 +115,13 @@ func (z *Zoho) RefreshTokenRequest() (err error) {
 		return ErrClientSecretInvalidCode
 	}
 
-	z.oauth.token.AccessToken = tokenResponse.AccessToken
-	z.oauth.token.APIDomain = tokenResponse.APIDomain
-	z.oauth.token.ExpiresIn = tokenResponse.ExpiresIn
-	z.oauth.token.TokenType = tokenResponse.TokenType
+	if tokenResponse.Error != "" || tokenResponse.AccessToken == "" {
+		return fmt.Errorf("Failed to %s: %s", action, tokenResponse.Error)
+	}
+
+	saved := z.setToken(tokenResponse)
 
-	err = z.SaveTokens(z.oauth.token)
+	err = z.SaveTokens(saved)
 	if err != nil {
 		return fmt.Errorf("Failed to save access tokens: %s", err)
 	}
@@ -103,11 +130,16 @@ func (z *Zoho) RefreshTokenRequest() (err error) {
 }
 
 func (z *Zoho) GenerateTokenURL(code, clientID, clientSecret string) string {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
+
 	q := url.Values{}
 	q.Set("client_id", clientID)
 	q.Set("client_secret", clientSecret)
 	q.Set("code", code)
-	q.Set("redirect_uri", z.oauth.redirectURI)
+	if z.oauth.redirectURI != "" {
+		q.Set("redirect_uri", z.oauth.redirectURI)
+	}
 	q.Set("grant_type", "authorization_code")
 
 	return fmt.Sprintf("%s%s?%s", z.oauth.baseURL, oauthGenerateTokenRequestSlug, q.Encode())
@@ -119,86 +151,43 @@ func (z *Zoho) GenerateTokenURL(code, clientID, clientSecret string) string {
 // to this function which will generate your access token and refresh tokens.
 func (z *Zoho) GenerateTokenRequest(clientID, clientSecret, code, redirectURI string) (err error) {
 
-	z.oauth.clientID = clientID
-	z.oauth.clientSecret = clientSecret
-	z.oauth.redirectURI = redirectURI
+	z.setClient(clientID, clientSecret, redirectURI)
 
 	err = z.CheckForSavedTokens()
 	if err == ErrTokenExpired {
 		return z.RefreshTokenRequest()
 	}
 
-	// q := url.Values{}
-	// q.Set("client_id", clientID)
-	// q.Set("client_secret", clientSecret)
-	// q.Set("code", code)
-	// q.Set("redirect_uri", redirectURI)
-	// q.Set("grant_type", "authorization_code")
-
-	// tokenURL := fmt.Sprintf("%s%s?%s", z.oauth.baseURL, oauthGenerateTokenRequestSlug, q.Encode())
-	tokenURL := z.GenerateTokenURL(code, clientID, clientSecret)
-	resp, err := z.client.Post(tokenURL, "application/x-www-form-urlencoded", nil)
-	if err != nil {
-		return fmt.Errorf("Failed while requesting generate token: %s", err)
-	}
-
-	defer func() {
-		if err := resp.Body.Close(); err != nil {
-			fmt.Printf("Failed to close request body: %s\n", err)
-		}
-	}()
-
-	body, err := ioutil.ReadAll(resp.Body)
-	if err != nil {
-		return fmt.Errorf(
-			"Failed to read request body on request to %s%s: %s",
-			z.oauth.baseURL,
-			oauthGenerateTokenRequestSlug,
-			err,
-		)
-	}
-
-	if resp.StatusCode != 200 {
-		return fmt.Errorf(
-			"Got non-200 status code from request to generate token: %s\n%s",
-			resp.Status,
-			string(body),
-		)
-	}
+	return z.postTokenRequest(z.GenerateTokenURL(code, clientID, clientSecret), "generate token")
+}
 
-	tokenResponse := AccessTokenResponse{}
-	err = json.Unmarshal(body, &tokenResponse)
+// postForm posts to a Zoho Accounts URL without a body
+func (z *Zoho) postForm(rawURL string) (*http.Response, error) {
+	req, err := http.NewRequest(http.MethodPost, rawURL, nil)
 	if err != nil {
-		return fmt.Errorf(
-			"Failed to unmarshal access token response from request to generate token: %s",
-			err,
-		)
-	}
-
-	//If the tokenResponse is not valid it should not update local tokens
-	if tokenResponse.Error == "invalid_code" {
-		return ErrTokenInvalidCode
+		return nil, err
 	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
 
-	//If the tokenResponse is not obtained from proper client secret it should not update local tokens
-	if tokenResponse.Error == "invalid_client_secret" {
-		return ErrClientSecretInvalidCode
-	}
+	z.rewriteRequest(req)
+	return z.client.Do(req)
+}
 
+// setClient sets the client credentials used to generate and refresh tokens
+func (z *Zoho) setClient(clientID, clientSecret, redirectURI string) {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
 	z.oauth.clientID = clientID
 	z.oauth.clientSecret = clientSecret
 	z.oauth.redirectURI = redirectURI
-	z.oauth.token = tokenResponse
-
-	err = z.SaveTokens(z.oauth.token)
-	if err != nil {
-		return fmt.Errorf("Failed to save access tokens: %s", err)
-	}
-
-	return nil
+	z.oauth.clientCredentials = false
 }
 
 func (z *Zoho) AuthorizationCodeURL(scopes, clientID, redirectURI string, consent bool) string {
+	return z.authorizationCodeURL(scopes, clientID, redirectURI, "", consent)
+}
+
+func (z *Zoho) authorizationCodeURL(scopes, clientID, redirectURI, state string, consent bool) string {
 	q := url.Values{}
 	q.Set("scope", scopes)
 	q.Set("client_id", clientID)
@@ -206,19 +195,27 @@ func (z *Zoho) AuthorizationCodeURL(scopes, clientID, redirectURI string, consen
 	q.Set("response_type", "code")
 	q.Set("access_type", "offline")
 
+	if state != "" {
+		q.Set("state", state)
+	}
+
 	if consent {
 		q.Set("prompt", "consent")
 	}
 
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
 	return fmt.Sprintf("%s%s?%s", z.oauth.baseURL, oauthAuthorizationRequestSlug, q.Encode())
 }
 
 // AuthorizationCodeRequest will request an authorization code from Zoho. This authorization code is then used to generate access and refresh tokens.
 // This function will print a link that needs to be pasted into a browser to continue the oAuth2 flow. Then it will redirect to the redirectURL, it
-// must be the same as the redirect URL that was provided to Zoho when generating your client ID and client secret. If the redirect URL was a localhost
-// domain, the function will start a server that will get the code from the URL when the browser redirects.
-// If the domain is not a localhost, you will be prompted to paste the code from the URL back into the terminal window,
+// must be the same as the redirect URL that was provided to Zoho when generating your client ID and client secret. If the redirect URL is on
+// localhost or a loopback address, the function will start a server that will get the code from the URL when the browser redirects.
+// Otherwise, you will be prompted to paste the URL redirected to, or only the code from it, back into the terminal window,
 // eg. https://domain.com/redirect-url?code=xxxxxxxxxx
+//
+// Web applications should use WebFlow instead, which does not read from the terminal.
 func (z *Zoho) AuthorizationCodeRequest(
 	clientID, clientSecret string,
 	scopes []ScopeString,
@@ -227,103 +224,107 @@ func (z *Zoho) AuthorizationCodeRequest(
 	// check for existing tokens
 	err = z.CheckForSavedTokens()
 	if err == nil {
-		z.oauth.clientID = clientID
-		z.oauth.clientSecret = clientSecret
-		z.oauth.redirectURI = redirectURI
+		z.setClient(clientID, clientSecret, redirectURI)
+		z.tokenMu.Lock()
 		z.oauth.scopes = scopes
+		z.tokenMu.Unlock()
 		return nil
 	}
 
+	flow := z.WebFlow(clientID, clientSecret, redirectURI, scopes...)
 	// user may be able to issue a refresh if they have a refresh token, but maybe they are trying to get a new token.
-	// a breaking change could be to provide a bool: consent - where the user forces the consent screen otherwise we will try to refresh
-	requiresConsentPrompt := false
-	if err == ErrTokenExpired {
-		// currently we will simply check if the token is expired and if it is we will "prompt=consent"
-		requiresConsentPrompt = true
-	}
+	// currently we will simply check if the token is expired and if it is we will "prompt=consent"
+	flow.Consent = err == ErrTokenExpired
 
-	scopeStr := ""
-	for i, a := range scopes {
-		scopeStr += string(a)
-		if i < len(scopes)-1 {
-			scopeStr += ","
-		}
+	authURL, state, err := flow.Start()
+	if err != nil {
+		return err
 	}
 
-	z.oauth.scopes = scopes
-
-	// q := url.Values{}
-	// q.Set("scope", scopeStr)
-	// q.Set("client_id", clientID)
-	// q.Set("redirect_uri", redirectURI)
-	// q.Set("response_type", "code")
-	// q.Set("access_type", "offline")
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		return fmt.Errorf("Failed to parse redirect URI: %s", err)
+	}
 
-	// authURL := fmt.Sprintf("%s%s?%s", z.oauth.baseURL, oauthAuthorizationRequestSlug, q.Encode())
-	authURL := z.AuthorizationCodeURL(scopeStr, clientID, redirectURI, requiresConsentPrompt)
+	if !isLocalhost(u) {
+		fmt.Printf("Go to the following authentication URL to begin oAuth2 flow:\n %s\n\n", authURL)
+		fmt.Printf("Paste the URL redirected to, or the code, and press enter:\n")
 
-	srvChan := make(chan int)
-	codeChan := make(chan string)
-	var srv *http.Server
+		code := ""
+		if _, err := fmt.Scan(&code); err != nil {
+			return fmt.Errorf("Failed to read code from input: %s", err)
+		}
 
-	localRedirect := strings.Contains(redirectURI, "localhost")
-	if localRedirect {
-		// start a localhost server that will handle the redirect url
-		u, err := url.Parse(redirectURI)
-		if err != nil {
-			return fmt.Errorf("Failed to parse redirect URI: %s", err)
+		if pasted, perr := url.Parse(code); perr == nil && pasted.Query().Get("code") != "" {
+			if _, err := flow.Exchange(pasted.Query(), state); err != nil {
+				return fmt.Errorf("Failed to retrieve oAuth2 token: %s", err)
+			}
+			return nil
 		}
-		_, port, err := net.SplitHostPort(u.Host)
-		if err != nil {
-			return fmt.Errorf("Failed to split redirect URI into host and port segments: %s", err)
+		if code == "" {
+			return fmt.Errorf("No code was recieved from oAuth2 flow")
 		}
-		srv = &http.Server{Addr: ":" + port}
-
-		http.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
-			w.Write([]byte("Code retrieved, you can close this window to continue"))
-
-			codeChan <- r.URL.Query().Get("code")
-		})
+		if err := z.GenerateTokenRequest(clientID, clientSecret, code, redirectURI); err != nil {
+			return fmt.Errorf("Failed to retrieve oAuth2 token: %s", err)
+		}
+		return nil
+	}
 
-		go func() {
-			srvChan <- 1
-			err := srv.ListenAndServe()
-			if err != nil && err != http.ErrServerClosed {
-				fmt.Printf("Error while serving locally: %s\n", err)
-			}
-		}()
+	// start a localhost server that will handle the redirect url, on its own ServeMux so that it can be
+	// started more than once
+	port := u.Port()
+	if port == "" {
+		port = "80"
+		if u.Scheme == "https" {
+			port = "443"
+		}
+	}
+	listener, err := net.Listen("tcp", net.JoinHostPort(u.Hostname(), port))
+	if err != nil {
+		return fmt.Errorf("Failed to listen on redirect URI: %s", err)
+	}
 
-		<-srvChan
+	path := u.Path
+	if path == "" {
+		path = "/"
 	}
 
-	fmt.Printf("Go to the following authentication URL to begin oAuth2 flow:\n %s\n\n", authURL)
+	queryChan := make(chan url.Values, 1)
+	mux := http.NewServeMux()
+	mux.HandleFunc(path, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		if q.Get("code") == "" && q.Get("error") == "" {
+			http.NotFound(w, r)
+			return
+		}
 
-	code := ""
-
-	if localRedirect {
-		// wait for code to be returned by the server
-		code = <-codeChan
-		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
-		defer func() {
-			cancel()
-		}()
-		if err := srv.Shutdown(ctx); err != nil {
-			fmt.Printf("Error while shutting down local server: %s\n", err)
+		select {
+		case queryChan <- q:
+			w.Write([]byte("Code retrieved, you can close this window to continue"))
+		default:
+			w.Write([]byte("Code was already retrieved, you can close this window"))
 		}
-	} else {
-		fmt.Printf("Paste code and press enter:\n")
-		_, err := fmt.Scan(&code)
-		if err != nil {
-			return fmt.Errorf("Failed to read code from input: %s", err)
+	})
+
+	srv := &http.Server{Handler: mux}
+	go func() {
+		err := srv.Serve(listener)
+		if err != nil && err != http.ErrServerClosed {
+			fmt.Printf("Error while serving locally: %s\n", err)
 		}
-	}
+	}()
+
+	fmt.Printf("Go to the following authentication URL to begin oAuth2 flow:\n %s\n\n", authURL)
 
-	if code == "" {
-		return fmt.Errorf("No code was recieved from oAuth2 flow")
+	// wait for code to be returned by the server
+	query := <-queryChan
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := srv.Shutdown(ctx); err != nil {
+		fmt.Printf("Error while shutting down local server: %s\n", err)
 	}
 
-	err = z.GenerateTokenRequest(clientID, clientSecret, code, redirectURI)
-	if err != nil {
+	if _, err := flow.Exchange(query, state); err != nil {
 		return fmt.Errorf("Failed to retrieve oAuth2 token: %s", err)
 	}
 
@@ -338,6 +339,13 @@ type AccessTokenResponse struct {
 	APIDomain    string `json:"api_domain,omitempty"`
 	TokenType    string `json:"token_type,omitempty"`
 	Error        string `json:"error,omitempty"`
+
+	// Scope is the scopes granted to the token separated by spaces, see GrantedScopes
+	Scope string `json:"scope,omitempty"`
+
+	// ExpiresAt is when the access token expires, it is set when the token is received. It is the zero
+	// time when the expiry is unknown.
+	ExpiresAt time.Time `json:"expires_at"`
 }
 
 const (
@@ -0,0 +1,328 @@
+package zoho
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+// Tenant is the configuration of a Zoho account connected by a Pool
+type Tenant struct {
+	ClientID     string
+	ClientSecret string
+	RefreshToken string
+
+	// ZohoTLD is the data center of the account, eg. "com", "eu" or "in"
+	ZohoTLD string
+
+	// OrganizationID is set as the OrganizationID of the tenant's *Zoho, OrganizationIDs holds the IDs of
+	// products using different organizations, keyed by product, see Pool.OrganizationID
+	OrganizationID  string
+	OrganizationIDs map[string]string
+
+	// TokenStore saves the tokens of the tenant, when nil Pool.NewTokenStore is used
+	TokenStore TokenLoaderSaver
+
+	// RateLimit and Burst override the rate limit of the Pool for the tenant
+	RateLimit float64
+	Burst     int
+}
+
+// Pool holds a *Zoho per tenant, keyed by a name chosen by the caller, so that many Zoho accounts can be used
+// from one process. Every tenant shares the transport of one HTTP client, and requests of each tenant are
+// rate limited independently.
+//
+//    pool := zoho.NewPool()
+//    pool.Resolve = func(key string) (zoho.Tenant, error) { return loadTenant(key) }
+//    z, err := pool.Get("acme")
+//    records, err := crm.New(z).ListRecords(...)
+type Pool struct {
+	// Resolve returns the configuration of a tenant which has not been added, it is optional
+	Resolve func(key string) (Tenant, error)
+
+	// NewTokenStore returns the token store of a tenant without a TokenStore, by default tokens are saved
+	// to a gob file per tenant in TokensDir, which requires the key to only contain letters, digits, '.', '_',
+	// '-' or '@'
+	NewTokenStore func(key string) TokenLoaderSaver
+	TokensDir     string
+
+	// RateLimit is the number of requests per second allowed for each tenant, up to Burst requests at once,
+	// retries of the default client are counted too. Zero is unlimited.
+	RateLimit float64
+	Burst     int
+
+	// Middleware is registered on the *Zoho of every tenant added afterwards, see Zoho.Use
+	Middleware []Middleware
+
+	mu        sync.Mutex
+	client    *http.Client
+	tenants   map[string]*poolTenant
+	resolving map[string]*poolResolve
+}
+
+type poolTenant struct {
+	zoho   *Zoho
+	tenant Tenant
+}
+
+// poolResolve is a call of Resolve in progress, other calls of Get for the key wait for done
+type poolResolve struct {
+	done chan struct{}
+	zoho *Zoho
+	err  error
+}
+
+// tenantKey matches the keys which can be used in the name of a tokens file
+var tenantKey = regexp.MustCompile(`^[A-Za-z0-9._@-]+$`)
+
+// NewPool returns an empty Pool using the same HTTP client configuration as New
+func NewPool() *Pool {
+	return &Pool{
+		TokensDir: ".",
+		client:    defaultHTTPClient(),
+		tenants:   map[string]*poolTenant{},
+		resolving: map[string]*poolResolve{},
+	}
+}
+
+// CustomHTTPClient replaces the HTTP client whose transport is shared by tenants added afterwards
+func (p *Pool) CustomHTTPClient(c *http.Client) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.client = c
+}
+
+// Add configures the tenant under key, replacing a tenant with the same key, and returns its *Zoho
+func (p *Pool) Add(key string, t Tenant) (*Zoho, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.add(key, t)
+}
+
+func (p *Pool) add(key string, t Tenant) (*Zoho, error) {
+	if key == "" {
+		return nil, fmt.Errorf("Failed to add tenant, must provide a key")
+	}
+	if t.TokenStore == nil && p.NewTokenStore == nil && !tenantKey.MatchString(key) {
+		return nil, fmt.Errorf("Failed to add tenant '%s', key can not be used in the name of a tokens file", key)
+	}
+
+	z := newZoho(p.tenantClient(t))
+	if t.ZohoTLD != "" {
+		z.SetZohoTLD(t.ZohoTLD)
+	}
+	z.SetClientID(t.ClientID)
+	z.SetClientSecret(t.ClientSecret)
+	if t.RefreshToken != "" {
+		z.SetRefreshToken(t.RefreshToken)
+	}
+	z.SetOrganizationID(t.OrganizationID)
+	z.Use(p.Middleware...)
+
+	switch {
+	case t.TokenStore != nil:
+		z.SetTokenManager(t.TokenStore)
+	case p.NewTokenStore != nil:
+		z.SetTokenManager(p.NewTokenStore(key))
+	default:
+		z.SetTokensFile(filepath.Join(p.TokensDir, fmt.Sprintf(".tokens.%s.zoho", key)))
+	}
+
+	p.tenants[key] = &poolTenant{zoho: z, tenant: t}
+	return z, nil
+}
+
+// Get returns the *Zoho of the tenant, a tenant which has not been added is added with the configuration
+// returned by Resolve. The same *Zoho is returned for every call, it can be passed to the New function of
+// any product package, eg. crm.New(z). Resolve is called once for concurrent calls with the same key, and
+// does not block calls for other tenants.
+func (p *Pool) Get(key string) (*Zoho, error) {
+	p.mu.Lock()
+	if t, ok := p.tenants[key]; ok {
+		p.mu.Unlock()
+		return t.zoho, nil
+	}
+	if p.Resolve == nil {
+		p.mu.Unlock()
+		return nil, fmt.Errorf("Tenant '%s' was not found", key)
+	}
+	if call, ok := p.resolving[key]; ok {
+		p.mu.Unlock()
+		<-call.done
+		return call.zoho, call.err
+	}
+	call := &poolResolve{done: make(chan struct{})}
+	p.resolving[key] = call
+	p.mu.Unlock()
+
+	t, err := p.Resolve(key)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.resolving, key)
+	defer close(call.done)
+
+	switch existing, ok := p.tenants[key]; {
+	case ok:
+		// the tenant was added while resolving
+		call.zoho = existing.zoho
+	case err != nil:
+		call.err = fmt.Errorf("Failed to resolve tenant '%s': %s", key, err)
+	default:
+		call.zoho, call.err = p.add(key, t)
+	}
+	return call.zoho, call.err
+}
+
+// OrganizationID returns the organization ID of the tenant for the product, eg. "subscriptions", falling back
+// to the OrganizationID of the tenant
+func (p *Pool) OrganizationID(key, product string) (string, error) {
+	if _, err := p.Get(key); err != nil {
+		return "", err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pt, ok := p.tenants[key]
+	if !ok {
+		return "", fmt.Errorf("Tenant '%s' was not found", key)
+	}
+	t := pt.tenant
+	if id, ok := t.OrganizationIDs[product]; ok {
+		return id, nil
+	}
+	return t.OrganizationID, nil
+}
+
+// Remove removes the tenant from the pool, its saved tokens are kept
+func (p *Pool) Remove(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	delete(p.tenants, key)
+}
+
+// Keys returns the keys of the tenants in the pool
+func (p *Pool) Keys() []string {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	keys := make([]string, 0, len(p.tenants))
+	for k := range p.tenants {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// tenantClient returns a client sharing the transport of the pool's client, limited to the rate of the tenant.
+// When the pool's client retries requests with go-retryablehttp, as the default client does, the limit is
+// applied to the transport used by the retries, so each attempt waits for the limiter.
+func (p *Pool) tenantClient(t Tenant) *http.Client {
+	rate, burst := p.RateLimit, p.Burst
+	if t.RateLimit > 0 {
+		rate, burst = t.RateLimit, t.Burst
+	}
+
+	c := *p.client
+	if rate > 0 {
+		limiter := newRateLimiter(rate, burst)
+		if rt, ok := c.Transport.(*retryablehttp.RoundTripper); ok && rt.Client != nil {
+			c.Transport = &retryablehttp.RoundTripper{Client: rateLimitedRetryClient(rt.Client, limiter)}
+		} else {
+			c.Transport = &rateLimitedTransport{base: orDefaultTransport(c.Transport), limiter: limiter}
+		}
+	}
+	return &c
+}
+
+// rateLimitedRetryClient returns a copy of the retrying client whose HTTP client waits for the limiter
+func rateLimitedRetryClient(rc *retryablehttp.Client, limiter *rateLimiter) *retryablehttp.Client {
+	inner := http.Client{}
+	if rc.HTTPClient != nil {
+		inner = *rc.HTTPClient
+	}
+	inner.Transport = &rateLimitedTransport{base: orDefaultTransport(inner.Transport), limiter: limiter}
+
+	return &retryablehttp.Client{
+		HTTPClient:      &inner,
+		Logger:          rc.Logger,
+		RetryWaitMin:    rc.RetryWaitMin,
+		RetryWaitMax:    rc.RetryWaitMax,
+		RetryMax:        rc.RetryMax,
+		RequestLogHook:  rc.RequestLogHook,
+		ResponseLogHook: rc.ResponseLogHook,
+		CheckRetry:      rc.CheckRetry,
+		Backoff:         rc.Backoff,
+		ErrorHandler:    rc.ErrorHandler,
+	}
+}
+
+func orDefaultTransport(rt http.RoundTripper) http.RoundTripper {
+	if rt == nil {
+		return http.DefaultTransport
+	}
+	return rt
+}
+
+// rateLimitedTransport waits for the limiter before each request
+type rateLimitedTransport struct {
+	base    http.RoundTripper
+	limiter *rateLimiter
+}
+
+func (t *rateLimitedTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if err := t.limiter.wait(req.Context()); err != nil {
+		return nil, err
+	}
+	return t.base.RoundTrip(req)
+}
+
+// rateLimiter is a token bucket filled at rate tokens per second, holding at most burst tokens
+type rateLimiter struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newRateLimiter(rate float64, burst int) *rateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	return &rateLimiter{rate: rate, burst: float64(burst), tokens: float64(burst), last: time.Now()}
+}
+
+// wait blocks until a token is taken from the bucket or the context is done
+func (l *rateLimiter) wait(ctx context.Context) error {
+	for {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return nil
+		}
+		delay := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
@@ -0,0 +1,119 @@
+package zoho
+
+import (
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+)
+
+func TestPoolTokensFileKey(t *testing.T) {
+	p := NewPool()
+	p.TokensDir = "/nonexistent"
+
+	for _, key := range []string{"/../../tmp/x", "../x", `a\b`, "acme corp"} {
+		if _, err := p.Add(key, Tenant{}); err == nil {
+			t.Errorf("Add(%q) did not return an error", key)
+		}
+	}
+	for _, key := range []string{"acme", "acme-2.eu", "owner@acme.com"} {
+		if _, err := p.Add(key, Tenant{}); err != nil {
+			t.Errorf("Add(%q) returned error: %s", key, err)
+		}
+	}
+
+	// keys are not used in file names when the tenant has a token store
+	if _, err := p.Add("../x", Tenant{TokenStore: &EnvStore{}}); err != nil {
+		t.Errorf("Add with a TokenStore returned error: %s", err)
+	}
+}
+
+func TestPoolGetResolvesOnce(t *testing.T) {
+	p := NewPool()
+	p.TokensDir = "/nonexistent"
+
+	var mu sync.Mutex
+	calls := map[string]int{}
+	release := make(chan struct{})
+	p.Resolve = func(key string) (Tenant, error) {
+		mu.Lock()
+		calls[key]++
+		mu.Unlock()
+		if key == "slow" {
+			<-release
+		}
+		if key == "missing" {
+			return Tenant{}, fmt.Errorf("not found")
+		}
+		return Tenant{ClientID: key}, nil
+	}
+
+	var wg sync.WaitGroup
+	results := make([]*Zoho, 5)
+	for i := range results {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			z, err := p.Get("slow")
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = z
+		}(i)
+	}
+
+	// other tenants are not blocked by the slow Resolve
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		if _, err := p.Get("fast"); err != nil {
+			t.Error(err)
+		}
+		if _, err := p.Get("missing"); err == nil {
+			t.Error("Get did not return the error of Resolve")
+		}
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Get of another tenant waited for Resolve")
+	}
+
+	time.Sleep(10 * time.Millisecond)
+	close(release)
+	wg.Wait()
+
+	for _, z := range results {
+		if z == nil || z != results[0] {
+			t.Fatal("Get returned different clients for the same tenant")
+		}
+	}
+	if calls["slow"] != 1 {
+		t.Errorf("Resolve was called %d times for the same tenant", calls["slow"])
+	}
+}
+
+func TestPoolRateLimitsRetries(t *testing.T) {
+	p := NewPool()
+	p.RateLimit = 1
+	p.TokensDir = "/nonexistent"
+
+	z, err := p.Add("acme", Tenant{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rt, ok := z.client.Transport.(*retryablehttp.RoundTripper)
+	if !ok {
+		t.Fatalf("transport is %T, want the retrying transport", z.client.Transport)
+	}
+	if _, ok := rt.Client.HTTPClient.Transport.(*rateLimitedTransport); !ok {
+		t.Errorf("transport of the retrying client is %T, want a rate limited transport", rt.Client.HTTPClient.Transport)
+	}
+
+	shared := p.client.Transport.(*retryablehttp.RoundTripper).Client.HTTPClient.Transport
+	if rt.Client.HTTPClient.Transport.(*rateLimitedTransport).base != shared {
+		t.Error("tenant does not share the transport of the pool")
+	}
+}
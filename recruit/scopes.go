@@ -0,0 +1,73 @@
+package recruit
+
+import (
+	zoho "github.com/schmorrison/Zoho"
+)
+
+var methodScopes = zoho.MethodScopes{
+	// candidates.go
+	"InsertCandidates":           {"ZohoRecruit.modules.candidates.CREATE"},
+	"UpsertCandidates":           {"ZohoRecruit.modules.candidates.CREATE", "ZohoRecruit.modules.candidates.UPDATE"},
+	"GetCandidates":              {"ZohoRecruit.modules.candidates.READ"},
+	"GetCandidateById":           {"ZohoRecruit.modules.candidates.READ"},
+	"GetCandidateRelatedRecords": {"ZohoRecruit.modules.ALL"},
+	"DeleteCandidateById":        {"ZohoRecruit.modules.candidates.DELETE"},
+	"DeleteCandidatesByIds":      {"ZohoRecruit.modules.candidates.DELETE"},
+	"ListDeletedCandidates":      {"ZohoRecruit.modules.candidates.READ"},
+	"AssociateCandidates":        {"ZohoRecruit.modules.candidates.UPDATE"},
+
+	// clients.go, contacts.go and interviews.go
+	"GetClientsRecords":       {"ZohoRecruit.modules.clients.READ"},
+	"GetClientsRecordById":    {"ZohoRecruit.modules.clients.READ"},
+	"GetContactsRecords":      {"ZohoRecruit.modules.contacts.READ"},
+	"GetContactsRecordById":   {"ZohoRecruit.modules.contacts.READ"},
+	"GetInterviewsRecords":    {"ZohoRecruit.modules.interviews.READ"},
+	"GetInterviewsRecordById": {"ZohoRecruit.modules.interviews.READ"},
+
+	// filesandattachments.go
+	"UploadAttachment": {"ZohoRecruit.modules.ALL"},
+
+	// jobopenings.go
+	"GetJobOpenings":          {"ZohoRecruit.modules.ALL"},
+	"GetJobOpeningsById":      {"ZohoRecruit.modules.ALL"},
+	"SearchJobOpenings":       {"ZohoRecruit.modules.ALL"},
+	"GetAssociatedCandidates": {"ZohoRecruit.modules.ALL"},
+	"XMLSearchJobOpenings":    {"ZohoRecruit.modules.ALL"},
+	"XMLgetRecordById":        {"ZohoRecruit.modules.ALL"},
+	"XMLGetRecords":           {"ZohoRecruit.modules.ALL"},
+
+	// metadata.go
+	"GetAllMetadata":         {"ZohoRecruit.settings.modules.READ"},
+	"GetModuleMetadata":      {"ZohoRecruit.settings.modules.READ"},
+	"GetFieldsMetadata":      {"ZohoRecruit.settings.fields.READ"},
+	"GetCustomViewsMetadata": {"ZohoRecruit.settings.custom_views.READ"},
+
+	// notes.go and organization.go
+	"GetNotes":               {"ZohoRecruit.modules.notes.READ"},
+	"GetOrganizationDetails": {"ZohoRecruit.org.READ"},
+
+	// records.go
+	"SearchRecords":        {"ZohoRecruit.modules.ALL"},
+	"InsertRecords":        {"ZohoRecruit.modules.ALL"},
+	"UpsertRecords":        {"ZohoRecruit.modules.ALL"},
+	"GetAssociatedRecords": {"ZohoRecruit.modules.ALL"},
+
+	// tags.go
+	"CreateTags":        {"ZohoRecruit.settings.tags.CREATE"},
+	"AddTagsToIDs":      {"ZohoRecruit.modules.ALL"},
+	"AddTagsToId":       {"ZohoRecruit.modules.ALL"},
+	"DeleteTagById":     {"ZohoRecruit.settings.tags.DELETE"},
+	"GetTagsList":       {"ZohoRecruit.settings.tags.READ"},
+	"UpdateTag":         {"ZohoRecruit.settings.tags.UPDATE"},
+	"RemoveTagsFromIDs": {"ZohoRecruit.modules.ALL"},
+	"RemoveTagsFromId":  {"ZohoRecruit.modules.ALL"},
+
+	// user.go
+	"GetUsers": {"ZohoRecruit.users.READ"},
+}
+
+// RequiredScopes returns the minimal scopes required by the methods of API, named as in the API, eg.
+// RequiredScopes("GetCandidates", "GetUsers")
+func RequiredScopes(methods ...string) ([]zoho.ScopeString, error) {
+	return methodScopes.Required(methods...)
+}
@@ -309,11 +309,14 @@ func (c *API) UpdateTag(ID string, request UpdateTagRequest) (data UpdateTagResp
 	return UpdateTagResponse{}, fmt.Errorf("data returned was not 'UpdateTagResponse'")
 }
 
+// UpdateTagRequest is the data provided to UpdateTag, the name is the only field of a tag that can be changed
 type UpdateTagRequest struct {
 	Tags []struct {
 		Name string `json:"name"`
 	} `json:"tags"`
 }
+
+// UpdateTagResponse is the data returned by UpdateTag
 type UpdateTagResponse struct {
 	Tags []struct {
 		Code    string `json:"code"`
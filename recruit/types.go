@@ -65,10 +65,7 @@ func (s *SingleLine) UnmarshalJSON(data []byte) error {
 }
 
 func (s SingleLine) MarshalJSON() ([]byte, error) {
-	if string(s) == "" {
-		return []byte{}, nil
-	}
-	return []byte(s), nil
+	return json.Marshal(string(s))
 }
 
 // MultiLine is the field type in Zoho that defines a multiline input field, like text area in HTML
@@ -92,10 +89,7 @@ func (s *MultiLine) UnmarshalJSON(data []byte) error {
 }
 
 func (s MultiLine) MarshalJSON() ([]byte, error) {
-	if string(s) == "" {
-		return []byte{}, nil
-	}
-	return []byte(s), nil
+	return json.Marshal(string(s))
 }
 
 // Email is the field type in Zoho that defines an email address field
@@ -119,10 +113,7 @@ func (s *Email) UnmarshalJSON(data []byte) error {
 }
 
 func (s Email) MarshalJSON() ([]byte, error) {
-	if string(s) == "" {
-		return []byte{}, nil
-	}
-	return []byte(s), nil
+	return json.Marshal(string(s))
 }
 
 // Phone is the field type in Zoho that defines a phone number field
@@ -146,10 +137,7 @@ func (s *Phone) UnmarshalJSON(data []byte) error {
 }
 
 func (s Phone) MarshalJSON() ([]byte, error) {
-	if string(s) == "" {
-		return []byte{}, nil
-	}
-	return []byte(s), nil
+	return json.Marshal(string(s))
 }
 
 // PickList is the field type in Zoho that defines a dropdown that has been selected
@@ -173,8 +161,5 @@ func (s *PickList) UnmarshalJSON(data []byte) error {
 }
 
 func (s PickList) MarshalJSON() ([]byte, error) {
-	if string(s) == "" {
-		return []byte{}, nil
-	}
-	return []byte(s), nil
+	return json.Marshal(string(s))
 }
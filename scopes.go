@@ -0,0 +1,248 @@
+package zoho
+
+import (
+	"fmt"
+	"strings"
+)
+
+const (
+	// Books is the Service portion of the scope string
+	Books Service = "ZohoBooks"
+	// Invoice is the Service portion of the scope string
+	Invoice Service = "ZohoInvoice"
+	// Subscriptions is the Service portion of the scope string
+	Subscriptions Service = "ZohoSubscriptions"
+	// Recruit is the Service portion of the scope string
+	Recruit Service = "ZohoRecruit"
+	// Shifts is the Service portion of the scope string
+	Shifts Service = "ZohoShifts"
+	// ZohoFiles is the Service portion of the scope string, used to upload files for CRM bulk write jobs
+	ZohoFiles Service = "ZohoFiles"
+	// AaaServer is the Service portion of the scope string of Zoho Accounts, eg. for GetUserInfo
+	AaaServer Service = "AaaServer"
+)
+
+const (
+	// CoqlScope is a possible Scope portion of the scope string
+	CoqlScope Scope = "coql"
+	// BulkScope is a possible Scope portion of the scope string
+	BulkScope Scope = "bulk"
+	// NotificationsScope is a possible Scope portion of the scope string
+	NotificationsScope Scope = "notifications"
+	// SendMailScope is a possible Scope portion of the scope string
+	SendMailScope Scope = "send_mail"
+	// FunctionsScope is a possible Scope portion of the scope string
+	FunctionsScope Scope = "functions"
+	// TemplatesScope is a possible Scope portion of the scope string
+	TemplatesScope Scope = "templates"
+	// FilesScope is a possible Scope portion of the scope string
+	FilesScope Scope = "files"
+	// EmailsScope is a possible Scope portion of the scope string
+	EmailsScope Scope = "emails"
+	// MassUpdateScope is a possible Scope portion of the scope string
+	MassUpdateScope Scope = "mass_update"
+	// ChangeOwnerScope is a possible Scope portion of the scope string
+	ChangeOwnerScope Scope = "change_owner"
+	// ShareScope is a possible Scope portion of the scope string
+	ShareScope Scope = "share"
+	// LockingInformationScope is a possible Scope portion of the scope string
+	LockingInformationScope Scope = "locking_information"
+
+	// Scopes of the finance APIs: Books, Invoice and Subscriptions
+
+	// ContactsScope is a possible Scope portion of the scope string
+	ContactsScope Scope = "contacts"
+	// InvoicesScope is a possible Scope portion of the scope string
+	InvoicesScope Scope = "invoices"
+	// EstimatesScope is a possible Scope portion of the scope string
+	EstimatesScope Scope = "estimates"
+	// CustomerPaymentsScope is a possible Scope portion of the scope string
+	CustomerPaymentsScope Scope = "customerpayments"
+	// CreditNotesScope is a possible Scope portion of the scope string
+	CreditNotesScope Scope = "creditnotes"
+	// ProjectsScope is a possible Scope portion of the scope string
+	ProjectsScope Scope = "projects"
+	// ExpensesScope is a possible Scope portion of the scope string
+	ExpensesScope Scope = "expenses"
+	// SalesOrdersScope is a possible Scope portion of the scope string
+	SalesOrdersScope Scope = "salesorders"
+	// PurchaseOrdersScope is a possible Scope portion of the scope string
+	PurchaseOrdersScope Scope = "purchaseorders"
+	// BillsScope is a possible Scope portion of the scope string
+	BillsScope Scope = "bills"
+	// DebitNotesScope is a possible Scope portion of the scope string
+	DebitNotesScope Scope = "debitnotes"
+	// VendorPaymentsScope is a possible Scope portion of the scope string
+	VendorPaymentsScope Scope = "vendorpayments"
+	// BankingScope is a possible Scope portion of the scope string
+	BankingScope Scope = "banking"
+	// AccountantsScope is a possible Scope portion of the scope string
+	AccountantsScope Scope = "accountants"
+	// CustomersScope is a possible Scope portion of the scope string
+	CustomersScope Scope = "customers"
+	// SubscriptionsScope is a possible Scope portion of the scope string
+	SubscriptionsScope Scope = "subscriptions"
+	// PaymentsScope is a possible Scope portion of the scope string
+	PaymentsScope Scope = "payments"
+	// ProductsScope is a possible Scope portion of the scope string
+	ProductsScope Scope = "products"
+	// PlansScope is a possible Scope portion of the scope string
+	PlansScope Scope = "plans"
+	// AddonsScope is a possible Scope portion of the scope string
+	AddonsScope Scope = "addons"
+	// CouponsScope is a possible Scope portion of the scope string
+	CouponsScope Scope = "coupons"
+	// HostedPagesScope is a possible Scope portion of the scope string
+	HostedPagesScope Scope = "hostedpages"
+
+	// Scopes of the Shifts, Expense and Accounts APIs
+
+	// SchedulesScope is a possible Scope portion of the scope string
+	SchedulesScope Scope = "schedules"
+	// EmployeesScope is a possible Scope portion of the scope string
+	EmployeesScope Scope = "employees"
+	// TimesheetsScope is a possible Scope portion of the scope string
+	TimesheetsScope Scope = "timesheets"
+	// TimeoffScope is a possible Scope portion of the scope string
+	TimeoffScope Scope = "timeoff"
+	// AvailabilityScope is a possible Scope portion of the scope string
+	AvailabilityScope Scope = "availability"
+	// OrgSettingsScope is a possible Scope portion of the scope string
+	OrgSettingsScope Scope = "orgsettings"
+	// ProfileScope is a possible Scope portion of the scope string
+	ProfileScope Scope = "profile"
+)
+
+// ScopeCatalog lists the Scope portions of the scope strings of each Service
+var ScopeCatalog = map[Service][]Scope{
+	Crm: {
+		ModulesScope, SettingsScope, UsersScope, OrgScope, CoqlScope, BulkScope, NotificationsScope, SendMailScope,
+		FunctionsScope, TemplatesScope, FilesScope, EmailsScope, MassUpdateScope, ChangeOwnerScope, ShareScope,
+		LockingInformationScope,
+	},
+	Books: {
+		FullAccessScope, ContactsScope, SettingsScope, EstimatesScope, InvoicesScope, CustomerPaymentsScope,
+		CreditNotesScope, ProjectsScope, ExpensesScope, SalesOrdersScope, PurchaseOrdersScope, BillsScope,
+		DebitNotesScope, VendorPaymentsScope, BankingScope, AccountantsScope,
+	},
+	Invoice: {
+		FullAccessScope, ContactsScope, SettingsScope, EstimatesScope, InvoicesScope, CustomerPaymentsScope,
+		CreditNotesScope, ProjectsScope, ExpensesScope,
+	},
+	Subscriptions: {
+		FullAccessScope, CustomersScope, SubscriptionsScope, InvoicesScope, PaymentsScope, CreditNotesScope,
+		ProductsScope, PlansScope, AddonsScope, CouponsScope, HostedPagesScope, SettingsScope,
+	},
+	Recruit: {ModulesScope, SettingsScope, UsersScope, OrgScope},
+	Shifts: {
+		SchedulesScope, EmployeesScope, TimesheetsScope, TimeoffScope, AvailabilityScope, SettingsScope,
+	},
+	Expense: {
+		FullAccessScope, ExpenseReportScope, ApprovalScope, ReimbursementScope, AdvanceScope, DataScope,
+		OrgSettingsScope,
+	},
+	Bookings:  {DataScope},
+	ZohoFiles: {FilesScope},
+	AaaServer: {ProfileScope},
+}
+
+// ValidateScope returns an error if the scope string is malformed, or its Service or Scope portion is not in the
+// ScopeCatalog. A scope string is made of a service, a scope, an optional method, and an operation, which is one
+// of ALL, READ, CREATE, UPDATE or DELETE, eg. ZohoCRM.modules.leads.READ.
+func ValidateScope(s ScopeString) error {
+	parts := strings.Split(string(s), ".")
+	if len(parts) < 3 || len(parts) > 4 {
+		return fmt.Errorf("Invalid scope '%s': must be service.scope[.method].operation", s)
+	}
+	for _, p := range parts {
+		if p == "" || strings.IndexFunc(p, invalidScopeRune) != -1 {
+			return fmt.Errorf("Invalid scope '%s': invalid characters", s)
+		}
+	}
+
+	scopes, ok := catalogScopes(parts[0])
+	if !ok {
+		return fmt.Errorf("Invalid scope '%s': unknown service '%s'", s, parts[0])
+	}
+	if !containsScope(scopes, parts[1]) {
+		return fmt.Errorf("Invalid scope '%s': unknown scope '%s' for service '%s'", s, parts[1], parts[0])
+	}
+
+	switch Operation(strings.ToUpper(parts[len(parts)-1])) {
+	case All, Read, Create, Update, Delete:
+		return nil
+	}
+	return fmt.Errorf("Invalid scope '%s': unknown operation '%s'", s, parts[len(parts)-1])
+}
+
+// ValidateScopes returns the error of the first invalid scope string, see ValidateScope
+func ValidateScopes(scopes ...ScopeString) error {
+	for _, s := range scopes {
+		if err := ValidateScope(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// MethodScopes maps the names of the methods of an API to the scopes they require, it is used by the
+// RequiredScopes functions of the product packages
+type MethodScopes map[string][]ScopeString
+
+// Required returns the scopes required by the methods, without duplicates or scopes granted by another scope
+// returned, eg. ZohoCRM.users.READ is not returned with ZohoCRM.users.ALL
+func (m MethodScopes) Required(methods ...string) ([]ScopeString, error) {
+	scopes := []ScopeString{}
+	for _, method := range methods {
+		s, ok := m[method]
+		if !ok {
+			return nil, fmt.Errorf("Unknown method '%s'", method)
+		}
+		scopes = append(scopes, s...)
+	}
+	return CompactScopes(scopes...), nil
+}
+
+// CompactScopes removes the duplicate scopes, and the scopes granted by another of the scopes
+func CompactScopes(scopes ...ScopeString) []ScopeString {
+	compact := []ScopeString{}
+	for i, s := range scopes {
+		granted := false
+		for j, o := range scopes {
+			if i == j {
+				continue
+			}
+			// of two equal scopes the first is kept
+			if scopeGrants(o, s) && (!scopeGrants(s, o) || j < i) {
+				granted = true
+				break
+			}
+		}
+		if !granted {
+			compact = append(compact, s)
+		}
+	}
+	return compact
+}
+
+func catalogScopes(service string) ([]Scope, bool) {
+	for svc, scopes := range ScopeCatalog {
+		if strings.EqualFold(string(svc), service) {
+			return scopes, true
+		}
+	}
+	return nil, false
+}
+
+func containsScope(scopes []Scope, scope string) bool {
+	for _, s := range scopes {
+		if strings.EqualFold(string(s), scope) {
+			return true
+		}
+	}
+	return false
+}
+
+func invalidScopeRune(r rune) bool {
+	return !(r == '_' || r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9')
+}
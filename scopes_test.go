@@ -0,0 +1,83 @@
+package zoho
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCompactScopes(t *testing.T) {
+	tests := []struct {
+		scopes []ScopeString
+		want   []ScopeString
+	}{
+		{
+			[]ScopeString{"ZohoInvoice.fullaccess.all", "ZohoInvoice.invoices.CREATE"},
+			[]ScopeString{"ZohoInvoice.fullaccess.all"},
+		},
+		{
+			[]ScopeString{"ZohoBooks.contacts.READ", "ZohoBooks.fullaccess.all", "ZohoInvoice.invoices.READ"},
+			[]ScopeString{"ZohoBooks.fullaccess.all", "ZohoInvoice.invoices.READ"},
+		},
+		{
+			[]ScopeString{"ZohoCRM.users.READ", "ZohoCRM.users.ALL", "ZohoCRM.users.ALL"},
+			[]ScopeString{"ZohoCRM.users.ALL"},
+		},
+		{
+			[]ScopeString{"ZohoCRM.modules.leads.READ", "ZohoCRM.modules.contacts.READ"},
+			[]ScopeString{"ZohoCRM.modules.leads.READ", "ZohoCRM.modules.contacts.READ"},
+		},
+	}
+
+	for _, tt := range tests {
+		if got := CompactScopes(tt.scopes...); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("CompactScopes(%v) = %v, want %v", tt.scopes, got, tt.want)
+		}
+	}
+}
+
+func TestMethodScopesRequired(t *testing.T) {
+	m := MethodScopes{
+		"ListInvoices":  {"ZohoInvoice.invoices.READ"},
+		"CreateInvoice": {"ZohoInvoice.invoices.CREATE", "ZohoInvoice.fullaccess.all"},
+	}
+
+	got, err := m.Required("ListInvoices", "CreateInvoice")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []ScopeString{"ZohoInvoice.fullaccess.all"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Required returned %v, want %v", got, want)
+	}
+
+	if _, err := m.Required("DeleteInvoice"); err == nil {
+		t.Error("Required did not return an error for an unknown method")
+	}
+}
+
+func TestValidateScope(t *testing.T) {
+	tests := []struct {
+		scope ScopeString
+		valid bool
+	}{
+		{"ZohoCRM.modules.leads.READ", true},
+		{"ZohoCRM.modules.ALL", true},
+		{"ZohoInvoice.fullaccess.all", true},
+		{"AaaServer.profile.READ", true},
+		{"ZohoCRM.modules", false},
+		{"ZohoCRM.modules.leads.READ.extra", false},
+		{"ZohoUnknown.modules.READ", false},
+		{"ZohoCRM.unknown.READ", false},
+		{"ZohoCRM.modules.leads.WRITE", false},
+		{"ZohoCRM.modules.le ads.READ", false},
+	}
+
+	for _, tt := range tests {
+		err := ValidateScope(tt.scope)
+		if tt.valid && err != nil {
+			t.Errorf("ValidateScope(%s) returned error: %s", tt.scope, err)
+		}
+		if !tt.valid && err == nil {
+			t.Errorf("ValidateScope(%s) did not return an error", tt.scope)
+		}
+	}
+}
@@ -252,6 +252,16 @@ type UpdateShiftRequest struct {
 		StartTime    *Time  `json:"start_time,omitempty"`
 		EndTime      *Time  `json:"end_time,omitempty"`
 	} `json:"breaks,omitempty"`
+
+	// NullFields clears shift fields, eg. "position_id" to leave the shift without a position
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdateShiftRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateShiftRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdateShiftResponse struct {
@@ -463,6 +473,16 @@ type UpdateAvailabilityRequest struct {
 	EndTime    *Time  `json:"end_time,omitempty"`
 	Preference string `json:"preference,omitempty"` // preferred, unavailable
 	Notes      string `json:"notes,omitempty"`
+
+	// NullFields clears availability fields, eg. "notes"
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdateAvailabilityRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateAvailabilityRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdateAvailabilityResponse struct {
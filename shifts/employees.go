@@ -278,6 +278,16 @@ type UpdateEmployeeRequest struct {
 	MaxHrsDay          int    `json:"max_hrs_day,omitempty"`
 	MaxDaysWeek        int    `json:"max_days_week,omitempty"`
 	MaxShiftsDay       int    `json:"max_shifts_day,omitempty"`
+
+	// NullFields clears employee fields, eg. "hourly_rate" or "max_hrs_week"
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdateEmployeeRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateEmployeeRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdateEmployeeResponse struct {
@@ -0,0 +1,66 @@
+package shifts
+
+import (
+	zoho "github.com/schmorrison/Zoho"
+)
+
+var methodScopes = zoho.MethodScopes{
+	// shifts.go
+	"GetAllShifts": {"ZohoShifts.schedules.READ"},
+	"CreateShift":  {"ZohoShifts.schedules.CREATE"},
+	"GetShift":     {"ZohoShifts.schedules.READ"},
+	"UpdateShift":  {"ZohoShifts.schedules.UPDATE"},
+	"DeleteShift":  {"ZohoShifts.schedules.DELETE"},
+
+	// availability.go
+	"GetAllAvailabilities": {"ZohoShifts.availability.READ"},
+	"CreateAvailability":   {"ZohoShifts.availability.CREATE"},
+	"UpdateAvailability":   {"ZohoShifts.availability.UPDATE"},
+	"DeleteAvailability":   {"ZohoShifts.availability.DELETE"},
+
+	// employees.go
+	"GetAllEmployees":    {"ZohoShifts.employees.READ"},
+	"CreateEmployee":     {"ZohoShifts.employees.CREATE"},
+	"GetEmployee":        {"ZohoShifts.employees.READ"},
+	"UpdateEmployee":     {"ZohoShifts.employees.UPDATE"},
+	"ActivateEmployee":   {"ZohoShifts.employees.UPDATE"},
+	"DeactivateEmployee": {"ZohoShifts.employees.UPDATE"},
+	"InviteEmployee":     {"ZohoShifts.employees.UPDATE"},
+
+	// settings.go
+	"GetAllSchedules": {"ZohoShifts.settings.READ"},
+	"CreateSchedule":  {"ZohoShifts.settings.CREATE"},
+	"UpdateSchedule":  {"ZohoShifts.settings.UPDATE"},
+	"DeleteSchedule":  {"ZohoShifts.settings.DELETE"},
+	"GetAllPositions": {"ZohoShifts.settings.READ"},
+	"CreatePosition":  {"ZohoShifts.settings.CREATE"},
+	"UpdatePosition":  {"ZohoShifts.settings.UPDATE"},
+	"DeletePosition":  {"ZohoShifts.settings.DELETE"},
+	"GetAllJobsites":  {"ZohoShifts.settings.READ"},
+	"CreateJobsite":   {"ZohoShifts.settings.CREATE"},
+	"UpdateJobsite":   {"ZohoShifts.settings.UPDATE"},
+	"DeleteJobsite":   {"ZohoShifts.settings.DELETE"},
+
+	// timeoff.go
+	"GetAllTimeoffRequests": {"ZohoShifts.timeoff.READ"},
+	"CreateTimeoffRequest":  {"ZohoShifts.timeoff.CREATE"},
+	"GetTimeoffRequest":     {"ZohoShifts.timeoff.READ"},
+	"UpdateTimeoff":         {"ZohoShifts.timeoff.UPDATE"},
+	"DeleteTimeoffRequest":  {"ZohoShifts.timeoff.DELETE"},
+	"CancelTimeoffRequest":  {"ZohoShifts.timeoff.UPDATE"},
+	"ApproveTimeoffRequest": {"ZohoShifts.timeoff.UPDATE"},
+	"DenyTimeoffRequest":    {"ZohoShifts.timeoff.UPDATE"},
+
+	// timesheets.go
+	"GetAllTimesheets": {"ZohoShifts.timesheets.READ"},
+	"CreateTimesheet":  {"ZohoShifts.timesheets.CREATE"},
+	"GetTimesheet":     {"ZohoShifts.timesheets.READ"},
+	"UpdateTimesheet":  {"ZohoShifts.timesheets.UPDATE"},
+	"DeleteTimesheet":  {"ZohoShifts.timesheets.DELETE"},
+}
+
+// RequiredScopes returns the minimal scopes required by the methods of API, named as in the API, eg.
+// RequiredScopes("GetAllShifts", "GetAllEmployees")
+func RequiredScopes(methods ...string) ([]zoho.ScopeString, error) {
+	return methodScopes.Required(methods...)
+}
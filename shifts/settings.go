@@ -137,6 +137,16 @@ type UpdateScheduleRequest struct {
 	Address   string `json:"address,omitempty"`
 	Latitude  string `json:"latitude,omitempty"`
 	Longitude string `json:"longitude,omitempty"`
+
+	// NullFields clears schedule fields, eg. "address"
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdateScheduleRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateScheduleRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdateScheduleResponse struct {
@@ -315,6 +325,16 @@ type UpdatePositionRequest struct {
 	Schedules []struct {
 		ID string `json:"id,omitempty"`
 	} `json:"schedules,omitempty"`
+
+	// NullFields clears position fields, eg. "color"
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdatePositionRequest) MarshalJSON() ([]byte, error) {
+	type request UpdatePositionRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdatePositionResponse struct {
@@ -513,6 +533,16 @@ type UpdateJobsiteRequest struct {
 	Latitude  string `json:"latitude,omitempty"`
 	Longitude string `json:"longitude,omitempty"`
 	Notes     string `json:"notes,omitempty"`
+
+	// NullFields clears job site fields, eg. "notes" or "address"
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdateJobsiteRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateJobsiteRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdateJobsiteResponse struct {
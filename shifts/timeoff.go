@@ -247,6 +247,16 @@ type UpdateTimeoffRequest struct {
 	TypeID    string `json:"type_id,omitempty"`
 	DayType   string `json:"day_type,omitempty"` // all_day, partial
 	Reason    string `json:"reason,omitempty"`
+
+	// NullFields clears fields of the request, eg. "reason"
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdateTimeoffRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateTimeoffRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdateTimeoffResponse struct {
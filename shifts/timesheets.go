@@ -295,6 +295,16 @@ type UpdateTimesheetRequest struct {
 		StartTime    *Time  `json:"start_time,omitempty"`
 		EndTime      *Time  `json:"end_time,omitempty"`
 	} `json:"breaks,omitempty"`
+
+	// NullFields clears timesheet fields, eg. "job_site_id" or "notes"
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r UpdateTimesheetRequest) MarshalJSON() ([]byte, error) {
+	type request UpdateTimesheetRequest
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type UpdateTimesheetResponse struct {
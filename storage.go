@@ -1,20 +1,18 @@
 package zoho
 
 import (
+	"bytes"
 	"encoding/gob"
 	"errors"
 	"fmt"
-	"net/http"
 	"os"
 	"time"
-
-	"google.golang.org/appengine"
-	"google.golang.org/appengine/datastore"
 )
 
 // TokenLoaderSaver is an interface that can be implemented when using a system that does
 // not allow disk persistence, or a different type of persistence is required.
-// The use case that was in mind was AppEngine where datastore is the only persistence option.
+// The use case that was in mind was AppEngine where datastore is the only persistence option,
+// see the datastore sub-package. EncryptedFileStore, SQLStore, KVStore and EnvStore are also provided.
 type TokenLoaderSaver interface {
 	SaveTokens(t AccessTokenResponse) error
 	LoadAccessAndRefreshToken() (AccessTokenResponse, error)
@@ -22,56 +20,57 @@ type TokenLoaderSaver interface {
 
 // SaveTokens will check for a provided 'TokenManager' interface
 // if one exists it will use its provided method
-func (z Zoho) SaveTokens(t AccessTokenResponse) error {
-	if z.tokenManager != nil {
-		return z.tokenManager.SaveTokens(t)
-	}
+//
+// Otherwise the tokens are written to a temporary file which replaces the tokens file, so a concurrent
+// reader never sees a partially written file
+func (z *Zoho) SaveTokens(t AccessTokenResponse) error {
+	z.tokenMu.Lock()
+	manager, tokensFile := z.tokenManager, z.tokensFile
+	z.tokenMu.Unlock()
 
-	// Save the token response as GOB to file
-	file, err := os.OpenFile(z.tokensFile, os.O_WRONLY|os.O_CREATE, 0666)
-	if err != nil {
-		return fmt.Errorf("Failed to open file '%s': %s", z.tokensFile, err)
+	if manager != nil {
+		return manager.SaveTokens(t)
 	}
-	enc := gob.NewEncoder(file)
 
+	// Save the token response as GOB to a temporary file in the same directory, then rename it over the tokens file
 	v := TokenWrapper{
-		Token: z.oauth.token,
+		Token: t,
 	}
 	v.SetExpiry()
 
-	err = enc.Encode(v)
-	if err != nil {
-		return fmt.Errorf("Failed to encode tokens to file '%s': %s", z.tokensFile, err)
+	b := bytes.Buffer{}
+	if err := gob.NewEncoder(&b).Encode(v); err != nil {
+		return fmt.Errorf("Failed to encode tokens to file '%s': %s", tokensFile, err)
 	}
 
-	return nil
+	return writeFileAtomic(tokensFile, b.Bytes())
 }
 
 // LoadAccessAndRefreshToken will check for a provided 'TokenManager' interface
 // if one exists it will use its provided method
-func (z Zoho) LoadAccessAndRefreshToken() (AccessTokenResponse, error) {
-	if z.tokenManager != nil {
-		return z.tokenManager.LoadAccessAndRefreshToken()
+func (z *Zoho) LoadAccessAndRefreshToken() (AccessTokenResponse, error) {
+	z.tokenMu.Lock()
+	manager, tokensFile := z.tokenManager, z.tokensFile
+	z.tokenMu.Unlock()
+
+	if manager != nil {
+		return manager.LoadAccessAndRefreshToken()
 	}
 
 	// Load the GOB and decode to AccessToken
-	file, err := os.OpenFile(z.tokensFile, os.O_RDONLY|os.O_CREATE, 0666)
+	file, err := os.OpenFile(tokensFile, os.O_RDONLY|os.O_CREATE, 0600)
 	if err != nil {
-		return AccessTokenResponse{}, fmt.Errorf("Failed to open file '%s': %s", z.tokensFile, err)
+		return AccessTokenResponse{}, fmt.Errorf("Failed to open file '%s': %s", tokensFile, err)
 	}
-	dec := gob.NewDecoder(file)
+	defer file.Close()
 
 	var v TokenWrapper
-	err = dec.Decode(&v)
+	err = gob.NewDecoder(file).Decode(&v)
 	if err != nil {
-		return AccessTokenResponse{}, fmt.Errorf("Failed to decode tokens from file '%s': %s", z.tokensFile, err)
-	}
-
-	if v.CheckExpiry() {
-		return v.Token, ErrTokenExpired
+		return AccessTokenResponse{}, fmt.Errorf("Failed to decode tokens from file '%s': %s", tokensFile, err)
 	}
 
-	return v.Token, nil
+	return v.loaded()
 }
 
 // ErrTokenExpired should be returned when the token is expired but still exists in persistence
@@ -83,14 +82,22 @@ var ErrTokenInvalidCode = errors.New("zoho: authorization-code is invalid ")
 // ErrClientSecretInvalidCode is turned when the client secret used is invalid
 var ErrClientSecretInvalidCode = errors.New("zoho: client secret used in authorization is invalid")
 
+// ErrInvalidState is returned when the state of an authorization callback does not match the state of the flow
+var ErrInvalidState = errors.New("zoho: state of authorization callback is invalid")
+
 // TokenWrapper should be used to provide the time.Time corresponding to the expiry of an access token
 type TokenWrapper struct {
 	Token   AccessTokenResponse
 	Expires time.Time
 }
 
-// SetExpiry sets the TokenWrappers expiry time to now + seconds until expiry
+// SetExpiry sets the TokenWrappers expiry time to the expiry of the token, or now + seconds until expiry
+// when the expiry of the token is unknown
 func (t *TokenWrapper) SetExpiry() {
+	if !t.Token.ExpiresAt.IsZero() {
+		t.Expires = t.Token.ExpiresAt
+		return
+	}
 	t.Expires = time.Now().Add(time.Duration(t.Token.ExpiresIn) * time.Second)
 }
 
@@ -99,9 +106,16 @@ func (t *TokenWrapper) CheckExpiry() bool {
 	return t.Expires.Before(time.Now())
 }
 
+// CheckForSavedTokens loads the saved tokens, returning ErrTokenExpired if the access token has expired. The
+// tokens held are only replaced when tokens were saved.
 func (z *Zoho) CheckForSavedTokens() error {
 	t, err := z.LoadAccessAndRefreshToken()
-	z.oauth.token = t
+	if t != (AccessTokenResponse{}) {
+		if err == ErrTokenExpired {
+			t.ExpiresAt = time.Now()
+		}
+		z.setToken(t)
+	}
 
 	if err != nil && err == ErrTokenExpired {
 		return err
@@ -112,66 +126,3 @@ func (z *Zoho) CheckForSavedTokens() error {
 	}
 	return fmt.Errorf("No saved tokens")
 }
-
-// DatastoreManager is an example TokenManager that satisfies the TokenManager interface
-// When instantiating, user must provide the *http.Request for the current app engine request
-// and the token key where the tokens are to be saved to/loaded from.
-type DatastoreManager struct {
-	Request         *http.Request
-	EntityNamespace string
-	TokensKey       string
-}
-
-// LoadAccessAndRefreshToken will use datastore package to get tokens from the datastore under the entity namespace
-// 'ZohoAccessTokens' unless a value is provided to the EntityNamespace field
-func (d DatastoreManager) LoadAccessAndRefreshToken() (AccessTokenResponse, error) {
-	t := TokenWrapper{}
-	if d.Request == nil || d.TokensKey == "" {
-		return AccessTokenResponse{}, fmt.Errorf("Must provide the *http.Request for the current request and a valid token key")
-	}
-
-	entity := "ZohoAccessTokens"
-	if d.EntityNamespace != "" {
-		entity = d.EntityNamespace
-	}
-
-	ctx := appengine.NewContext(d.Request)
-	k := datastore.NewKey(ctx, entity, d.TokensKey, 0, nil)
-
-	if err := datastore.Get(ctx, k, &t); err != nil {
-		return AccessTokenResponse{}, fmt.Errorf("Failed to retrieve tokens from datastore: %s", err)
-	}
-
-	if t.CheckExpiry() {
-		return AccessTokenResponse{}, ErrTokenExpired
-	}
-
-	return t.Token, nil
-}
-
-// SaveTokens will use datastore package to put tokens to the datastore under the entity namespace
-// 'ZohoAccessTokens' unless a value is provided to the EntityNamespace field
-func (d DatastoreManager) SaveTokens(t AccessTokenResponse) error {
-	if d.Request == nil || d.TokensKey == "" {
-		return fmt.Errorf("Must provide the *http.Request for the current request and a valid token key")
-	}
-
-	entity := "ZohoAccessTokens"
-	if d.EntityNamespace != "" {
-		entity = d.EntityNamespace
-	}
-
-	ctx := appengine.NewContext(d.Request)
-	k := datastore.NewKey(ctx, entity, d.TokensKey, 0, nil)
-
-	v := TokenWrapper{
-		Token: t,
-	}
-	v.SetExpiry()
-
-	if _, err := datastore.Put(ctx, k, v); err != nil {
-		return fmt.Errorf("Failed to save tokens to datastore: %s", err)
-	}
-
-	return nil
-}
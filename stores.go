@@ -0,0 +1,351 @@
+package zoho
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"database/sql"
+	"encoding/gob"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"regexp"
+	"time"
+)
+
+// EncryptedFileStore is a TokenLoaderSaver which saves the tokens to a file encrypted with AES-GCM.
+// The Key must be 16, 24 or 32 bytes long, selecting AES-128, AES-192 or AES-256.
+type EncryptedFileStore struct {
+	Path string
+	Key  []byte
+}
+
+// NewEncryptedFileStore returns an EncryptedFileStore saving to the file at path, encrypted with key
+func NewEncryptedFileStore(path string, key []byte) (*EncryptedFileStore, error) {
+	if _, err := aes.NewCipher(key); err != nil {
+		return nil, fmt.Errorf("Failed to create cipher: %s", err)
+	}
+	return &EncryptedFileStore{Path: path, Key: key}, nil
+}
+
+// SaveTokens encrypts the tokens and replaces the file with them
+func (s *EncryptedFileStore) SaveTokens(t AccessTokenResponse) error {
+	gcm, err := s.gcm()
+	if err != nil {
+		return err
+	}
+
+	v := TokenWrapper{Token: t}
+	v.SetExpiry()
+
+	plain := bytes.Buffer{}
+	if err := gob.NewEncoder(&plain).Encode(v); err != nil {
+		return fmt.Errorf("Failed to encode tokens: %s", err)
+	}
+
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("Failed to generate nonce: %s", err)
+	}
+
+	return writeFileAtomic(s.Path, gcm.Seal(nonce, nonce, plain.Bytes(), nil))
+}
+
+// LoadAccessAndRefreshToken decrypts the tokens from the file
+func (s *EncryptedFileStore) LoadAccessAndRefreshToken() (AccessTokenResponse, error) {
+	gcm, err := s.gcm()
+	if err != nil {
+		return AccessTokenResponse{}, err
+	}
+
+	b, err := ioutil.ReadFile(s.Path)
+	if err != nil {
+		return AccessTokenResponse{}, fmt.Errorf("Failed to read file '%s': %s", s.Path, err)
+	}
+	if len(b) < gcm.NonceSize() {
+		return AccessTokenResponse{}, fmt.Errorf("Failed to decrypt file '%s': file is too short", s.Path)
+	}
+
+	plain, err := gcm.Open(nil, b[:gcm.NonceSize()], b[gcm.NonceSize():], nil)
+	if err != nil {
+		return AccessTokenResponse{}, fmt.Errorf("Failed to decrypt file '%s': %s", s.Path, err)
+	}
+
+	var v TokenWrapper
+	if err := gob.NewDecoder(bytes.NewReader(plain)).Decode(&v); err != nil {
+		return AccessTokenResponse{}, fmt.Errorf("Failed to decode tokens from file '%s': %s", s.Path, err)
+	}
+	return v.loaded()
+}
+
+func (s *EncryptedFileStore) gcm() (cipher.AEAD, error) {
+	block, err := aes.NewCipher(s.Key)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create cipher: %s", err)
+	}
+	return cipher.NewGCM(block)
+}
+
+// KeyValue is a key-value store used by KVStore, it can be implemented by a small adapter around a Redis or etcd
+// client. Get must return a nil value and no error when the key does not exist.
+type KeyValue interface {
+	Get(key string) ([]byte, error)
+	Set(key string, value []byte) error
+}
+
+// KVStore is a TokenLoaderSaver which saves the tokens as JSON under Key in a key-value store
+type KVStore struct {
+	KV  KeyValue
+	Key string
+}
+
+// SaveTokens saves the tokens under the key
+func (s KVStore) SaveTokens(t AccessTokenResponse) error {
+	v := TokenWrapper{Token: t}
+	v.SetExpiry()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("Failed to encode tokens: %s", err)
+	}
+
+	if err := s.KV.Set(s.Key, b); err != nil {
+		return fmt.Errorf("Failed to save tokens to key '%s': %s", s.Key, err)
+	}
+	return nil
+}
+
+// LoadAccessAndRefreshToken loads the tokens saved under the key
+func (s KVStore) LoadAccessAndRefreshToken() (AccessTokenResponse, error) {
+	b, err := s.KV.Get(s.Key)
+	if err != nil {
+		return AccessTokenResponse{}, fmt.Errorf("Failed to retrieve tokens from key '%s': %s", s.Key, err)
+	}
+	if b == nil {
+		return AccessTokenResponse{}, fmt.Errorf("No tokens saved to key '%s'", s.Key)
+	}
+
+	var v TokenWrapper
+	if err := json.Unmarshal(b, &v); err != nil {
+		return AccessTokenResponse{}, fmt.Errorf("Failed to decode tokens from key '%s': %s", s.Key, err)
+	}
+	return v.loaded()
+}
+
+// SQLStore is a TokenLoaderSaver which saves the tokens as JSON to a row of a database/sql table, identified
+// by Key. The table is created by CreateTable, or can be created beforehand as
+//
+//    CREATE TABLE zoho_tokens (token_key VARCHAR(255) PRIMARY KEY, tokens TEXT NOT NULL, expires BIGINT NOT NULL)
+//
+// Placeholder renders the nth (from 1) query parameter, by default '?'. It must be set for drivers using another
+// syntax, eg. func(n int) string { return fmt.Sprintf("$%d", n) } for PostgreSQL.
+type SQLStore struct {
+	DB          *sql.DB
+	Table       string
+	Key         string
+	Placeholder func(n int) string
+}
+
+var sqlIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_.]*$`)
+
+// NewSQLStore returns an SQLStore saving to the row identified by key in the table
+func NewSQLStore(db *sql.DB, table, key string) (*SQLStore, error) {
+	if !sqlIdentifier.MatchString(table) {
+		return nil, fmt.Errorf("Invalid table name '%s'", table)
+	}
+	return &SQLStore{DB: db, Table: table, Key: key}, nil
+}
+
+// CreateTable creates the table of the store if it does not exist
+func (s *SQLStore) CreateTable() error {
+	_, err := s.DB.Exec(fmt.Sprintf(
+		"CREATE TABLE IF NOT EXISTS %s (token_key VARCHAR(255) PRIMARY KEY, tokens TEXT NOT NULL, expires BIGINT NOT NULL)",
+		s.Table,
+	))
+	if err != nil {
+		return fmt.Errorf("Failed to create table '%s': %s", s.Table, err)
+	}
+	return nil
+}
+
+// SaveTokens updates the row of the key, or inserts it when it does not exist. When the insert fails because
+// the row was inserted concurrently, the row is updated again.
+func (s *SQLStore) SaveTokens(t AccessTokenResponse) error {
+	v := TokenWrapper{Token: t}
+	v.SetExpiry()
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("Failed to encode tokens: %s", err)
+	}
+
+	// MySQL counts the rows changed rather than matched, so a row saved with the same tokens is not counted
+	n, err := s.update(string(b), v.Expires.Unix())
+	if err != nil || n > 0 {
+		return err
+	}
+
+	_, insertErr := s.DB.Exec(
+		fmt.Sprintf("INSERT INTO %s (token_key, tokens, expires) VALUES (%s, %s, %s)", s.Table, s.param(1), s.param(2), s.param(3)),
+		s.Key, string(b), v.Expires.Unix(),
+	)
+	if insertErr == nil {
+		return nil
+	}
+
+	if n, err = s.update(string(b), v.Expires.Unix()); err != nil || n > 0 {
+		return err
+	}
+	if exists, err := s.exists(); err != nil || !exists {
+		return fmt.Errorf("Failed to save tokens to table '%s': %s", s.Table, insertErr)
+	}
+	return nil
+}
+
+// update updates the row of the key, returning the number of rows affected
+func (s *SQLStore) update(tokens string, expires int64) (int64, error) {
+	res, err := s.DB.Exec(
+		fmt.Sprintf("UPDATE %s SET tokens = %s, expires = %s WHERE token_key = %s", s.Table, s.param(1), s.param(2), s.param(3)),
+		tokens, expires, s.Key,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("Failed to save tokens to table '%s': %s", s.Table, err)
+	}
+
+	n, err := res.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("Failed to save tokens to table '%s': %s", s.Table, err)
+	}
+	return n, nil
+}
+
+// exists returns whether the row of the key exists
+func (s *SQLStore) exists() (bool, error) {
+	var n int
+	err := s.DB.QueryRow(
+		fmt.Sprintf("SELECT COUNT(*) FROM %s WHERE token_key = %s", s.Table, s.param(1)),
+		s.Key,
+	).Scan(&n)
+	if err != nil {
+		return false, fmt.Errorf("Failed to save tokens to table '%s': %s", s.Table, err)
+	}
+	return n > 0, nil
+}
+
+// LoadAccessAndRefreshToken loads the tokens from the row of the key
+func (s *SQLStore) LoadAccessAndRefreshToken() (AccessTokenResponse, error) {
+	var tokens string
+	err := s.DB.QueryRow(
+		fmt.Sprintf("SELECT tokens FROM %s WHERE token_key = %s", s.Table, s.param(1)),
+		s.Key,
+	).Scan(&tokens)
+	if err == sql.ErrNoRows {
+		return AccessTokenResponse{}, fmt.Errorf("No tokens saved to table '%s' for key '%s'", s.Table, s.Key)
+	}
+	if err != nil {
+		return AccessTokenResponse{}, fmt.Errorf("Failed to retrieve tokens from table '%s': %s", s.Table, err)
+	}
+
+	var v TokenWrapper
+	if err := json.Unmarshal([]byte(tokens), &v); err != nil {
+		return AccessTokenResponse{}, fmt.Errorf("Failed to decode tokens from table '%s': %s", s.Table, err)
+	}
+	return v.loaded()
+}
+
+func (s *SQLStore) param(n int) string {
+	if s.Placeholder != nil {
+		return s.Placeholder(n)
+	}
+	return "?"
+}
+
+// EnvStore is a read-only TokenLoaderSaver which loads the tokens from environment variables, by default
+// ZOHO_REFRESH_TOKEN, ZOHO_ACCESS_TOKEN and ZOHO_TOKEN_EXPIRES (RFC 3339). Usually only the refresh token is
+// provided, the access token is then refreshed and held in memory. As the environment is not modified, tokens
+// revoked by Zoho.RevokeToken are still loaded from it, they are ignored by the Zoho which revoked them.
+type EnvStore struct {
+	RefreshTokenVar string
+	AccessTokenVar  string
+	ExpiresVar      string
+}
+
+// SaveTokens does nothing, the environment is not modified
+func (s EnvStore) SaveTokens(t AccessTokenResponse) error {
+	return nil
+}
+
+// LoadAccessAndRefreshToken loads the tokens from the environment variables
+func (s EnvStore) LoadAccessAndRefreshToken() (AccessTokenResponse, error) {
+	t := AccessTokenResponse{
+		RefreshToken: os.Getenv(envOrDefault(s.RefreshTokenVar, "ZOHO_REFRESH_TOKEN")),
+		AccessToken:  os.Getenv(envOrDefault(s.AccessTokenVar, "ZOHO_ACCESS_TOKEN")),
+	}
+	if t.RefreshToken == "" && t.AccessToken == "" {
+		return AccessTokenResponse{}, fmt.Errorf("No tokens in the environment")
+	}
+
+	expires := os.Getenv(envOrDefault(s.ExpiresVar, "ZOHO_TOKEN_EXPIRES"))
+	if expires != "" {
+		e, err := time.Parse(time.RFC3339, expires)
+		if err != nil {
+			return AccessTokenResponse{}, fmt.Errorf("Failed to parse token expiry '%s': %s", expires, err)
+		}
+		t.ExpiresAt = e
+	}
+
+	if t.AccessToken == "" || (!t.ExpiresAt.IsZero() && t.ExpiresAt.Before(time.Now())) {
+		return t, ErrTokenExpired
+	}
+	return t, nil
+}
+
+func envOrDefault(name, def string) string {
+	if name != "" {
+		return name
+	}
+	return def
+}
+
+// loaded returns the token of a loaded TokenWrapper, with ErrTokenExpired if it has expired
+func (t TokenWrapper) loaded() (AccessTokenResponse, error) {
+	if t.Token.ExpiresAt.IsZero() {
+		t.Token.ExpiresAt = t.Expires
+	}
+	if t.CheckExpiry() {
+		return t.Token, ErrTokenExpired
+	}
+	return t.Token, nil
+}
+
+// renameFile is os.Rename, it is replaced in tests to fail the replacement of a file
+var renameFile = os.Rename
+
+// writeFileAtomic writes the data to a temporary file readable only by the owner, then renames it over the file
+func writeFileAtomic(path string, data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("Failed to create temporary file for '%s': %s", path, err)
+	}
+	defer os.Remove(file.Name())
+
+	_, err = file.Write(data)
+	if err == nil {
+		err = file.Sync()
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("Failed to write file '%s': %s", path, err)
+	}
+
+	if err := renameFile(file.Name(), path); err != nil {
+		return fmt.Errorf("Failed to replace file '%s': %s", path, err)
+	}
+	return nil
+}
@@ -0,0 +1,158 @@
+package zoho
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// mysqlDriver is a database/sql driver holding a single token table in memory. Like MySQL, an UPDATE
+// counts the rows it changed rather than the rows it matched, and an INSERT of an existing key fails.
+type mysqlDriver struct {
+	mu   sync.Mutex
+	rows map[string][]driver.Value
+	// beforeInsert is called before each INSERT, eg. to insert the row concurrently
+	beforeInsert func()
+}
+
+func (d *mysqlDriver) Open(name string) (driver.Conn, error) { return mysqlConn{d}, nil }
+
+type mysqlConn struct{ d *mysqlDriver }
+
+func (c mysqlConn) Prepare(query string) (driver.Stmt, error) { return mysqlStmt{c.d, query}, nil }
+func (c mysqlConn) Close() error                              { return nil }
+func (c mysqlConn) Begin() (driver.Tx, error)                 { return nil, fmt.Errorf("transactions are not supported") }
+
+type mysqlStmt struct {
+	d     *mysqlDriver
+	query string
+}
+
+func (s mysqlStmt) Close() error  { return nil }
+func (s mysqlStmt) NumInput() int { return strings.Count(s.query, "?") }
+
+func (s mysqlStmt) Exec(args []driver.Value) (driver.Result, error) {
+	switch {
+	case strings.HasPrefix(s.query, "UPDATE"):
+		s.d.mu.Lock()
+		defer s.d.mu.Unlock()
+		row, ok := s.d.rows[args[2].(string)]
+		if !ok || (row[0] == args[0] && row[1] == args[1]) {
+			return driver.RowsAffected(0), nil
+		}
+		s.d.rows[args[2].(string)] = []driver.Value{args[0], args[1]}
+		return driver.RowsAffected(1), nil
+
+	case strings.HasPrefix(s.query, "INSERT"):
+		if s.d.beforeInsert != nil {
+			s.d.beforeInsert()
+		}
+		s.d.mu.Lock()
+		defer s.d.mu.Unlock()
+		if _, ok := s.d.rows[args[0].(string)]; ok {
+			return nil, fmt.Errorf("Error 1062: Duplicate entry '%s' for key 'PRIMARY'", args[0])
+		}
+		s.d.rows[args[0].(string)] = []driver.Value{args[1], args[2]}
+		return driver.RowsAffected(1), nil
+	}
+	return nil, fmt.Errorf("unexpected query %s", s.query)
+}
+
+func (s mysqlStmt) Query(args []driver.Value) (driver.Rows, error) {
+	s.d.mu.Lock()
+	defer s.d.mu.Unlock()
+	row, ok := s.d.rows[args[0].(string)]
+	switch {
+	case strings.HasPrefix(s.query, "SELECT COUNT(*)"):
+		n := int64(0)
+		if ok {
+			n = 1
+		}
+		return &mysqlRows{values: [][]driver.Value{{n}}}, nil
+	case strings.HasPrefix(s.query, "SELECT tokens"):
+		if !ok {
+			return &mysqlRows{}, nil
+		}
+		return &mysqlRows{values: [][]driver.Value{{row[0]}}}, nil
+	}
+	return nil, fmt.Errorf("unexpected query %s", s.query)
+}
+
+type mysqlRows struct{ values [][]driver.Value }
+
+func (r *mysqlRows) Columns() []string { return []string{"value"} }
+func (r *mysqlRows) Close() error      { return nil }
+func (r *mysqlRows) Next(dest []driver.Value) error {
+	if len(r.values) == 0 {
+		return io.EOF
+	}
+	copy(dest, r.values[0])
+	r.values = r.values[1:]
+	return nil
+}
+
+func newMySQLStore(t *testing.T, name string) (*SQLStore, *mysqlDriver) {
+	d := &mysqlDriver{rows: map[string][]driver.Value{}}
+	sql.Register(name, d)
+	db, err := sql.Open(name, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	store, err := NewSQLStore(db, "zoho_tokens", "acme")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return store, d
+}
+
+func TestSQLStoreSaveTokens(t *testing.T) {
+	store, _ := newMySQLStore(t, "zohotest-mysql-save")
+	token := AccessTokenResponse{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour)}
+
+	// the second save is identical, so no row is changed
+	for i := 0; i < 2; i++ {
+		if err := store.SaveTokens(token); err != nil {
+			t.Fatalf("save %d: %s", i+1, err)
+		}
+	}
+
+	token.AccessToken = "renewed"
+	if err := store.SaveTokens(token); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := store.LoadAccessAndRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.AccessToken != "renewed" || loaded.RefreshToken != "refresh" {
+		t.Errorf("loaded %+v", loaded)
+	}
+}
+
+func TestSQLStoreSaveTokensInsertedConcurrently(t *testing.T) {
+	store, d := newMySQLStore(t, "zohotest-mysql-concurrent")
+
+	// another process inserts the row between the UPDATE and the INSERT
+	d.beforeInsert = func() {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+		d.rows["acme"] = []driver.Value{`{"Token":{"access_token":"other"}}`, int64(0)}
+	}
+
+	token := AccessTokenResponse{AccessToken: "access", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour)}
+	if err := store.SaveTokens(token); err != nil {
+		t.Fatal(err)
+	}
+	loaded, err := store.LoadAccessAndRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if loaded.AccessToken != "access" {
+		t.Errorf("loaded access token %s, want the saved token", loaded.AccessToken)
+	}
+}
@@ -0,0 +1,36 @@
+package subscriptions
+
+import (
+	zoho "github.com/schmorrison/Zoho"
+)
+
+var methodScopes = zoho.MethodScopes{
+	// customers.go
+	"GetCustomer": {"ZohoSubscriptions.customers.READ"},
+
+	// invoices.go
+	"ListAllInvoices":             {"ZohoSubscriptions.invoices.READ"},
+	"ListInvoicesForSubscription": {"ZohoSubscriptions.invoices.READ"},
+	"ListInvoicesForCustomer":     {"ZohoSubscriptions.invoices.READ"},
+	"GetInvoice":                  {"ZohoSubscriptions.invoices.READ"},
+	"AddAttachment":               {"ZohoSubscriptions.invoices.UPDATE"},
+	"EmailInvoice":                {"ZohoSubscriptions.invoices.CREATE"},
+	"AddItems":                    {"ZohoSubscriptions.invoices.UPDATE"},
+	"CollectChargeViaCreditCard":  {"ZohoSubscriptions.payments.CREATE"},
+	"CollectChargeViaBankAccount": {"ZohoSubscriptions.payments.CREATE"},
+
+	// subscriptions.go
+	"ListSubscriptions":       {"ZohoSubscriptions.subscriptions.READ"},
+	"GetSubscription":         {"ZohoSubscriptions.subscriptions.READ"},
+	"CreateSubscription":      {"ZohoSubscriptions.subscriptions.CREATE"},
+	"UpdateSubscription":      {"ZohoSubscriptions.subscriptions.UPDATE"},
+	"CancelSubscription":      {"ZohoSubscriptions.subscriptions.UPDATE"},
+	"DeleteSubscription":      {"ZohoSubscriptions.subscriptions.DELETE"},
+	"AddChargeToSubscription": {"ZohoSubscriptions.subscriptions.UPDATE"},
+}
+
+// RequiredScopes returns the minimal scopes required by the methods of API, named as in the API, eg.
+// RequiredScopes("ListSubscriptions", "GetCustomer")
+func RequiredScopes(methods ...string) ([]zoho.ScopeString, error) {
+	return methodScopes.Required(methods...)
+}
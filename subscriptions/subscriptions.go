@@ -662,6 +662,16 @@ type SubscriptionUpdate struct {
 	PaymentGateways   []PaymentGateway `json:"payment_gateways,omitempty"`
 	CustomFields      []CustomField    `json:"custom_fields,omitempty"`
 	TemplateID        int64            `json:"template_id,omitempty"`
+
+	// NullFields clears subscription fields, eg. "coupon_code" or "salesperson_name"
+	NullFields zoho.NullFields `json:"-"`
+}
+
+// MarshalJSON implements json.Marshaler, the fields in NullFields are sent as null
+func (r SubscriptionUpdate) MarshalJSON() ([]byte, error) {
+	type request SubscriptionUpdate
+	req := request(r)
+	return zoho.MarshalWithNulls(&req, r.NullFields)
 }
 
 type SubscriptionAddCharge struct {
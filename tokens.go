@@ -0,0 +1,135 @@
+package zoho
+
+import (
+	"time"
+)
+
+// TokenRefreshMargin is how long before the access token expires that it is refreshed
+var TokenRefreshMargin = time.Minute
+
+// tokenRefresh is a refresh of the access token in progress, callers requiring a refresh while it is in
+// progress wait for done and share its result
+type tokenRefresh struct {
+	done chan struct{}
+	err  error
+}
+
+// token returns a copy of the tokens held in memory
+func (z *Zoho) token() AccessTokenResponse {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
+	return z.oauth.token
+}
+
+// setToken replaces the tokens held in memory with t, setting its expiry when unknown. The refresh token and scope
+// are kept when t does not contain them, as Zoho does not return the refresh token when refreshing. The tokens
+// held are returned.
+func (z *Zoho) setToken(t AccessTokenResponse) AccessTokenResponse {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
+
+	if t.ExpiresAt.IsZero() && t.ExpiresIn > 0 {
+		t.ExpiresAt = time.Now().Add(time.Duration(t.ExpiresIn) * time.Second)
+	}
+	if t.RefreshToken == "" {
+		t.RefreshToken = z.oauth.token.RefreshToken
+	}
+	if t.Scope == "" {
+		t.Scope = z.oauth.token.Scope
+	}
+	z.oauth.token = t
+	z.tokensLoaded = true
+	return t
+}
+
+// loadToken reads the saved tokens into memory, if any are saved. Tokens in memory are only replaced by saved tokens
+// that expire later, so a token refreshed by this process is not replaced by an older copy. Saved tokens which were
+// revoked by RevokeToken are ignored.
+func (z *Zoho) loadToken() {
+	t, err := z.LoadAccessAndRefreshToken()
+	z.tokenMu.Lock()
+	revoked := z.oauth.revoked
+	z.tokenMu.Unlock()
+	if revoked != "" && (t.RefreshToken == revoked || t.AccessToken == revoked) {
+		t = AccessTokenResponse{}
+	}
+	if t == (AccessTokenResponse{}) || (err != nil && err != ErrTokenExpired) {
+		z.tokenMu.Lock()
+		z.tokensLoaded = true
+		z.tokenMu.Unlock()
+		return
+	}
+
+	if err == ErrTokenExpired {
+		t.ExpiresAt = time.Now()
+	}
+
+	current := z.token()
+	if current.AccessToken == "" || t.ExpiresAt.IsZero() || t.ExpiresAt.After(current.ExpiresAt) {
+		z.setToken(t)
+	}
+}
+
+// accessToken returns an access token valid for at least TokenRefreshMargin. The saved tokens are read when
+// the token held in memory is expiring, in case it was refreshed by another process, before refreshing it.
+// No error is returned when there is no refresh token or client credentials, the request is sent with the access
+// token held, if any.
+func (z *Zoho) accessToken() (string, error) {
+	z.tokenMu.Lock()
+	loaded := z.tokensLoaded
+	clientCredentials := z.oauth.clientCredentials
+	z.tokenMu.Unlock()
+
+	t := z.token()
+	if !loaded || t.expiring() {
+		z.loadToken()
+		t = z.token()
+	}
+
+	if !t.expiring() || (t.RefreshToken == "" && !clientCredentials) {
+		return t.AccessToken, nil
+	}
+
+	if err := z.singleRefresh(true); err != nil {
+		return "", err
+	}
+	return z.token().AccessToken, nil
+}
+
+// singleRefresh refreshes the access token, or waits for the refresh in progress and returns its result. When
+// onlyIfExpiring is set the token is not refreshed if it was refreshed by another caller in the meantime.
+func (z *Zoho) singleRefresh(onlyIfExpiring bool) error {
+	z.tokenMu.Lock()
+	if r := z.refreshing; r != nil {
+		z.tokenMu.Unlock()
+		<-r.done
+		return r.err
+	}
+	if onlyIfExpiring && !z.oauth.token.expiring() {
+		z.tokenMu.Unlock()
+		return nil
+	}
+	r := &tokenRefresh{done: make(chan struct{})}
+	z.refreshing = r
+	z.tokenMu.Unlock()
+
+	r.err = z.refreshTokenRequest()
+
+	z.tokenMu.Lock()
+	z.refreshing = nil
+	z.tokenMu.Unlock()
+	close(r.done)
+	return r.err
+}
+
+// expiring reports whether the access token is missing or expires within TokenRefreshMargin, a token without a
+// known expiry is assumed valid
+func (t AccessTokenResponse) expiring() bool {
+	if t.AccessToken == "" {
+		return true
+	}
+	if t.ExpiresAt.IsZero() {
+		return false
+	}
+	return time.Now().Add(TokenRefreshMargin).After(t.ExpiresAt)
+}
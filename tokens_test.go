@@ -0,0 +1,126 @@
+package zoho
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestConcurrentRefresh(t *testing.T) {
+	var refreshes int32
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Path == "/oauth/v2/token" {
+			atomic.AddInt32(&refreshes, 1)
+			// hold the refresh long enough for the other requests to wait for it
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte(`{"access_token":"new","expires_in":3600}`))
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Zoho-oauthtoken new" {
+			w.Write([]byte(fmt.Sprintf(`{"status":"error","message":"authorization %s"}`, got)))
+			return
+		}
+		w.Write([]byte(`{"data":[]}`))
+	}))
+	defer srv.Close()
+
+	dir, err := ioutil.TempDir("", "zoho")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	z := New()
+	z.CustomHTTPClient(srv.Client())
+	if err := z.SetBaseURL(srv.URL); err != nil {
+		t.Fatal(err)
+	}
+	z.SetTokensFile(filepath.Join(dir, "tokens"))
+	z.SetClientID("id")
+	z.SetClientSecret("secret")
+	expired := AccessTokenResponse{AccessToken: "old", RefreshToken: "refresh", ExpiresAt: time.Now().Add(-time.Hour)}
+	if err := z.SaveTokens(expired); err != nil {
+		t.Fatal(err)
+	}
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 10)
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var data map[string]interface{}
+			errs <- z.HTTPRequest(&Endpoint{
+				Name:         "records",
+				URL:          "https://www.zohoapis.com/crm/v2/Leads",
+				Method:       HTTPGet,
+				ResponseData: &data,
+			})
+		}()
+	}
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		if err != nil {
+			t.Error(err)
+		}
+	}
+	if refreshes != 1 {
+		t.Errorf("access token was refreshed %d times, want once", refreshes)
+	}
+
+	saved, err := z.LoadAccessAndRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.AccessToken != "new" || saved.RefreshToken != "refresh" {
+		t.Errorf("saved tokens %+v, want the refreshed access token and the refresh token", saved)
+	}
+}
+
+func TestSaveTokensFailure(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zoho")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	z := New()
+	z.SetTokensFile(filepath.Join(dir, "tokens"))
+	old := AccessTokenResponse{AccessToken: "old", RefreshToken: "refresh", ExpiresAt: time.Now().Add(time.Hour).Round(0)}
+	if err := z.SaveTokens(old); err != nil {
+		t.Fatal(err)
+	}
+
+	renameFile = func(oldpath, newpath string) error { return fmt.Errorf("disk full") }
+	defer func() { renameFile = os.Rename }()
+
+	if err := z.SaveTokens(AccessTokenResponse{AccessToken: "new", ExpiresIn: 3600}); err == nil {
+		t.Fatal("SaveTokens did not return the error of the rename")
+	}
+
+	saved, err := z.LoadAccessAndRefreshToken()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if saved.AccessToken != "old" || !saved.ExpiresAt.Equal(old.ExpiresAt) {
+		t.Errorf("loaded tokens %+v after a failed save, want %+v", saved, old)
+	}
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(files) != 1 {
+		t.Errorf("temporary file was not removed, found %d files", len(files))
+	}
+}
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"net/url"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/hashicorp/go-retryablehttp"
@@ -11,6 +14,24 @@ import (
 
 // New initializes a Zoho structure
 func New() *Zoho {
+	return newZoho(defaultHTTPClient())
+}
+
+func newZoho(client *http.Client) *Zoho {
+	z := Zoho{
+		client:     client,
+		ZohoTLD:    "com",
+		tokensFile: "./.tokens.zoho",
+		oauth: OAuth{
+			baseURL: "https://accounts.zoho.com/oauth/v2/",
+		},
+	}
+
+	return &z
+}
+
+// defaultHTTPClient returns the client used by New, which retries a failed request once
+func defaultHTTPClient() *http.Client {
 	retryClient := retryablehttp.NewClient()
 	retryClient.Logger = nil
 	retryClient.RetryMax = 1
@@ -23,34 +44,32 @@ func New() *Zoho {
 			TLSHandshakeTimeout: 5 * time.Second,
 		},
 	}
-
-	z := Zoho{
-		client:     retryClient.StandardClient(),
-		ZohoTLD:    "com",
-		tokensFile: "./.tokens.zoho",
-		oauth: OAuth{
-			baseURL: "https://accounts.zoho.com/oauth/v2/",
-		},
-	}
-
-	return &z
+	return retryClient.StandardClient()
 }
 
 // SetTokenManager can be used to provide a type which implements the TokenManager interface
 // which will get/set AccessTokens/RenewTokens using a persistence mechanism
 func (z *Zoho) SetTokenManager(tm TokenLoaderSaver) {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
 	z.tokenManager = tm
+	z.tokensLoaded = false
 }
 
 // SetTokensFile can be used to set the file location of the token persistence location,
 // by default tokens are stored in a file in the current directory called '.tokens.zoho'
 func (z *Zoho) SetTokensFile(s string) {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
 	z.tokensFile = s
+	z.tokensLoaded = false
 }
 
 // SetZohoTLD can be used to set the TLD extension for API calls for example for Zoho in EU and China.
 // by default this is set to "com", other options are "eu" and "ch"
 func (z *Zoho) SetZohoTLD(s string) {
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
 	z.ZohoTLD = s
 	z.oauth.baseURL = fmt.Sprintf("https://accounts.zoho.%s/oauth/v2/", s)
 }
@@ -64,6 +83,45 @@ func (z *Zoho) CustomHTTPClient(c *http.Client) {
 	z.client = c
 }
 
+// OriginalHostHeader is the header holding the host a request was sent to before SetBaseURL replaced it
+const OriginalHostHeader = "X-Zoho-Original-Host"
+
+// SetBaseURL sends every request, including the requests to Zoho Accounts, to the base URL instead of the Zoho
+// domains, eg. to a zohotest.Server or a proxy. The path of a request is appended to the path of the base URL and
+// its original host is sent in the X-Zoho-Original-Host header. An empty base URL restores the Zoho domains.
+func (z *Zoho) SetBaseURL(base string) error {
+	var u *url.URL
+	if base != "" {
+		var err error
+		u, err = url.Parse(base)
+		if err != nil || u.Scheme == "" || u.Host == "" {
+			return fmt.Errorf("Invalid base URL '%s'", base)
+		}
+	}
+
+	z.tokenMu.Lock()
+	defer z.tokenMu.Unlock()
+	z.baseURL = u
+	return nil
+}
+
+// rewriteRequest sends the request to the base URL set with SetBaseURL, if any
+func (z *Zoho) rewriteRequest(req *http.Request) {
+	z.tokenMu.Lock()
+	base := z.baseURL
+	z.tokenMu.Unlock()
+	if base == nil {
+		return
+	}
+
+	req.Header.Set(OriginalHostHeader, req.URL.Host)
+	req.URL.Scheme = base.Scheme
+	req.URL.Host = base.Host
+	req.URL.Path = strings.TrimSuffix(base.Path, "/") + req.URL.Path
+	req.URL.RawPath = ""
+	req.Host = base.Host
+}
+
 // SetOrganizationID can be used to add organization id in zoho struct
 // which is needed for expense apis
 func (z *Zoho) SetOrganizationID(orgID string) {
@@ -75,11 +133,23 @@ func (z *Zoho) SetOrganizationID(orgID string) {
 type Zoho struct {
 	oauth OAuth
 
+	// tokenMu guards oauth.token, tokensLoaded and refreshing, which are shared by concurrent requests
+	tokenMu      sync.Mutex
+	tokensLoaded bool
+	refreshing   *tokenRefresh
+
 	client         *http.Client
 	tokenManager   TokenLoaderSaver
 	tokensFile     string
 	OrganizationID string
 
+	// baseURL replaces the scheme and host of every request when set, see SetBaseURL
+	baseURL *url.URL
+
+	// middleware wraps the requests made to endpoints, see Use
+	middlewareMu sync.Mutex
+	middleware   []Middleware
+
 	ZohoTLD string
 }
 
@@ -91,4 +161,12 @@ type OAuth struct {
 	redirectURI  string
 	token        AccessTokenResponse
 	baseURL      string
+
+	// clientCredentials is set when tokens are obtained with the client credentials grant, which is repeated
+	// instead of refreshing the access token
+	clientCredentials bool
+	soid              string
+
+	// revoked is the token last revoked, which is not loaded again from a store that can not forget it
+	revoked string
 }
@@ -0,0 +1,486 @@
+package zohotest
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"mime"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"unicode/utf8"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// Mode selects whether a Cassette records or replays
+type Mode int
+
+const (
+	// ModeReplay replays the recorded interactions, requests which were not recorded fail
+	ModeReplay Mode = iota
+	// ModeRecord sends the requests to Zoho and records them, Save replaces the fixture
+	ModeRecord
+	// ModeAuto replays the fixture when it exists, and records it otherwise
+	ModeAuto
+)
+
+// Redacted replaces the tokens, credentials, emails and secrets in the fixtures of a Cassette
+const Redacted = "REDACTED"
+
+// RedactedNumber replaces the numbers holding secrets in the JSON bodies of the fixtures of a Cassette
+const RedactedNumber = "0"
+
+// RedactedEmail replaces the email addresses in the fixtures of a Cassette
+const RedactedEmail = "redacted@example.com"
+
+// Interaction is a recorded request and its response
+type Interaction struct {
+	Request  RecordedRequest  `json:"request"`
+	Response RecordedResponse `json:"response"`
+}
+
+// RecordedRequest is a request as matched by a Cassette, with its query and body normalized and redacted
+type RecordedRequest struct {
+	Method string `json:"method"`
+	Host   string `json:"host"`
+	Path   string `json:"path"`
+	Query  string `json:"query,omitempty"`
+	Body   string `json:"body,omitempty"`
+}
+
+// RecordedResponse is a redacted response
+type RecordedResponse struct {
+	Status      int    `json:"status"`
+	ContentType string `json:"content_type,omitempty"`
+	Body        string `json:"body,omitempty"`
+	// Base64 is set when the body is not text, eg. a file, and is encoded in base64
+	Base64 bool `json:"base64,omitempty"`
+}
+
+// Cassette is an http.RoundTripper which records the requests sent to Zoho and their responses to a fixture, and
+// replays them without network access. Requests are matched on their method, path, and normalized query and body.
+// Repeated requests replay the interactions recorded for them in order.
+//
+// Tokens, client credentials and email addresses are redacted from the fixture, as are the organization IDs sent
+// in the organization_id parameter or the organization headers. Other values, eg. the organization ID in the paths
+// of the Shifts API, can be added to Secrets. The *zoho.Zoho replaying a fixture needs an access token, which is
+// not matched, eg. from a zohotest.TokenStore.
+//
+//    cassette, err := zohotest.NewCassette("testdata/leads.json", zohotest.ModeAuto)
+//    defer cassette.Save()
+//    z.CustomHTTPClient(cassette.Client())
+type Cassette struct {
+	Path string
+	Mode Mode
+	// Transport sends the requests when recording, by default http.DefaultTransport
+	Transport http.RoundTripper
+	// Secrets are values replaced by Redacted in the fixture
+	Secrets []string
+
+	mu           sync.Mutex
+	interactions []Interaction
+	used         []bool
+	recorded     []rawInteraction
+	detected     []string
+}
+
+// rawInteraction is an interaction as it was recorded, it is redacted when saved, once every organization ID
+// sent by the requests is known
+type rawInteraction struct {
+	method      string
+	host        string
+	path        string
+	query       url.Values
+	contentType string
+	body        []byte
+
+	status          int
+	respContentType string
+	respBody        []byte
+}
+
+// NewCassette returns a Cassette for the fixture at the path, loading it unless recording
+func NewCassette(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{Path: path, Mode: mode}
+
+	if mode == ModeAuto {
+		c.Mode = ModeReplay
+		if _, err := os.Stat(path); os.IsNotExist(err) {
+			c.Mode = ModeRecord
+		}
+	}
+
+	if c.Mode == ModeReplay {
+		b, err := ioutil.ReadFile(path)
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read cassette: %s", err)
+		}
+		if err := json.Unmarshal(b, &c.interactions); err != nil {
+			return nil, fmt.Errorf("Failed to decode cassette %s: %s", path, err)
+		}
+		c.used = make([]bool, len(c.interactions))
+	}
+	return c, nil
+}
+
+// Client returns an *http.Client using the cassette, to be passed to zoho.CustomHTTPClient
+func (c *Cassette) Client() *http.Client {
+	return &http.Client{Transport: c}
+}
+
+// Recording reports whether the cassette records the requests, rather than replaying them
+func (c *Cassette) Recording() bool {
+	return c.Mode == ModeRecord
+}
+
+// RoundTrip implements http.RoundTripper
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	var body []byte
+	if req.Body != nil {
+		b, err := ioutil.ReadAll(req.Body)
+		req.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to read request body: %s", err)
+		}
+		body = b
+		req.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+
+	host := req.Header.Get(zoho.OriginalHostHeader)
+	if host == "" {
+		host = req.URL.Host
+	}
+	raw := rawInteraction{
+		method:      req.Method,
+		host:        host,
+		path:        req.URL.Path,
+		query:       req.URL.Query(),
+		contentType: req.Header.Get("Content-Type"),
+		body:        body,
+	}
+
+	c.mu.Lock()
+	c.detectSecrets(req)
+	c.mu.Unlock()
+
+	if !c.Recording() {
+		c.mu.Lock()
+		recorded := c.redactRequest(raw)
+		c.mu.Unlock()
+		return c.replay(req, recorded)
+	}
+
+	transport := c.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	resp, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+
+	respBody, err := ioutil.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to read response body: %s", err)
+	}
+	resp.Body = ioutil.NopCloser(bytes.NewReader(respBody))
+
+	raw.status = resp.StatusCode
+	raw.respContentType = resp.Header.Get("Content-Type")
+	raw.respBody = respBody
+	c.mu.Lock()
+	c.recorded = append(c.recorded, raw)
+	c.mu.Unlock()
+
+	return resp, nil
+}
+
+// replay returns the response of the first unused interaction matching the request
+func (c *Cassette) replay(req *http.Request, recorded RecordedRequest) (*http.Response, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for i, in := range c.interactions {
+		r := in.Request
+		if c.used[i] || r.Method != recorded.Method || r.Path != recorded.Path || r.Query != recorded.Query || r.Body != recorded.Body {
+			continue
+		}
+		c.used[i] = true
+
+		header := http.Header{}
+		if in.Response.ContentType != "" {
+			header.Set("Content-Type", in.Response.ContentType)
+		}
+		body := []byte(in.Response.Body)
+		if in.Response.Base64 {
+			b, err := base64.StdEncoding.DecodeString(in.Response.Body)
+			if err != nil {
+				return nil, fmt.Errorf("Failed to decode recorded body of %s %s: %s", r.Method, r.Path, err)
+			}
+			body = b
+		}
+		return &http.Response{
+			Status:        fmt.Sprintf("%d %s", in.Response.Status, http.StatusText(in.Response.Status)),
+			StatusCode:    in.Response.Status,
+			Proto:         "HTTP/1.1",
+			ProtoMajor:    1,
+			ProtoMinor:    1,
+			Header:        header,
+			Body:          ioutil.NopCloser(bytes.NewReader(body)),
+			ContentLength: int64(len(body)),
+			Request:       req,
+		}, nil
+	}
+
+	return nil, fmt.Errorf(
+		"Failed to replay %s %s?%s: no matching interaction was recorded in %s",
+		recorded.Method,
+		recorded.Path,
+		recorded.Query,
+		c.Path,
+	)
+}
+
+// Unused returns the recorded interactions which were not replayed, it returns none when recording
+func (c *Cassette) Unused() []Interaction {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	unused := []Interaction{}
+	for i, in := range c.interactions {
+		if i < len(c.used) && !c.used[i] {
+			unused = append(unused, in)
+		}
+	}
+	return unused
+}
+
+// Save redacts the recorded interactions and writes them to the fixture, it does nothing when replaying. The
+// organization IDs sent by any request are redacted from every interaction, including the earlier responses.
+func (c *Cassette) Save() error {
+	if !c.Recording() {
+		return nil
+	}
+
+	c.mu.Lock()
+	interactions := make([]Interaction, len(c.recorded))
+	for i, raw := range c.recorded {
+		resp := RecordedResponse{Status: raw.status, ContentType: raw.respContentType}
+		if utf8.Valid(raw.respBody) {
+			resp.Body = c.redactBody(raw.respContentType, raw.respBody)
+		} else {
+			resp.Body, resp.Base64 = base64.StdEncoding.EncodeToString(raw.respBody), true
+		}
+		interactions[i] = Interaction{Request: c.redactRequest(raw), Response: resp}
+	}
+	c.mu.Unlock()
+
+	var b bytes.Buffer
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	e.SetIndent("", "  ")
+	err := e.Encode(interactions)
+	if err != nil {
+		return fmt.Errorf("Failed to encode cassette: %s", err)
+	}
+
+	if err := os.MkdirAll(filepath.Dir(c.Path), 0755); err != nil {
+		return fmt.Errorf("Failed to create cassette directory: %s", err)
+	}
+	if err := ioutil.WriteFile(c.Path, b.Bytes(), 0644); err != nil {
+		return fmt.Errorf("Failed to write cassette: %s", err)
+	}
+	return nil
+}
+
+// sensitiveKeys are the JSON keys whose values are always redacted
+var sensitiveKeys = map[string]bool{
+	"access_token":  true,
+	"refresh_token": true,
+	"id_token":      true,
+	"client_id":     true,
+	"client_secret": true,
+}
+
+// sensitiveParams are the query parameters and form fields whose values are always redacted
+var sensitiveParams = map[string]bool{
+	"access_token":    true,
+	"refresh_token":   true,
+	"client_id":       true,
+	"client_secret":   true,
+	"code":            true,
+	"token":           true,
+	"zapikey":         true,
+	"soid":            true,
+	"state":           true,
+	"organization_id": true,
+}
+
+// organizationHeaders hold the organization ID of the request
+var organizationHeaders = []string{
+	"X-com-zoho-invoice-organizationid",
+	"X-com-zoho-subscriptions-organizationid",
+	"X-com-zoho-expense-organizationid",
+	"X-CRM-ORG",
+}
+
+var emailPattern = regexp.MustCompile(`[A-Za-z0-9._%+\-]+@[A-Za-z0-9.\-]+\.[A-Za-z]{2,}`)
+
+// detectSecrets adds the organization IDs of the request to the values redacted. The cassette must be locked.
+func (c *Cassette) detectSecrets(req *http.Request) {
+	values := []string{req.URL.Query().Get("organization_id")}
+	for _, h := range organizationHeaders {
+		values = append(values, req.Header.Get(h))
+	}
+	if soid := req.URL.Query().Get("soid"); strings.Contains(soid, ".") {
+		values = append(values, soid[strings.Index(soid, ".")+1:])
+	}
+
+	for _, v := range values {
+		if v == "" {
+			continue
+		}
+		found := false
+		for _, d := range c.detected {
+			if d == v {
+				found = true
+				break
+			}
+		}
+		if !found {
+			c.detected = append(c.detected, v)
+		}
+	}
+}
+
+// redactRequest returns the redacted request, with its query parameters sorted and its body in a canonical
+// form. The cassette must be locked.
+func (c *Cassette) redactRequest(raw rawInteraction) RecordedRequest {
+	return RecordedRequest{
+		Method: raw.method,
+		Host:   raw.host,
+		Path:   c.redactString(raw.path),
+		Query:  c.redactValues(raw.query).Encode(),
+		Body:   c.redactBody(raw.contentType, raw.body),
+	}
+}
+
+// redactBody returns the body with its sensitive values redacted. JSON, form and multipart bodies are
+// re-encoded with their fields sorted. The cassette must be locked.
+func (c *Cassette) redactBody(contentType string, body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+
+	mediaType, params, _ := mime.ParseMediaType(contentType)
+	switch {
+	case mediaType == "application/x-www-form-urlencoded":
+		if v, err := url.ParseQuery(string(body)); err == nil {
+			return c.redactValues(v).Encode()
+		}
+
+	case strings.HasPrefix(mediaType, "multipart/"):
+		fields := map[string]string{}
+		r := multipart.NewReader(bytes.NewReader(body), params["boundary"])
+		for {
+			part, err := r.NextPart()
+			if err != nil {
+				break
+			}
+			b, _ := ioutil.ReadAll(part)
+			name := part.FormName()
+			if part.FileName() != "" {
+				name += ";filename=" + part.FileName()
+			}
+			if sensitiveParams[strings.ToLower(part.FormName())] {
+				fields[name] = Redacted
+			} else {
+				fields[name] = c.redactBody(part.Header.Get("Content-Type"), b)
+			}
+		}
+		return marshalJSON(fields)
+	}
+
+	// numbers are kept as they were sent, large IDs would lose precision as floats
+	var v interface{}
+	d := json.NewDecoder(bytes.NewReader(body))
+	d.UseNumber()
+	if err := d.Decode(&v); err == nil && !d.More() {
+		return marshalJSON(c.redactJSON(v))
+	}
+	return c.redactString(string(body))
+}
+
+// redactJSON redacts the sensitive keys and the strings of a decoded JSON value. The cassette must be locked.
+func (c *Cassette) redactJSON(v interface{}) interface{} {
+	switch t := v.(type) {
+	case map[string]interface{}:
+		for k, value := range t {
+			if sensitiveKeys[strings.ToLower(k)] {
+				t[k] = Redacted
+				continue
+			}
+			t[k] = c.redactJSON(value)
+		}
+	case []interface{}:
+		for i, value := range t {
+			t[i] = c.redactJSON(value)
+		}
+	case string:
+		return c.redactString(t)
+	case json.Number:
+		// a number holding a secret, eg. a numeric organization ID, is replaced by a number so the response
+		// still decodes into the same types
+		if c.redactString(string(t)) != string(t) {
+			return json.Number(RedactedNumber)
+		}
+	}
+	return v
+}
+
+// redactValues redacts query parameters or form fields. The cassette must be locked.
+func (c *Cassette) redactValues(values url.Values) url.Values {
+	redacted := url.Values{}
+	for k, vs := range values {
+		for _, v := range vs {
+			if sensitiveParams[strings.ToLower(k)] {
+				v = Redacted
+			}
+			redacted.Add(k, c.redactString(v))
+		}
+	}
+	return redacted
+}
+
+// redactString replaces the email addresses and secrets, the longest secrets first. The cassette must be locked.
+func (c *Cassette) redactString(s string) string {
+	secrets := append(append([]string{}, c.Secrets...), c.detected...)
+	sort.SliceStable(secrets, func(i, j int) bool {
+		return len(secrets[i]) > len(secrets[j])
+	})
+	for _, secret := range secrets {
+		if secret != "" {
+			s = strings.Replace(s, secret, Redacted, -1)
+		}
+	}
+	return emailPattern.ReplaceAllString(s, RedactedEmail)
+}
+
+// marshalJSON encodes the value with its object keys sorted, without escaping HTML characters
+func marshalJSON(v interface{}) string {
+	var b bytes.Buffer
+	e := json.NewEncoder(&b)
+	e.SetEscapeHTML(false)
+	e.Encode(v)
+	return strings.TrimSuffix(b.String(), "\n")
+}
@@ -0,0 +1,158 @@
+package zohotest
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/crm"
+)
+
+// tempCassette returns the path of a fixture in a new directory, and a function removing the directory
+func tempCassette(t *testing.T) (string, func()) {
+	dir, err := ioutil.TempDir("", "zohotest")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return filepath.Join(dir, "fixtures", "cassette.json"), func() { os.RemoveAll(dir) }
+}
+
+func TestCassetteRedactsOrganizationIDFromEarlierResponses(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/organizations":
+			w.Write([]byte(`{"organizations":[{"organization_id":"60012345","id":60012345,"email":"owner@acme.com"}]}`))
+		default:
+			w.Write([]byte(`{"code":0,"invoices":[]}`))
+		}
+	}))
+	defer srv.Close()
+
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+	cassette, err := NewCassette(path, ModeRecord)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cassette.Transport = srv.Client().Transport
+	client := cassette.Client()
+
+	for _, u := range []string{srv.URL + "/organizations", srv.URL + "/invoices?organization_id=60012345"} {
+		resp, err := client.Get(u)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+	}
+	if err := cassette.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"60012345", "owner@acme.com"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("fixture contains %s:\n%s", secret, b)
+		}
+	}
+
+	var interactions []Interaction
+	if err := json.Unmarshal(b, &interactions); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"organizations":[{"email":"redacted@example.com","id":0,"organization_id":"REDACTED"}]}`
+	if got := interactions[0].Response.Body; got != want {
+		t.Errorf("got response %s, want %s", got, want)
+	}
+}
+
+func TestCassetteRecordReplay(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+
+	path, cleanup := tempCassette(t)
+	defer cleanup()
+	record := func(z *zoho.Zoho) []map[string]interface{} {
+		c := crm.New(z)
+		_, err := c.InsertRecords(crm.InsertRecordsData{
+			Data: []map[string]interface{}{{"Last_Name": "Smith", "Email": "john@acme.com"}},
+		}, crm.LeadsModule)
+		if err != nil {
+			t.Fatal(err)
+		}
+		res, err := c.ListRecords(&crm.COQLResponse{}, crm.LeadsModule, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res.(*crm.COQLResponse).Data
+	}
+
+	cassette, err := NewCassette(path, ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cassette.Recording() {
+		t.Fatal("cassette without a fixture should record")
+	}
+	cassette.Transport = srv.Client().Transport
+
+	z := srv.Zoho()
+	z.CustomHTTPClient(cassette.Client())
+	// an expired token is refreshed, so the token request is recorded too
+	z.SetTokenManager(&TokenStore{Token: zoho.AccessTokenResponse{
+		RefreshToken: "zohotest-refresh-token",
+		ExpiresAt:    time.Now().Add(-time.Hour),
+	}})
+	recorded := record(z)
+	if err := cassette.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	b, _ := ioutil.ReadFile(path)
+	for _, secret := range []string{"zohotest-access-token", "zohotest-refresh-token", "zohotest-client", "john@acme.com"} {
+		if strings.Contains(string(b), secret) {
+			t.Errorf("fixture contains %s", secret)
+		}
+	}
+
+	// replay with other credentials and no server
+	replay, err := NewCassette(path, ModeAuto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if replay.Recording() {
+		t.Fatal("cassette with a fixture should replay")
+	}
+	z = zoho.New()
+	z.SetBaseURL("http://127.0.0.1:1")
+	z.SetClientID("other-client")
+	z.SetClientSecret("other-secret")
+	z.SetTokenManager(&TokenStore{Token: zoho.AccessTokenResponse{
+		RefreshToken: "other-refresh-token",
+		ExpiresAt:    time.Now().Add(-time.Hour),
+	}})
+	z.CustomHTTPClient(replay.Client())
+
+	replayed := record(z)
+	if len(replayed) != 1 || replayed[0]["Last_Name"] != recorded[0]["Last_Name"] || replayed[0]["id"] != recorded[0]["id"] {
+		t.Errorf("replayed %v, recorded %v", replayed, recorded)
+	}
+	if unused := replay.Unused(); len(unused) != 0 {
+		t.Errorf("%d interactions were not replayed", len(unused))
+	}
+
+	// every interaction was used, a further request was not recorded
+	if _, err := crm.New(z).ListRecords(&crm.COQLResponse{}, crm.LeadsModule, nil); err == nil {
+		t.Error("request which was not recorded did not fail")
+	}
+}
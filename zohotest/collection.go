@@ -0,0 +1,146 @@
+package zohotest
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// collection holds the records of a module or resource in the order they were created
+type collection struct {
+	idField string
+	ids     []string
+	records map[string]Record
+}
+
+func newCollection(idField string) *collection {
+	return &collection{idField: idField, records: map[string]Record{}}
+}
+
+// idField returns the name of the ID field of the records of a collection, eg. "contact_id" for "books/contacts"
+func idField(name string) string {
+	parts := strings.SplitN(name, "/", 2)
+	switch parts[0] {
+	case "books", "invoice", "subscriptions":
+		if len(parts) == 2 {
+			return financeResource(parts[1]).idField
+		}
+	}
+	return "id"
+}
+
+// put adds the record, or replaces the record with the same ID, and returns its ID
+func (c *collection) put(r Record) string {
+	id := fmt.Sprint(r[c.idField])
+	if _, ok := c.records[id]; !ok {
+		c.ids = append(c.ids, id)
+	}
+	c.records[id] = r
+	return id
+}
+
+func (c *collection) get(id string) (Record, bool) {
+	r, ok := c.records[id]
+	return r, ok
+}
+
+func (c *collection) delete(id string) bool {
+	if _, ok := c.records[id]; !ok {
+		return false
+	}
+	delete(c.records, id)
+	for i, v := range c.ids {
+		if v == id {
+			c.ids = append(c.ids[:i], c.ids[i+1:]...)
+			break
+		}
+	}
+	return true
+}
+
+// list returns copies of the records
+func (c *collection) list() []Record {
+	records := make([]Record, 0, len(c.ids))
+	for _, id := range c.ids {
+		r := Record{}
+		for k, v := range c.records[id] {
+			r[k] = v
+		}
+		records = append(records, r)
+	}
+	return records
+}
+
+// page returns the records of the page, from 1, and whether there are more records
+func page(records []Record, page, perPage int) ([]Record, bool) {
+	if page < 1 {
+		page = 1
+	}
+	if perPage < 1 {
+		perPage = 200
+	}
+
+	start := (page - 1) * perPage
+	if start >= len(records) {
+		return []Record{}, false
+	}
+	end := start + perPage
+	if end > len(records) {
+		end = len(records)
+	}
+	return records[start:end], end < len(records)
+}
+
+func intParam(v string, def int) int {
+	if i, err := strconv.Atoi(v); err == nil {
+		return i
+	}
+	return def
+}
+
+// merge sets the fields of update on the record
+func merge(r, update Record) {
+	for k, v := range update {
+		r[k] = v
+	}
+}
+
+// sameValue reports whether two decoded JSON values are equal, comparing strings case-insensitively
+func sameValue(a, b interface{}) bool {
+	as, bs := formatValue(a), formatValue(b)
+	return as != "" && strings.EqualFold(as, bs)
+}
+
+func formatValue(v interface{}) string {
+	switch t := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return t
+	case map[string]interface{}:
+		// lookups are compared by ID
+		if id, ok := t["id"]; ok {
+			return formatValue(id)
+		}
+	}
+	b, _ := json.Marshal(v)
+	return strings.Trim(string(b), `"`)
+}
+
+// decodeRecords decodes the records of a {"data": [...]} body, or of a body holding a single record
+func decodeRecords(body []byte, key string) ([]Record, error) {
+	if key == "" {
+		var r Record
+		if err := json.Unmarshal(body, &r); err != nil {
+			return nil, err
+		}
+		return []Record{r}, nil
+	}
+
+	var v map[string][]Record
+	if err := json.Unmarshal(body, &v); err != nil {
+		return nil, err
+	}
+	return v[key], nil
+}
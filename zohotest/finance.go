@@ -0,0 +1,191 @@
+package zohotest
+
+import (
+	"net/http"
+	"strings"
+)
+
+// resource describes how a resource of the finance APIs is returned
+type resource struct {
+	// listKey holds the records of a list, eg. "contacts"
+	listKey string
+	// itemKey holds a single record, eg. "contact"
+	itemKey string
+	idField string
+}
+
+// financeResources lists the resources of Books, Invoice and Subscriptions whose keys do not follow the
+// default of financeResource
+var financeResources = map[string]resource{
+	"contacts":          {"contacts", "contact", "contact_id"},
+	"contactpersons":    {"contact_persons", "contact_person", "contact_person_id"},
+	"invoices":          {"invoices", "invoice", "invoice_id"},
+	"items":             {"items", "item", "item_id"},
+	"recurringinvoices": {"recurring_invoices", "recurring_invoice", "recurring_invoice_id"},
+	"customerpayments":  {"customerpayments", "payment", "payment_id"},
+	"customers":         {"customers", "customer", "customer_id"},
+	"subscriptions":     {"subscriptions", "subscription", "subscription_id"},
+	"plans":             {"plans", "plan", "plan_code"},
+	"addons":            {"addons", "addon", "addon_code"},
+	"coupons":           {"coupons", "coupon", "coupon_code"},
+	"hostedpages":       {"hostedpages", "hostedpage", "hostedpage_id"},
+	"estimates":         {"estimates", "estimate", "estimate_id"},
+	"salesorders":       {"salesorders", "salesorder", "salesorder_id"},
+	"purchaseorders":    {"purchaseorders", "purchaseorder", "purchaseorder_id"},
+	"bills":             {"bills", "bill", "bill_id"},
+	"creditnotes":       {"creditnotes", "creditnote", "creditnote_id"},
+	"projects":          {"projects", "project", "project_id"},
+	"expenses":          {"expenses", "expense", "expense_id"},
+	"users":             {"users", "user", "user_id"},
+}
+
+// financeResource returns how the resource is returned, by default the list key is the resource name and the
+// item key is the name without its trailing s, eg. "taxes" holds "tax" records identified by "tax_id"
+func financeResource(name string) resource {
+	if name == "contacts/contactpersons" {
+		name = "contactpersons"
+	}
+	if r, ok := financeResources[name]; ok {
+		return r
+	}
+	item := strings.TrimSuffix(name, "s")
+	if strings.HasSuffix(name, "xes") || strings.HasSuffix(name, "ses") {
+		item = strings.TrimSuffix(name, "es")
+	}
+	return resource{listKey: name, itemKey: item, idField: item + "_id"}
+}
+
+// serveFinance handles the APIs of Books, Invoice and Subscriptions, which share their routes and response format
+func (s *Server) serveFinance(w http.ResponseWriter, r *http.Request, product string, body []byte) {
+	path := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, "/api/v3/"), "/api/v1/")
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	name, rest := parts[0], parts[1:]
+	if name == "contacts" && len(rest) > 0 && rest[0] == "contactpersons" {
+		name, rest = "contactpersons", rest[1:]
+	}
+	if name == "users" && len(rest) == 1 && rest[0] == "me" {
+		writeJSON(w, http.StatusOK, Record{
+			"code":    0,
+			"message": "success",
+			"user": Record{
+				"user_id":   formatValue(s.User.ZUID),
+				"name":      s.User.DisplayName,
+				"email_ids": []Record{{"email": s.User.Email, "is_selected": true}},
+				"status":    "active",
+			},
+		})
+		return
+	}
+
+	res := financeResource(name)
+	collectionName := product + "/" + name
+
+	if r.Method == http.MethodPost || r.Method == http.MethodPut {
+		if strings.HasPrefix(r.Header.Get("Content-Type"), "multipart/form-data") {
+			body = []byte(r.FormValue("JSONString"))
+		}
+	}
+
+	switch {
+	case len(rest) == 0 && r.Method == http.MethodGet:
+		s.mu.Lock()
+		records := s.collection(collectionName).list()
+		s.mu.Unlock()
+
+		q := r.URL.Query()
+		records = filterRecords(records, func(rec Record) bool {
+			for k := range q {
+				if _, ok := rec[k]; ok && !sameValue(rec[k], q.Get(k)) {
+					return false
+				}
+			}
+			return true
+		})
+		p, perPage := intParam(q.Get("page"), 1), intParam(q.Get("per_page"), 200)
+		records, more := page(records, p, perPage)
+		writeJSON(w, http.StatusOK, Record{
+			"code":         0,
+			"message":      "success",
+			res.listKey:    records,
+			"page_context": Record{"page": p, "per_page": perPage, "has_more_page": more},
+		})
+
+	case len(rest) == 0 && r.Method == http.MethodPost:
+		records, err := decodeRecords(body, "")
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, Record{"code": 4, "message": "Invalid value passed for JSONString"})
+			return
+		}
+
+		s.mu.Lock()
+		if field, ok := s.recordFails(collectionName, records[0]); ok {
+			s.mu.Unlock()
+			writeJSON(w, http.StatusBadRequest, Record{"code": 2, "message": "Invalid value passed for " + field})
+			return
+		}
+		c := s.collection(collectionName)
+		rec := s.copyWithID(records[0], c.idField)
+		c.put(rec)
+		s.mu.Unlock()
+
+		writeJSON(w, http.StatusCreated, Record{
+			"code":      0,
+			"message":   "The " + strings.Replace(res.itemKey, "_", " ", -1) + " has been created.",
+			res.itemKey: rec,
+		})
+
+	case len(rest) == 1:
+		s.serveFinanceRecord(w, r, collectionName, res, rest[0], body)
+
+	default:
+		// actions such as marking as sent or emailing
+		writeJSON(w, http.StatusOK, Record{"code": 0, "message": "success"})
+	}
+}
+
+// serveFinanceRecord handles the requests on a single record of the finance APIs
+func (s *Server) serveFinanceRecord(w http.ResponseWriter, r *http.Request, name string, res resource, id string, body []byte) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.collection(name)
+	rec, ok := c.get(id)
+	if !ok {
+		writeJSON(w, http.StatusNotFound, Record{"code": 1002, "message": strings.Replace(res.itemKey, "_", " ", -1) + " does not exist."})
+		return
+	}
+
+	switch r.Method {
+	case http.MethodGet:
+		writeJSON(w, http.StatusOK, Record{"code": 0, "message": "success", res.itemKey: rec})
+
+	case http.MethodPut:
+		records, err := decodeRecords(body, "")
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, Record{"code": 4, "message": "Invalid value passed for JSONString"})
+			return
+		}
+		if field, ok := s.recordFails(name, records[0]); ok {
+			writeJSON(w, http.StatusBadRequest, Record{"code": 2, "message": "Invalid value passed for " + field})
+			return
+		}
+		merge(rec, records[0])
+		rec[c.idField] = id
+		writeJSON(w, http.StatusOK, Record{
+			"code":      0,
+			"message":   "The " + strings.Replace(res.itemKey, "_", " ", -1) + " has been updated.",
+			res.itemKey: rec,
+		})
+
+	case http.MethodDelete:
+		c.delete(id)
+		writeJSON(w, http.StatusOK, Record{
+			"code":    0,
+			"message": "The " + strings.Replace(res.itemKey, "_", " ", -1) + " has been deleted.",
+		})
+
+	default:
+		writeJSON(w, http.StatusOK, Record{"code": 0, "message": "success"})
+	}
+}
@@ -0,0 +1,126 @@
+package zohotest
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// serveOAuth handles the token, revoke and user info endpoints of Zoho Accounts
+func (s *Server) serveOAuth(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+
+	switch r.URL.Path {
+	case "/oauth/v2/token":
+		switch q.Get("grant_type") {
+		case "authorization_code":
+			if q.Get("code") == "" || q.Get("code") == "invalid" {
+				writeJSON(w, http.StatusOK, map[string]string{"error": "invalid_code"})
+				return
+			}
+			t := s.issueToken()
+			writeJSON(w, http.StatusOK, tokenResponse(t, true))
+
+		case "refresh_token":
+			s.mu.Lock()
+			valid := s.refreshToken != "" && q.Get("refresh_token") == s.refreshToken
+			s.mu.Unlock()
+			if !valid {
+				writeJSON(w, http.StatusOK, map[string]string{"error": "invalid_code"})
+				return
+			}
+			writeJSON(w, http.StatusOK, tokenResponse(s.issueToken(), false))
+
+		case "client_credentials":
+			writeJSON(w, http.StatusOK, tokenResponse(s.issueToken(), false))
+
+		default:
+			writeJSON(w, http.StatusBadRequest, map[string]string{"error": "unsupported_grant_type"})
+		}
+
+	case "/oauth/v2/token/revoke":
+		s.mu.Lock()
+		if q.Get("token") == s.refreshToken {
+			s.refreshToken = ""
+			s.accessTokens = map[string]bool{}
+		}
+		delete(s.accessTokens, q.Get("token"))
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]string{"status": "success"})
+
+	case "/oauth/user/info":
+		if !s.authorized(r) {
+			writeJSON(w, http.StatusUnauthorized, map[string]string{"code": "INVALID_OAUTHTOKEN", "status": "error"})
+			return
+		}
+		writeJSON(w, http.StatusOK, s.User)
+
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// issueToken issues a new access token, and a refresh token if it was revoked
+func (s *Server) issueToken() zoho.AccessTokenResponse {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.nextID++
+	access := fmt.Sprintf("zohotest-access-token-%d", s.nextID)
+	s.accessTokens[access] = true
+	if s.refreshToken == "" {
+		s.refreshToken = fmt.Sprintf("zohotest-refresh-token-%d", s.nextID)
+	}
+
+	return zoho.AccessTokenResponse{
+		AccessToken:  access,
+		RefreshToken: s.refreshToken,
+		ExpiresIn:    s.TokenExpiresIn,
+		ExpiresAt:    time.Now().Add(time.Duration(s.TokenExpiresIn) * time.Second),
+		APIDomain:    "https://www.zohoapis.com",
+		TokenType:    "Bearer",
+	}
+}
+
+func tokenResponse(t zoho.AccessTokenResponse, withRefresh bool) map[string]interface{} {
+	v := map[string]interface{}{
+		"access_token": t.AccessToken,
+		"expires_in":   t.ExpiresIn,
+		"api_domain":   t.APIDomain,
+		"token_type":   t.TokenType,
+	}
+	if withRefresh {
+		v["refresh_token"] = t.RefreshToken
+	}
+	return v
+}
+
+// TokenStore is a zoho.TokenLoaderSaver holding the tokens in memory
+type TokenStore struct {
+	mu    sync.Mutex
+	Token zoho.AccessTokenResponse
+}
+
+// SaveTokens holds the tokens
+func (t *TokenStore) SaveTokens(token zoho.AccessTokenResponse) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.Token = token
+	return nil
+}
+
+// LoadAccessAndRefreshToken returns the tokens held
+func (t *TokenStore) LoadAccessAndRefreshToken() (zoho.AccessTokenResponse, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.Token.AccessToken == "" && t.Token.RefreshToken == "" {
+		return zoho.AccessTokenResponse{}, fmt.Errorf("No tokens are held")
+	}
+	if !t.Token.ExpiresAt.IsZero() && t.Token.ExpiresAt.Before(time.Now()) {
+		return t.Token, zoho.ErrTokenExpired
+	}
+	return t.Token, nil
+}
@@ -0,0 +1,1000 @@
+package zohotest
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// timeLayout is the layout of the date-time fields set by the server
+const timeLayout = "2006-01-02T15:04:05-07:00"
+
+// serveRecords handles the record APIs of CRM and Recruit, which share their routes and response format
+func (s *Server) serveRecords(w http.ResponseWriter, r *http.Request, product string, body []byte) {
+	path := r.URL.Path
+	path = strings.TrimPrefix(path, "/"+product)
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+	// remove the version, eg. v2 or v2.1
+	if len(parts) > 0 && strings.HasPrefix(parts[0], "v") {
+		parts = parts[1:]
+	}
+	if len(parts) == 0 || parts[0] == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch parts[0] {
+	case "coql":
+		s.serveCOQL(w, r, product, body)
+		return
+	case "users", "org":
+		s.serveList(w, r, product+"/"+parts[0], parts[0], parts[1:], body)
+		return
+	case "settings":
+		if len(parts) < 2 {
+			http.NotFound(w, r)
+			return
+		}
+		s.serveList(w, r, product+"/settings/"+parts[1], parts[1], parts[2:], body)
+		return
+	}
+
+	name := product + "/" + parts[0]
+	switch {
+	case len(parts) == 1:
+		switch r.Method {
+		case http.MethodGet:
+			s.listRecords(w, r, name)
+		case http.MethodPost:
+			s.writeRecords(w, r, name, body, false, nil)
+		case http.MethodPut:
+			s.writeRecords(w, r, name, body, true, nil)
+		case http.MethodDelete:
+			s.deleteRecords(w, name, strings.Split(r.URL.Query().Get("ids"), ","))
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+
+	case parts[1] == "upsert":
+		fields := strings.Split(r.URL.Query().Get("duplicate_field_check"), ",")
+		s.writeRecords(w, r, name, body, false, fields)
+
+	case parts[1] == "search":
+		s.searchRecords(w, r, name)
+
+	case parts[1] == "deleted":
+		s.mu.Lock()
+		records := s.collection(name + "#deleted").list()
+		s.mu.Unlock()
+		writePage(w, r, "data", records)
+
+	case parts[1] == "actions":
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": []interface{}{recordResult("SUCCESS", "success", "action performed", Record{})},
+		})
+
+	case len(parts) == 2:
+		s.serveRecord(w, r, name, parts[1], body)
+
+	case len(parts) == 3 && parts[2] == "Notes" && r.Method == http.MethodGet:
+		// the notes of a record are the notes held in the Notes module with its id as their Parent_Id
+		s.mu.Lock()
+		notes := filterRecords(s.collection(product+"/Notes").list(), func(rec Record) bool {
+			parent := rec["Parent_Id"]
+			if lookup, ok := parent.(map[string]interface{}); ok {
+				parent = lookup["id"]
+			}
+			return formatValue(parent) == parts[1]
+		})
+		s.mu.Unlock()
+		writePage(w, r, "data", notes)
+
+	case name == "crm/Leads" && len(parts) == 4 && parts[2] == "actions" && parts[3] == "convert":
+		s.convertLead(w, parts[1], body)
+
+	default:
+		// related lists, attachments etc. are not held, reading them returns no records
+		if r.Method == http.MethodGet {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{
+			"data": []interface{}{recordResult("SUCCESS", "success", "record updated", Record{})},
+		})
+	}
+}
+
+// convertLead converts the lead to a contact and an account, or merges it into the contact and account of the
+// request, and creates the deal of the request. The contact and account created take the name, company and email
+// of the lead. The lead is deleted, and the IDs are returned like the v2.1 API.
+func (s *Server) convertLead(w http.ResponseWriter, id string, body []byte) {
+	records, err := decodeRecords(body, "data")
+	if err != nil || len(records) == 0 {
+		writeJSON(w, http.StatusBadRequest, errorBody("INVALID_DATA", "data is required"))
+		return
+	}
+	request := records[0]
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	lead, ok := s.collection("crm/Leads").get(id)
+	if !ok {
+		writeJSON(w, http.StatusBadRequest, map[string]interface{}{
+			"data": []interface{}{errorBody("INVALID_DATA", "the lead does not exist")},
+		})
+		return
+	}
+
+	convert := func(module string, fields map[string]string) Record {
+		if id := formatValue(request[module]); id != "" {
+			return Record{"id": id}
+		}
+		rec := Record{}
+		for from, to := range fields {
+			if v, ok := lead[from]; ok {
+				rec[to] = v
+			}
+		}
+		rec = s.copyWithID(rec, "id")
+		s.collection("crm/" + module).put(rec)
+		return Record{"id": rec["id"]}
+	}
+	details := Record{
+		"Contacts": convert("Contacts", map[string]string{"First_Name": "First_Name", "Last_Name": "Last_Name", "Email": "Email"}),
+		"Accounts": convert("Accounts", map[string]string{"Company": "Account_Name"}),
+		"Deals":    nil,
+	}
+	if deal, ok := request["Deals"].(map[string]interface{}); ok {
+		rec := s.copyWithID(Record(deal), "id")
+		s.collection("crm/Deals").put(rec)
+		details["Deals"] = Record{"id": rec["id"]}
+	}
+	s.collection("crm/Leads").delete(id)
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": []interface{}{recordResult("SUCCESS", "success", "Lead converted successfully", details)},
+	})
+}
+
+// serveRecord handles the requests on a single record
+func (s *Server) serveRecord(w http.ResponseWriter, r *http.Request, name, id string, body []byte) {
+	switch r.Method {
+	case http.MethodGet:
+		s.mu.Lock()
+		rec, ok := s.collection(name).get(id)
+		s.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{"data": []Record{rec}})
+
+	case http.MethodPut:
+		s.writeRecords(w, r, name, body, true, nil, id)
+
+	case http.MethodDelete:
+		s.deleteRecords(w, name, []string{id})
+
+	default:
+		w.WriteHeader(http.StatusMethodNotAllowed)
+	}
+}
+
+// serveList handles the users, org and settings APIs, which return the records under their own key
+func (s *Server) serveList(w http.ResponseWriter, r *http.Request, name, key string, rest []string, body []byte) {
+	s.mu.Lock()
+	c := s.collection(name)
+	s.mu.Unlock()
+
+	if len(rest) > 0 && r.Method == http.MethodGet {
+		s.mu.Lock()
+		rec, ok := c.get(rest[0])
+		s.mu.Unlock()
+		if !ok {
+			w.WriteHeader(http.StatusNoContent)
+			return
+		}
+		writeJSON(w, http.StatusOK, map[string]interface{}{key: []Record{rec}})
+		return
+	}
+
+	if r.Method != http.MethodGet {
+		records, err := decodeRecords(body, key)
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorBody("INVALID_REQUEST_METHOD", err.Error()))
+			return
+		}
+		results := make([]interface{}, 0, len(records))
+		s.mu.Lock()
+		for _, rec := range records {
+			if len(rest) > 0 {
+				rec[c.idField] = rest[0]
+			}
+			if old, ok := c.get(formatValue(rec[c.idField])); ok {
+				merge(old, rec)
+				rec = old
+			}
+			rec = s.copyWithID(rec, c.idField)
+			c.put(rec)
+			results = append(results, recordResult("SUCCESS", "success", "record saved", rec))
+		}
+		s.mu.Unlock()
+		writeJSON(w, http.StatusOK, map[string]interface{}{key: results})
+		return
+	}
+
+	s.mu.Lock()
+	records := c.list()
+	s.mu.Unlock()
+	writePage(w, r, key, records)
+}
+
+// listRecords returns a page of the records, or the records of the ids parameter
+func (s *Server) listRecords(w http.ResponseWriter, r *http.Request, name string) {
+	s.mu.Lock()
+	records := s.collection(name).list()
+	s.mu.Unlock()
+
+	if ids := r.URL.Query().Get("ids"); ids != "" {
+		records = filterRecords(records, func(rec Record) bool {
+			return matches(rec, "id", "in", strings.Split(ids, ","))
+		})
+	}
+	writePage(w, r, "data", selectFields(records, r.URL.Query().Get("fields")))
+}
+
+// writeRecords inserts, updates or upserts the records of the {"data": [...]} body. When upserting, an existing
+// record with the same values for the duplicate check fields is updated. The id is used when updating a single
+// record by its URL.
+func (s *Server) writeRecords(w http.ResponseWriter, r *http.Request, name string, body []byte, update bool, duplicateCheck []string, id ...string) {
+	records, err := decodeRecords(body, "data")
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody("INVALID_DATA", err.Error()))
+		return
+	}
+	if len(records) == 0 {
+		writeJSON(w, http.StatusBadRequest, errorBody("INVALID_DATA", "the data is empty"))
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.collection(name)
+	now := time.Now().Format(timeLayout)
+	results := make([]interface{}, 0, len(records))
+	failed := 0
+	created := 0
+
+	for i, rec := range records {
+		if i == 0 && len(id) > 0 {
+			rec["id"] = id[0]
+		}
+
+		if field, ok := s.recordFails(name, rec); ok {
+			failed++
+			res := recordResult("INVALID_DATA", "error", "invalid data", Record{"api_name": field})
+			results = append(results, res)
+			continue
+		}
+
+		existing, found := Record(nil), false
+		if recID := formatValue(rec["id"]); recID != "" {
+			existing, found = c.get(recID)
+		} else if duplicateCheck != nil {
+			existing, found = findDuplicate(c, rec, duplicateCheck)
+		}
+
+		switch {
+		case found:
+			merge(existing, rec)
+			existing["Modified_Time"] = now
+			rec = existing
+		case update:
+			failed++
+			res := recordResult("INVALID_DATA", "error", "the id given seems to be invalid", Record{"api_name": "id"})
+			results = append(results, res)
+			continue
+		default:
+			rec = s.copyWithID(rec, "id")
+			rec["Created_Time"] = now
+			rec["Modified_Time"] = now
+			created++
+		}
+		c.put(rec)
+
+		message := "record updated"
+		if !found {
+			message = "record added"
+		}
+		results = append(results, recordResult("SUCCESS", "success", message, Record{
+			"id":            rec["id"],
+			"Created_Time":  rec["Created_Time"],
+			"Modified_Time": rec["Modified_Time"],
+		}))
+	}
+
+	writeJSON(w, resultStatus(len(records), failed, created > 0), map[string]interface{}{"data": results})
+}
+
+// deleteRecords deletes the records, moving them to the recycle bin returned by the deleted route
+func (s *Server) deleteRecords(w http.ResponseWriter, name string, ids []string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.collection(name)
+	deleted := s.collection(name + "#deleted")
+	results := make([]interface{}, 0, len(ids))
+	failed := 0
+
+	for _, id := range ids {
+		rec, ok := c.get(id)
+		if !ok || !c.delete(id) {
+			failed++
+			results = append(results, recordResult("INVALID_DATA", "error", "the id given seems to be invalid", Record{"id": id}))
+			continue
+		}
+		deleted.put(Record{
+			"id":           id,
+			"display_name": rec["Last_Name"],
+			"type":         "recycle",
+			"deleted_time": time.Now().Format(timeLayout),
+		})
+		results = append(results, recordResult("SUCCESS", "success", "record deleted", Record{"id": id}))
+	}
+
+	writeJSON(w, resultStatus(len(ids), failed, false), map[string]interface{}{"data": results})
+}
+
+// searchRecords returns the records matching the criteria, email, phone or word parameter
+func (s *Server) searchRecords(w http.ResponseWriter, r *http.Request, name string) {
+	q := r.URL.Query()
+
+	var match func(Record) bool
+	switch {
+	case q.Get("criteria") != "":
+		cond, err := parseSearchCriteria(q.Get("criteria"))
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, errorBody("INVALID_QUERY", err.Error()))
+			return
+		}
+		match = cond.match
+	case q.Get("email") != "":
+		match = anyField(q.Get("email"), "email")
+	case q.Get("phone") != "":
+		match = anyField(q.Get("phone"), "phone")
+	case q.Get("word") != "":
+		word := strings.ToLower(q.Get("word"))
+		match = func(rec Record) bool {
+			for _, v := range rec {
+				if strings.Contains(strings.ToLower(formatValue(v)), word) {
+					return true
+				}
+			}
+			return false
+		}
+	default:
+		writeJSON(w, http.StatusBadRequest, errorBody("REQUIRED_PARAM_MISSING", "one of criteria, email, phone or word is required"))
+		return
+	}
+
+	s.mu.Lock()
+	records := s.collection(name).list()
+	s.mu.Unlock()
+	writePage(w, r, "data", filterRecords(records, match))
+}
+
+// serveCOQL runs the select query of the {"select_query": "..."} body
+func (s *Server) serveCOQL(w http.ResponseWriter, r *http.Request, product string, body []byte) {
+	records, err := decodeRecords(body, "")
+	if err != nil || formatValue(records[0]["select_query"]) == "" {
+		writeJSON(w, http.StatusBadRequest, errorBody("INVALID_QUERY", "select_query is required"))
+		return
+	}
+
+	q, err := parseCOQL(formatValue(records[0]["select_query"]))
+	if err != nil {
+		writeJSON(w, http.StatusBadRequest, errorBody("SYNTAX_ERROR", err.Error()))
+		return
+	}
+
+	s.mu.Lock()
+	result := filterRecords(s.collection(product+"/"+q.module).list(), q.where.match)
+	s.mu.Unlock()
+
+	if q.orderBy != "" {
+		sort.SliceStable(result, func(i, j int) bool {
+			cmp := compareValues(formatValue(result[i][q.orderBy]), formatValue(result[j][q.orderBy]))
+			if q.desc {
+				return cmp > 0
+			}
+			return cmp < 0
+		})
+	}
+
+	more := false
+	if q.offset >= len(result) {
+		result = []Record{}
+	} else {
+		result = result[q.offset:]
+	}
+	if len(result) > q.limit {
+		result, more = result[:q.limit], true
+	}
+
+	if len(result) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"data": selectFields(result, strings.Join(q.fields, ",")),
+		"info": map[string]interface{}{"count": len(result), "more_records": more},
+	})
+}
+
+// writePage writes the page of the records requested by the page and per_page parameters, or a 204 status when
+// there are no records
+func writePage(w http.ResponseWriter, r *http.Request, key string, records []Record) {
+	q := r.URL.Query()
+	p, perPage := intParam(q.Get("page"), 1), intParam(q.Get("per_page"), 200)
+	records, more := page(records, p, perPage)
+	if len(records) == 0 {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		key: records,
+		"info": map[string]interface{}{
+			"per_page":     perPage,
+			"count":        len(records),
+			"page":         p,
+			"more_records": more,
+		},
+	})
+}
+
+// recordResult is the result of an operation on a record
+func recordResult(code, status, message string, details Record) Record {
+	return Record{"code": code, "details": details, "message": message, "status": status}
+}
+
+// resultStatus is the status of a response with a result for each record: 202 if only some records failed,
+// 400 if they all failed
+func resultStatus(total, failed int, created bool) int {
+	switch {
+	case failed == total:
+		return http.StatusBadRequest
+	case failed > 0:
+		return http.StatusAccepted
+	case created:
+		return http.StatusCreated
+	}
+	return http.StatusOK
+}
+
+func errorBody(code, message string) Record {
+	return Record{"code": code, "details": Record{}, "message": message, "status": "error"}
+}
+
+func filterRecords(records []Record, match func(Record) bool) []Record {
+	filtered := []Record{}
+	for _, rec := range records {
+		if match(rec) {
+			filtered = append(filtered, rec)
+		}
+	}
+	return filtered
+}
+
+// selectFields returns the records with only the fields of the comma separated list and their ID, or the
+// records unchanged when the list is empty
+func selectFields(records []Record, fields string) []Record {
+	if fields == "" {
+		return records
+	}
+	selected := make([]Record, len(records))
+	for i, rec := range records {
+		selected[i] = Record{"id": rec["id"]}
+		for _, f := range strings.Split(fields, ",") {
+			f = strings.TrimSpace(f)
+			if v, ok := rec[f]; ok {
+				selected[i][f] = v
+			}
+		}
+	}
+	return selected
+}
+
+// findDuplicate returns the record with the same values for all the fields
+func findDuplicate(c *collection, rec Record, fields []string) (Record, bool) {
+	for _, id := range c.ids {
+		existing := c.records[id]
+		same := false
+		for _, f := range fields {
+			if f == "" {
+				continue
+			}
+			if !sameValue(existing[f], rec[f]) {
+				same = false
+				break
+			}
+			same = true
+		}
+		if same {
+			return existing, true
+		}
+	}
+	return nil, false
+}
+
+// anyField matches records where a field whose name contains the kind, eg. "email", equals the value
+func anyField(value, kind string) func(Record) bool {
+	return func(rec Record) bool {
+		for k, v := range rec {
+			if strings.Contains(strings.ToLower(k), kind) && sameValue(v, value) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// condition is a parsed search criteria or COQL where clause
+type condition struct {
+	field    string
+	operator string
+	values   []string
+
+	joiner string
+	group  []condition
+}
+
+func (c condition) match(rec Record) bool {
+	switch c.joiner {
+	case "and":
+		for _, g := range c.group {
+			if !g.match(rec) {
+				return false
+			}
+		}
+		return true
+	case "or":
+		for _, g := range c.group {
+			if g.match(rec) {
+				return true
+			}
+		}
+		return false
+	}
+	return matches(rec, c.field, c.operator, c.values)
+}
+
+// matches compares the field of the record with the values using an operator of the search criteria syntax
+func matches(rec Record, field, operator string, values []string) bool {
+	v := formatValue(rec[field])
+	switch operator {
+	case "equals":
+		return len(values) == 1 && sameValue(v, values[0])
+	case "not_equal":
+		return len(values) == 1 && !sameValue(v, values[0])
+	case "starts_with":
+		return len(values) == 1 && strings.HasPrefix(strings.ToLower(v), strings.ToLower(values[0]))
+	case "greater_than":
+		return len(values) == 1 && v != "" && compareValues(v, values[0]) > 0
+	case "greater_equal":
+		return len(values) == 1 && v != "" && compareValues(v, values[0]) >= 0
+	case "less_than":
+		return len(values) == 1 && v != "" && compareValues(v, values[0]) < 0
+	case "less_equal":
+		return len(values) == 1 && v != "" && compareValues(v, values[0]) <= 0
+	case "in", "not_in":
+		found := false
+		for _, value := range values {
+			if sameValue(v, value) {
+				found = true
+				break
+			}
+		}
+		return found == (operator == "in")
+	case "between", "not_between":
+		between := len(values) == 2 && v != "" && compareValues(v, values[0]) >= 0 && compareValues(v, values[1]) <= 0
+		return between == (operator == "between")
+	case "like", "not_like":
+		return len(values) == 1 && likeMatch(v, values[0]) == (operator == "like")
+	case "is_null":
+		return v == ""
+	case "is_not_null":
+		return v != ""
+	}
+	return false
+}
+
+// compareValues compares the values as numbers when both are numbers, and as case-insensitive strings otherwise
+func compareValues(a, b string) int {
+	// record IDs exceed the precision of a float64
+	ai, aerr := strconv.ParseInt(a, 10, 64)
+	bi, berr := strconv.ParseInt(b, 10, 64)
+	if aerr == nil && berr == nil {
+		switch {
+		case ai < bi:
+			return -1
+		case ai > bi:
+			return 1
+		}
+		return 0
+	}
+
+	af, aerr := strconv.ParseFloat(a, 64)
+	bf, berr := strconv.ParseFloat(b, 64)
+	if aerr == nil && berr == nil {
+		switch {
+		case af < bf:
+			return -1
+		case af > bf:
+			return 1
+		}
+		return 0
+	}
+	return strings.Compare(strings.ToLower(a), strings.ToLower(b))
+}
+
+// likeMatch matches a value against a COQL like pattern, where % matches any characters
+func likeMatch(v, pattern string) bool {
+	v, pattern = strings.ToLower(v), strings.ToLower(pattern)
+	parts := strings.Split(pattern, "%")
+	if !strings.HasPrefix(v, parts[0]) {
+		return false
+	}
+	v = v[len(parts[0]):]
+	for i, p := range parts[1:] {
+		if i == len(parts)-2 {
+			return strings.HasSuffix(v, p)
+		}
+		idx := strings.Index(v, p)
+		if idx == -1 {
+			return false
+		}
+		v = v[idx+len(p):]
+	}
+	return v == ""
+}
+
+// parseSearchCriteria parses the '((Field:operator:value)and(Field:operator:value))' syntax of SearchRecords
+func parseSearchCriteria(s string) (condition, error) {
+	c, rest, err := parseSearchGroup(s)
+	if err != nil {
+		return condition{}, err
+	}
+	if rest != "" {
+		return condition{}, fmt.Errorf("unexpected '%s' in criteria", rest)
+	}
+	return c, nil
+}
+
+func parseSearchGroup(s string) (condition, string, error) {
+	if !strings.HasPrefix(s, "(") {
+		return condition{}, s, fmt.Errorf("criteria must be in parentheses: '%s'", s)
+	}
+	s = s[1:]
+
+	if !strings.HasPrefix(s, "(") {
+		// a single comparison, values are separated by unescaped commas
+		var (
+			parts   []string
+			current strings.Builder
+		)
+		for i := 0; i < len(s); i++ {
+			switch ch := s[i]; {
+			case ch == '\\' && i+1 < len(s):
+				i++
+				current.WriteByte(s[i])
+			case ch == ')':
+				parts = append(parts, current.String())
+				fields := strings.SplitN(parts[0], ":", 3)
+				if len(fields) != 3 {
+					return condition{}, s, fmt.Errorf("invalid comparison '%s'", parts[0])
+				}
+				values := append([]string{fields[2]}, parts[1:]...)
+				return condition{field: fields[0], operator: fields[1], values: values}, s[i+1:], nil
+			case ch == ',':
+				parts = append(parts, current.String())
+				current.Reset()
+			default:
+				current.WriteByte(ch)
+			}
+		}
+		return condition{}, s, fmt.Errorf("unterminated criteria")
+	}
+
+	group := condition{}
+	for {
+		c, rest, err := parseSearchGroup(s)
+		if err != nil {
+			return condition{}, rest, err
+		}
+		group.group = append(group.group, c)
+
+		switch {
+		case strings.HasPrefix(rest, ")"):
+			if len(group.group) == 1 {
+				return group.group[0], rest[1:], nil
+			}
+			return group, rest[1:], nil
+		case strings.HasPrefix(rest, "and"), strings.HasPrefix(rest, "or"):
+			joiner := "and"
+			if strings.HasPrefix(rest, "or") {
+				joiner = "or"
+			}
+			if group.joiner != "" && group.joiner != joiner {
+				return condition{}, rest, fmt.Errorf("mixed and/or must be grouped in parentheses")
+			}
+			group.joiner = joiner
+			s = rest[len(joiner):]
+		default:
+			return condition{}, rest, fmt.Errorf("expected and, or or ')' at '%s'", rest)
+		}
+	}
+}
+
+// coqlQuery is a parsed COQL select statement
+type coqlQuery struct {
+	fields  []string
+	module  string
+	where   condition
+	orderBy string
+	desc    bool
+	limit   int
+	offset  int
+}
+
+var coqlOperatorNames = map[string]string{
+	"=":           "equals",
+	"!=":          "not_equal",
+	">":           "greater_than",
+	">=":          "greater_equal",
+	"<":           "less_than",
+	"<=":          "less_equal",
+	"in":          "in",
+	"not in":      "not_in",
+	"like":        "like",
+	"not like":    "not_like",
+	"between":     "between",
+	"not between": "not_between",
+	"is null":     "is_null",
+	"is not null": "is_not_null",
+}
+
+// parseCOQL parses the subset of COQL built by crm.SelectQuery:
+// select fields from module where condition [order by field [asc|desc]] [limit n [offset n]]
+func parseCOQL(statement string) (coqlQuery, error) {
+	p := &coqlParser{tokens: tokenizeCOQL(statement)}
+	q := coqlQuery{limit: 200}
+
+	if !p.accept("select") {
+		return q, fmt.Errorf("expected select")
+	}
+	for {
+		q.fields = append(q.fields, p.next())
+		if !p.accept(",") {
+			break
+		}
+	}
+	if !p.accept("from") {
+		return q, fmt.Errorf("expected from")
+	}
+	q.module = p.next()
+	if !p.accept("where") {
+		return q, fmt.Errorf("expected where")
+	}
+
+	where, err := p.parseExpression()
+	if err != nil {
+		return q, err
+	}
+	q.where = where
+
+	if p.accept("order") {
+		if !p.accept("by") {
+			return q, fmt.Errorf("expected by")
+		}
+		q.orderBy = p.next()
+		if p.accept("desc") {
+			q.desc = true
+		} else {
+			p.accept("asc")
+		}
+		// only the first sort is used
+		for p.accept(",") {
+			p.next()
+			if !p.accept("asc") {
+				p.accept("desc")
+			}
+		}
+	}
+	if p.accept("limit") {
+		q.limit = intParam(p.next(), 200)
+		if p.accept("offset") {
+			q.offset = intParam(p.next(), 0)
+		}
+	}
+	if p.pos < len(p.tokens) {
+		return q, fmt.Errorf("unexpected '%s'", p.tokens[p.pos])
+	}
+	return q, nil
+}
+
+type coqlParser struct {
+	tokens []string
+	pos    int
+}
+
+func (p *coqlParser) next() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	p.pos++
+	return p.tokens[p.pos-1]
+}
+
+func (p *coqlParser) peek() string {
+	if p.pos >= len(p.tokens) {
+		return ""
+	}
+	return p.tokens[p.pos]
+}
+
+func (p *coqlParser) accept(token string) bool {
+	if strings.EqualFold(p.peek(), token) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+// parseExpression parses conditions joined by and or or
+func (p *coqlParser) parseExpression() (condition, error) {
+	first, err := p.parseTerm()
+	if err != nil {
+		return condition{}, err
+	}
+
+	group := condition{group: []condition{first}}
+	for {
+		joiner := strings.ToLower(p.peek())
+		if joiner != "and" && joiner != "or" {
+			break
+		}
+		if group.joiner != "" && group.joiner != joiner {
+			return condition{}, fmt.Errorf("mixed and/or must be grouped in parentheses")
+		}
+		p.next()
+		group.joiner = joiner
+
+		c, err := p.parseTerm()
+		if err != nil {
+			return condition{}, err
+		}
+		group.group = append(group.group, c)
+	}
+
+	if len(group.group) == 1 {
+		return first, nil
+	}
+	return group, nil
+}
+
+// parseTerm parses a parenthesized expression or a comparison
+func (p *coqlParser) parseTerm() (condition, error) {
+	if p.accept("(") {
+		c, err := p.parseExpression()
+		if err != nil {
+			return condition{}, err
+		}
+		if !p.accept(")") {
+			return condition{}, fmt.Errorf("expected ')'")
+		}
+		return c, nil
+	}
+
+	field := p.next()
+	op := strings.ToLower(p.next())
+	switch op {
+	case "not":
+		op += " " + strings.ToLower(p.next())
+	case "is":
+		op += " " + strings.ToLower(p.next())
+		if op == "is not" {
+			op += " " + strings.ToLower(p.next())
+		}
+	}
+	name, ok := coqlOperatorNames[op]
+	if !ok {
+		return condition{}, fmt.Errorf("unknown operator '%s'", op)
+	}
+
+	c := condition{field: field, operator: name}
+	switch name {
+	case "is_null", "is_not_null":
+	case "in", "not_in":
+		if !p.accept("(") {
+			return condition{}, fmt.Errorf("expected '(' after %s", op)
+		}
+		for {
+			c.values = append(c.values, unquoteCOQL(p.next()))
+			if !p.accept(",") {
+				break
+			}
+		}
+		if !p.accept(")") {
+			return condition{}, fmt.Errorf("expected ')'")
+		}
+	case "between", "not_between":
+		c.values = append(c.values, unquoteCOQL(p.next()))
+		if !p.accept("and") {
+			return condition{}, fmt.Errorf("expected and in %s", op)
+		}
+		c.values = append(c.values, unquoteCOQL(p.next()))
+	default:
+		c.values = []string{unquoteCOQL(p.next())}
+	}
+	return c, nil
+}
+
+// tokenizeCOQL splits a statement into words, quoted strings, operators and punctuation. Quoted strings keep
+// their quotes so they can not be mistaken for keywords.
+func tokenizeCOQL(s string) []string {
+	tokens := []string{}
+	for i := 0; i < len(s); {
+		ch := s[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n':
+			i++
+		case ch == '(' || ch == ')' || ch == ',':
+			tokens = append(tokens, string(ch))
+			i++
+		case ch == '\'':
+			var b strings.Builder
+			b.WriteByte(ch)
+			for i++; i < len(s); i++ {
+				if s[i] == '\\' && i+1 < len(s) {
+					i++
+					b.WriteByte(s[i])
+					continue
+				}
+				if s[i] == '\'' {
+					i++
+					break
+				}
+				b.WriteByte(s[i])
+			}
+			b.WriteByte('\'')
+			tokens = append(tokens, b.String())
+		case strings.ContainsRune("=!<>", rune(ch)):
+			j := i + 1
+			for j < len(s) && strings.ContainsRune("=!<>", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		default:
+			j := i
+			for j < len(s) && !strings.ContainsRune(" \t\n(),'=!<>", rune(s[j])) {
+				j++
+			}
+			tokens = append(tokens, s[i:j])
+			i = j
+		}
+	}
+	return tokens
+}
+
+func unquoteCOQL(token string) string {
+	if len(token) >= 2 && strings.HasPrefix(token, "'") && strings.HasSuffix(token, "'") {
+		return token[1 : len(token)-1]
+	}
+	return token
+}
@@ -0,0 +1,91 @@
+package zohotest
+
+import (
+	"reflect"
+	"testing"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/crm"
+)
+
+func TestParseSearchCriteria(t *testing.T) {
+	got, err := parseSearchCriteria(`((Last_Name:equals:Smith \(Jr\)\, \\ Sr)or(Lead_Source:in:Web,Email))`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := condition{joiner: "or", group: []condition{
+		{field: "Last_Name", operator: "equals", values: []string{`Smith (Jr), \ Sr`}},
+		{field: "Lead_Source", operator: "in", values: []string{"Web", "Email"}},
+	}}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsed %+v, want %+v", got, want)
+	}
+
+	for _, s := range []string{"Last_Name:equals:Smith", "(Last_Name:equals:Smith", "(Last_Name:equals:Smith)x"} {
+		if _, err := parseSearchCriteria(s); err == nil {
+			t.Errorf("parseSearchCriteria(%s) did not return an error", s)
+		}
+	}
+}
+
+func TestParseCOQL(t *testing.T) {
+	got, err := parseCOQL(`select Last_Name, Email from Leads where ((Last_Name = 'O\'Brien') and (Amount between 10 and 20)) order by Created_Time desc limit 50 offset 100`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := coqlQuery{
+		fields: []string{"Last_Name", "Email"},
+		module: "Leads",
+		where: condition{joiner: "and", group: []condition{
+			{field: "Last_Name", operator: "equals", values: []string{"O'Brien"}},
+			{field: "Amount", operator: "between", values: []string{"10", "20"}},
+		}},
+		orderBy: "Created_Time",
+		desc:    true,
+		limit:   50,
+		offset:  100,
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parsed %+v, want %+v", got, want)
+	}
+
+	for _, s := range []string{"Last_Name from Leads", "select Last_Name where (Last_Name = 'x')"} {
+		if _, err := parseCOQL(s); err == nil {
+			t.Errorf("parseCOQL(%s) did not return an error", s)
+		}
+	}
+}
+
+// TestCriteriaRoundTrip checks that values escaped by the crm package match the seeded records
+func TestCriteriaRoundTrip(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	names := []string{`Smith (Jr), \ Sr`, "O'Brien", "Jones"}
+	for _, name := range names {
+		srv.Seed("crm/Leads", Record{"Last_Name": name})
+	}
+	c := crm.New(srv.Zoho())
+
+	for _, name := range names[:2] {
+		criteria, err := crm.Where("Last_Name", crm.Equals, name).Parameter()
+		if err != nil {
+			t.Fatal(err)
+		}
+		v, err := c.SearchRecords(&crm.COQLResponse{}, crm.LeadsModule, map[string]zoho.Parameter{"criteria": criteria})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows := v.(*crm.COQLResponse).Data; len(rows) != 1 || rows[0]["Last_Name"] != name {
+			t.Errorf("search for %s returned %v", name, rows)
+		}
+
+		statement := crm.Select("Last_Name").From(crm.LeadsModule).Where(crm.Where("Last_Name", crm.Equals, name)).String()
+		v, err = c.QueryRecords(&crm.COQLResponse{}, statement)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rows := v.(*crm.COQLResponse).Data; len(rows) != 1 || rows[0]["Last_Name"] != name {
+			t.Errorf("COQL query for %s returned %v", name, rows)
+		}
+	}
+}
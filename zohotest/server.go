@@ -0,0 +1,314 @@
+// Package zohotest provides an in-memory Zoho server for testing code using this module without network access.
+// The server mimics the OAuth token endpoints and the CRM, Recruit, Books, Invoice, Subscriptions and Shifts routes
+// used by the product packages, holding the records created through them, and can inject failures.
+//
+//    srv := zohotest.NewServer()
+//    defer srv.Close()
+//    srv.Seed("crm/Leads", zohotest.Record{"Last_Name": "Smith"})
+//
+//    records, err := crm.New(srv.Zoho()).ListRecords(&crm.COQLResponse{}, crm.LeadsModule, nil)
+package zohotest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+
+	zoho "github.com/schmorrison/Zoho"
+)
+
+// Record is a record held by the server, as decoded from JSON
+type Record map[string]interface{}
+
+// Request is a request received by the server
+type Request struct {
+	Method string
+	// Host is the host the request was sent to before zoho.SetBaseURL replaced it, if known
+	Host  string
+	Path  string
+	Query url.Values
+	Body  []byte
+}
+
+// Failure is a response returned instead of handling matching requests
+type Failure struct {
+	// Path restricts the failure to requests whose path contains it, eg. "/crm/v2/Leads". When empty every
+	// request except those to the OAuth endpoints fails.
+	Path   string
+	Status int
+	Body   string
+	// Times is the number of requests failed, by default 1
+	Times int
+}
+
+// Server is an in-memory Zoho server, see NewServer
+type Server struct {
+	*httptest.Server
+
+	// TokenExpiresIn is the lifetime in seconds of the access tokens issued, by default 3600
+	TokenExpiresIn int
+	// User is returned by the user info endpoint
+	User zoho.UserInfo
+
+	mu           sync.Mutex
+	collections  map[string]*collection
+	failures     []*Failure
+	recordErrors []recordError
+	requests     []Request
+	accessTokens map[string]bool
+	refreshToken string
+	nextID       int64
+}
+
+// NewServer starts a Server issuing a first access token and refresh token, see Zoho
+func NewServer() *Server {
+	s := &Server{
+		TokenExpiresIn: 3600,
+		User: zoho.UserInfo{
+			FirstName:   "Test",
+			LastName:    "User",
+			DisplayName: "Test User",
+			Email:       "test.user@example.com",
+			ZUID:        10000001,
+		},
+		collections:  map[string]*collection{},
+		accessTokens: map[string]bool{},
+		refreshToken: "zohotest-refresh-token",
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// Zoho returns a *zoho.Zoho sending its requests to the server, holding a valid access token and refresh token in
+// memory. The *zoho.Zoho can be passed to the New function of any product package.
+func (s *Server) Zoho() *zoho.Zoho {
+	z := zoho.New()
+	z.CustomHTTPClient(s.Client())
+	if err := z.SetBaseURL(s.URL); err != nil {
+		panic(err)
+	}
+	z.SetClientID("zohotest-client-id")
+	z.SetClientSecret("zohotest-client-secret")
+	z.SetTokenManager(&TokenStore{Token: s.issueToken()})
+	return z
+}
+
+// Seed adds the records to the collection, eg. "crm/Leads", "books/contacts" or "shifts/employees", and returns
+// their IDs. Records without an ID are given one.
+func (s *Server) Seed(collection string, records ...Record) []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	c := s.collection(collection)
+	ids := make([]string, len(records))
+	for i, r := range records {
+		ids[i] = c.put(s.copyWithID(r, c.idField))
+	}
+	return ids
+}
+
+// Records returns a copy of the records of the collection, in the order they were created
+func (s *Server) Records(collection string) []Record {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.collection(collection).list()
+}
+
+// Requests returns the requests received by the server
+func (s *Server) Requests() []Request {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Request{}, s.requests...)
+}
+
+// Fail makes the server return the failure to matching requests
+func (s *Server) Fail(f Failure) {
+	if f.Times <= 0 {
+		f.Times = 1
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.failures = append(s.failures, &f)
+}
+
+// RateLimit makes the next requests fail with a 429 status, like when the API limits of Zoho are exceeded
+func (s *Server) RateLimit(times int) {
+	s.Fail(Failure{
+		Status: http.StatusTooManyRequests,
+		Body:   `{"code":"TOO_MANY_REQUESTS","details":{},"message":"The number of API requests has exceeded the limit","status":"error"}`,
+		Times:  times,
+	})
+}
+
+// InvalidateTokens invalidates the access tokens issued, requests with them fail with a 401 status and the
+// INVALID_TOKEN code until a new access token is issued, eg. by refreshing
+func (s *Server) InvalidateTokens() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accessTokens = map[string]bool{}
+}
+
+// FailRecords makes the records of the collection inserted or updated with the field set to the value fail with
+// an INVALID_DATA error, while the other records of the request succeed
+func (s *Server) FailRecords(collection, field string, value interface{}) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.recordErrors = append(s.recordErrors, recordError{collection: collection, field: field, value: value})
+}
+
+type recordError struct {
+	collection string
+	field      string
+	value      interface{}
+}
+
+// recordFails reports whether the record matches a record error of the collection
+func (s *Server) recordFails(collection string, r Record) (string, bool) {
+	for _, e := range s.recordErrors {
+		if e.collection == collection && sameValue(r[e.field], e.value) {
+			return e.field, true
+		}
+	}
+	return "", false
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	body, _ := ioutil.ReadAll(r.Body)
+	r.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	host := r.Header.Get(zoho.OriginalHostHeader)
+	s.mu.Lock()
+	s.requests = append(s.requests, Request{
+		Method: r.Method,
+		Host:   host,
+		Path:   r.URL.Path,
+		Query:  r.URL.Query(),
+		Body:   body,
+	})
+	s.mu.Unlock()
+
+	oauth := strings.HasPrefix(r.URL.Path, "/oauth/")
+	if f := s.failure(r.URL.Path, oauth); f != nil {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(f.Status)
+		w.Write([]byte(f.Body))
+		return
+	}
+
+	if oauth {
+		s.serveOAuth(w, r)
+		return
+	}
+
+	if !s.authorized(r) {
+		writeJSON(w, http.StatusUnauthorized, map[string]interface{}{
+			"code":    "INVALID_TOKEN",
+			"details": map[string]interface{}{},
+			"message": "invalid oauth token",
+			"status":  "error",
+		})
+		return
+	}
+
+	switch product(host, r.URL.Path) {
+	case "crm":
+		s.serveRecords(w, r, "crm", body)
+	case "recruit":
+		s.serveRecords(w, r, "recruit", body)
+	case "books", "invoice", "subscriptions":
+		s.serveFinance(w, r, product(host, r.URL.Path), body)
+	case "shifts":
+		s.serveShifts(w, r, body)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+// failure returns the failure matching the path and counts it, or nil
+func (s *Server) failure(path string, oauth bool) *Failure {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i, f := range s.failures {
+		if (f.Path == "" && oauth) || !strings.Contains(path, f.Path) {
+			continue
+		}
+		f.Times--
+		if f.Times == 0 {
+			s.failures = append(s.failures[:i], s.failures[i+1:]...)
+		}
+		return f
+	}
+	return nil
+}
+
+// authorized reports whether the request has a valid access token, or an API key
+func (s *Server) authorized(r *http.Request) bool {
+	if r.URL.Query().Get("zapikey") != "" {
+		return true
+	}
+
+	token := strings.TrimPrefix(r.Header.Get("Authorization"), "Zoho-oauthtoken ")
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.accessTokens[token]
+}
+
+// product returns the product a request was sent to, from its original host or else its path
+func product(host, path string) string {
+	for _, p := range []string{"recruit", "books", "invoice", "subscriptions", "shifts"} {
+		if strings.HasPrefix(host, p+".") {
+			return p
+		}
+	}
+
+	switch {
+	case strings.HasPrefix(path, "/crm/"):
+		return "crm"
+	case strings.HasPrefix(path, "/recruit/"):
+		return "recruit"
+	case strings.HasPrefix(path, "/api/v3/"):
+		return "books"
+	case strings.HasPrefix(path, "/api/v1/"):
+		if _, ok := financeResources[strings.SplitN(strings.TrimPrefix(path, "/api/v1/"), "/", 2)[0]]; ok {
+			return "subscriptions"
+		}
+		return "shifts"
+	}
+	return ""
+}
+
+// collection returns the collection, creating it if needed. The server must be locked.
+func (s *Server) collection(name string) *collection {
+	c, ok := s.collections[name]
+	if !ok {
+		c = newCollection(idField(name))
+		s.collections[name] = c
+	}
+	return c
+}
+
+// copyWithID returns a copy of the record, with a new ID if it has none. The server must be locked.
+func (s *Server) copyWithID(r Record, idField string) Record {
+	c := Record{}
+	for k, v := range r {
+		c[k] = v
+	}
+	if id, _ := c[idField].(string); id == "" {
+		s.nextID++
+		c[idField] = fmt.Sprintf("%d", 4000000000000000000+s.nextID)
+	}
+	return c
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.Header().Set("Content-Type", "application/json;charset=UTF-8")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,144 @@
+package zohotest
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	zoho "github.com/schmorrison/Zoho"
+	"github.com/schmorrison/Zoho/crm"
+)
+
+func TestServerCRUD(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := crm.New(srv.Zoho())
+
+	inserted, err := c.InsertRecords(crm.InsertRecordsData{
+		Data: []map[string]interface{}{{"Last_Name": "Smith", "Company": "Acme"}},
+	}, crm.LeadsModule)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(inserted.Data) != 1 || inserted.Data[0].Code != "SUCCESS" || inserted.Data[0].Details.ID == "" {
+		t.Fatalf("insert returned %+v", inserted)
+	}
+	id := inserted.Data[0].Details.ID
+
+	_, err = c.UpdateRecord(crm.UpdateRecordData{
+		Data: []map[string]interface{}{{"Company": "Acme Corp"}},
+	}, crm.LeadsModule, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	v, err := c.GetRecord(&crm.COQLResponse{}, crm.LeadsModule, id)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got := v.(*crm.COQLResponse).Data
+	if len(got) != 1 || got[0]["Last_Name"] != "Smith" || got[0]["Company"] != "Acme Corp" {
+		t.Errorf("get returned %v", got)
+	}
+
+	if _, err := c.DeleteRecord(crm.LeadsModule, id); err != nil {
+		t.Fatal(err)
+	}
+	if records := srv.Records("crm/Leads"); len(records) != 0 {
+		t.Errorf("%d records remain after delete", len(records))
+	}
+}
+
+func TestServerPagination(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	for i := 0; i < 250; i++ {
+		srv.Seed("crm/Leads", Record{"Last_Name": fmt.Sprintf("Lead %03d", i)})
+	}
+	c := crm.New(srv.Zoho())
+
+	tests := []struct {
+		page  string
+		count int
+		more  bool
+	}{
+		{"1", 200, true},
+		{"2", 50, false},
+	}
+	for _, tt := range tests {
+		v, err := c.ListRecords(&crm.COQLResponse{}, crm.LeadsModule, map[string]zoho.Parameter{"page": zoho.Parameter(tt.page)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		res := v.(*crm.COQLResponse)
+		if len(res.Data) != tt.count || res.Info.MoreRecords != tt.more {
+			t.Errorf("page %s: got %d records, more %t, want %d, more %t", tt.page, len(res.Data), res.Info.MoreRecords, tt.count, tt.more)
+		}
+	}
+}
+
+func TestServerRateLimit(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := crm.New(srv.Zoho())
+
+	srv.RateLimit(1)
+	_, err := c.ListRecords(&crm.COQLResponse{}, crm.LeadsModule, nil)
+	if err == nil || !strings.Contains(err.Error(), "TOO_MANY_REQUESTS") {
+		t.Fatalf("rate limited request returned error %v", err)
+	}
+
+	if _, err := c.ListRecords(&crm.COQLResponse{}, crm.LeadsModule, nil); err != nil {
+		t.Errorf("request after the rate limit returned error: %s", err)
+	}
+}
+
+func TestServerInvalidateTokens(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	z := srv.Zoho()
+	c := crm.New(z)
+
+	srv.InvalidateTokens()
+	_, err := c.ListRecords(&crm.COQLResponse{}, crm.LeadsModule, nil)
+	if err == nil || !strings.Contains(err.Error(), "INVALID_TOKEN") {
+		t.Fatalf("request with an invalid token returned error %v", err)
+	}
+
+	if err := z.RefreshTokenRequest(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ListRecords(&crm.COQLResponse{}, crm.LeadsModule, nil); err != nil {
+		t.Errorf("request after refreshing the token returned error: %s", err)
+	}
+
+	var refreshed bool
+	for _, r := range srv.Requests() {
+		if r.Path == "/oauth/v2/token" && r.Query.Get("grant_type") == "refresh_token" {
+			refreshed = r.Query.Get("refresh_token") == "zohotest-refresh-token"
+		}
+	}
+	if !refreshed {
+		t.Error("refresh token request was not sent with the refresh token")
+	}
+}
+
+func TestServerFailRecords(t *testing.T) {
+	srv := NewServer()
+	defer srv.Close()
+	c := crm.New(srv.Zoho())
+
+	srv.FailRecords("crm/Leads", "Email", "invalid")
+	_, err := c.InsertRecords(crm.InsertRecordsData{
+		Data: []map[string]interface{}{{"Last_Name": "Smith"}, {"Last_Name": "Jones", "Email": "invalid"}},
+	}, crm.LeadsModule)
+	// a response with a record in error is returned as an error by zoho.HTTPRequest
+	if err == nil || !strings.Contains(err.Error(), "INVALID_DATA") {
+		t.Errorf("partial failure returned error %v", err)
+	}
+
+	records := srv.Records("crm/Leads")
+	if len(records) != 1 || records[0]["Last_Name"] != "Smith" {
+		t.Errorf("records after partial failure: %v", records)
+	}
+}
@@ -0,0 +1,114 @@
+package zohotest
+
+import (
+	"net/http"
+	"strings"
+)
+
+// shiftsResources maps the paths of the Shifts resources, after the organization ID, to the key holding their
+// records in list responses
+var shiftsResources = map[string]string{
+	"schedules/shifts":       "shifts",
+	"schedules/availability": "availabilities",
+	"employees":              "employees",
+	"timesheets":             "time_entries",
+	"settings/schedules":     "schedules",
+	"settings/positions":     "positions",
+	"settings/jobsites":      "job_sites",
+	"timeoff/requests":       "time_off_requests",
+}
+
+// serveShifts handles the Shifts API, whose routes are /api/v1/{organization}/{resource}[/{id}[/{action}]]
+func (s *Server) serveShifts(w http.ResponseWriter, r *http.Request, body []byte) {
+	parts := strings.Split(strings.Trim(strings.TrimPrefix(r.URL.Path, "/api/v1/"), "/"), "/")
+	if len(parts) < 2 {
+		http.NotFound(w, r)
+		return
+	}
+	parts = parts[1:]
+
+	path, key := "", ""
+	for n := 2; n >= 1; n-- {
+		if len(parts) >= n {
+			if k, ok := shiftsResources[strings.Join(parts[:n], "/")]; ok {
+				path, key, parts = strings.Join(parts[:n], "/"), k, parts[n:]
+				break
+			}
+		}
+	}
+	if path == "" {
+		http.NotFound(w, r)
+		return
+	}
+	name := "shifts/" + path
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	c := s.collection(name)
+
+	switch {
+	case len(parts) == 0 && r.Method == http.MethodGet:
+		q := r.URL.Query()
+		records := c.list()
+		p, limit := intParam(q.Get("page"), 1), intParam(q.Get("limit"), 50)
+		records, _ = page(records, p, limit)
+		writeJSON(w, http.StatusOK, Record{key: records})
+
+	case len(parts) == 0 && r.Method == http.MethodPost:
+		records, err := decodeRecords(body, "")
+		if err != nil {
+			writeJSON(w, http.StatusBadRequest, Record{"message": err.Error()})
+			return
+		}
+		if field, ok := s.recordFails(name, records[0]); ok {
+			writeJSON(w, http.StatusBadRequest, Record{"message": "invalid value for " + field})
+			return
+		}
+		rec := s.copyWithID(records[0], c.idField)
+		c.put(rec)
+		writeJSON(w, http.StatusCreated, rec)
+
+	case len(parts) == 1 && !isShiftsAction(parts[0]):
+		rec, ok := c.get(parts[0])
+		if !ok {
+			writeJSON(w, http.StatusNotFound, Record{"message": "resource not found"})
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet:
+			writeJSON(w, http.StatusOK, rec)
+		case http.MethodPut, http.MethodPatch:
+			records, err := decodeRecords(body, "")
+			if err != nil {
+				writeJSON(w, http.StatusBadRequest, Record{"message": err.Error()})
+				return
+			}
+			if field, ok := s.recordFails(name, records[0]); ok {
+				writeJSON(w, http.StatusBadRequest, Record{"message": "invalid value for " + field})
+				return
+			}
+			merge(rec, records[0])
+			rec[c.idField] = parts[0]
+			writeJSON(w, http.StatusOK, rec)
+		case http.MethodDelete:
+			c.delete(parts[0])
+			writeJSON(w, http.StatusOK, Record{"message": "deleted successfully"})
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+
+	default:
+		// actions such as activating employees or approving time off
+		writeJSON(w, http.StatusOK, Record{"message": "success"})
+	}
+}
+
+// isShiftsAction reports whether the path segment is an action on several records rather than an ID
+func isShiftsAction(segment string) bool {
+	switch segment {
+	case "activate", "deactivate", "invite", "publish", "unpublish":
+		return true
+	}
+	return false
+}